@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/client/clientset"
+)
+
+// ACMEAccountOptions holds the configuration for the `cmctl acme
+// rollover-account-key` and `cmctl acme deactivate-account` commands.
+type ACMEAccountOptions struct {
+	Namespace     string
+	IssuerName    string
+	Annotation    string
+	APIServerHost string
+	Kubeconfig    string
+
+	CMClient clientset.Interface
+
+	StdOut io.Writer
+	StdErr io.Writer
+}
+
+// NewCmdACME returns a cobra command grouping ACME account maintenance
+// sub-commands.
+func NewCmdACME(out, errOut io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "acme",
+		Short: "Manage ACME issuer accounts",
+	}
+
+	cmd.AddCommand(NewCmdACMERolloverAccountKey(out, errOut))
+	cmd.AddCommand(NewCmdACMEDeactivateAccount(out, errOut))
+
+	return cmd
+}
+
+// NewCmdACMERolloverAccountKey returns a cobra command that marks an ACME
+// Issuer's account for rollover onto a newly generated private key.
+func NewCmdACMERolloverAccountKey(out, errOut io.Writer) *cobra.Command {
+	o := &ACMEAccountOptions{StdOut: out, StdErr: errOut, Annotation: v1alpha1.AnnotationACMERolloverAccountKey}
+
+	cmd := &cobra.Command{
+		Use:   "rollover-account-key ISSUER_NAME",
+		Short: "Roll an ACME issuer's account over onto a newly generated private key",
+		Long: `rollover-account-key marks an ACME Issuer for account key rollover by
+setting the ` + v1alpha1.AnnotationACMERolloverAccountKey + ` annotation,
+which the controller removes once the rollover has been triggered.
+
+This registers a new ACME account using a freshly generated private key and
+switches the issuer over to it, so a compromised account key can be retired
+without manual calls to the ACME server.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.complete(args)
+		},
+	}
+
+	o.addFlags(cmd)
+	return cmd
+}
+
+// NewCmdACMEDeactivateAccount returns a cobra command that marks an ACME
+// Issuer's account for deactivation.
+func NewCmdACMEDeactivateAccount(out, errOut io.Writer) *cobra.Command {
+	o := &ACMEAccountOptions{StdOut: out, StdErr: errOut, Annotation: v1alpha1.AnnotationACMEDeactivateAccount}
+
+	cmd := &cobra.Command{
+		Use:   "deactivate-account ISSUER_NAME",
+		Short: "Deactivate an ACME issuer's account with the ACME server",
+		Long: `deactivate-account marks an ACME Issuer's account for deactivation by
+setting the ` + v1alpha1.AnnotationACMEDeactivateAccount + ` annotation,
+which the controller removes once deactivation has been attempted.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.complete(args)
+		},
+	}
+
+	o.addFlags(cmd)
+	return cmd
+}
+
+func (o *ACMEAccountOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "default", "namespace containing the Issuer")
+	cmd.Flags().StringVar(&o.APIServerHost, "master", "", "optional apiserver host address to connect to, if not using a kubeconfig")
+	cmd.Flags().StringVar(&o.Kubeconfig, "kubeconfig", "", "path to a kubeconfig file, if not using in-cluster config")
+}
+
+func (o *ACMEAccountOptions) complete(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("exactly one argument (the Issuer name) must be provided")
+	}
+	o.IssuerName = args[0]
+
+	cfg, err := clientcmd.BuildConfigFromFlags(o.APIServerHost, o.Kubeconfig)
+	if err != nil {
+		return fmt.Errorf("error building kubeconfig: %s", err.Error())
+	}
+
+	cl, err := clientset.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error creating cert-manager client: %s", err.Error())
+	}
+	o.CMClient = cl
+
+	return o.run()
+}
+
+// run sets the configured annotation on the named Issuer.
+func (o *ACMEAccountOptions) run() error {
+	iss, err := o.CMClient.CertmanagerV1alpha1().Issuers(o.Namespace).Get(o.IssuerName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting issuer %s/%s: %s", o.Namespace, o.IssuerName, err.Error())
+	}
+
+	if iss.Spec.ACME == nil {
+		return fmt.Errorf("issuer %s/%s is not an ACME issuer", o.Namespace, o.IssuerName)
+	}
+
+	updated := iss.DeepCopy()
+	if updated.Annotations == nil {
+		updated.Annotations = make(map[string]string)
+	}
+	updated.Annotations[o.Annotation] = "true"
+
+	if _, err := o.CMClient.CertmanagerV1alpha1().Issuers(o.Namespace).Update(updated); err != nil {
+		return fmt.Errorf("error updating issuer %s/%s: %s", o.Namespace, o.IssuerName, err.Error())
+	}
+
+	fmt.Fprintf(o.StdOut, "issuer %s/%s marked for %s\n", o.Namespace, o.IssuerName, o.Annotation)
+	return nil
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/client/clientset"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/pki"
+)
+
+// AdoptSecretOptions holds the configuration for the `cmctl adopt-secret` command.
+type AdoptSecretOptions struct {
+	Namespace       string
+	SecretName      string
+	CertificateName string
+	IssuerName      string
+
+	APIServerHost string
+	Kubeconfig    string
+
+	Client   kubernetes.Interface
+	CMClient clientset.Interface
+
+	StdOut io.Writer
+	StdErr io.Writer
+}
+
+// NewCmdAdoptSecret returns a cobra command that generates a Certificate
+// resource matching an existing TLS Secret, so cert-manager can take over its
+// renewal without cert-manager re-issuing it immediately.
+func NewCmdAdoptSecret(out, errOut io.Writer) *cobra.Command {
+	o := &AdoptSecretOptions{StdOut: out, StdErr: errOut}
+
+	cmd := &cobra.Command{
+		Use:   "adopt-secret SECRET_NAME",
+		Short: "Generate a Certificate resource for an existing TLS Secret",
+		Long: `adopt-secret reads an existing kubernetes.io/tls Secret - for example one
+managed by kube-lego, or populated by hand from an openssl-issued certificate
+- and creates a matching Certificate resource, so cert-manager takes over its
+renewal going forward.
+
+The domains and secretName on the generated Certificate are derived from the
+Secret's own ` + api.TLSCertKey + ` entry, rather than from any external
+source, so that cert-manager's usual "is the existing certificate still valid
+for spec.domains" check passes immediately: the Secret is left untouched, and
+no certificate is issued until the existing one actually needs renewing.
+
+--issuer must name an existing, ready Issuer in the same namespace; it is not
+validated by this command, only by the cert-manager controller once the
+Certificate is created.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("exactly one argument (the Secret name) must be provided")
+			}
+			o.SecretName = args[0]
+
+			if err := o.Validate(); err != nil {
+				return err
+			}
+
+			if err := o.Complete(); err != nil {
+				return err
+			}
+
+			return o.Run()
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "default", "namespace containing the Secret to adopt")
+	cmd.Flags().StringVar(&o.CertificateName, "certificate-name", "", "name for the generated Certificate resource (defaults to the Secret name)")
+	cmd.Flags().StringVar(&o.IssuerName, "issuer", "", "name of the Issuer that should take over renewal of this certificate (required)")
+	cmd.Flags().StringVar(&o.APIServerHost, "master", "", "optional apiserver host address to connect to, if not using a kubeconfig")
+	cmd.Flags().StringVar(&o.Kubeconfig, "kubeconfig", "", "path to a kubeconfig file, if not using in-cluster config")
+
+	return cmd
+}
+
+// Validate checks the required flags are set.
+func (o *AdoptSecretOptions) Validate() error {
+	if o.IssuerName == "" {
+		return fmt.Errorf("--issuer must be set to the Issuer that should take over renewal")
+	}
+	return nil
+}
+
+// Complete finalises AdoptSecretOptions, building the Kubernetes and
+// cert-manager clients from the configured kubeconfig/master flags.
+func (o *AdoptSecretOptions) Complete() error {
+	cfg, err := clientcmd.BuildConfigFromFlags(o.APIServerHost, o.Kubeconfig)
+	if err != nil {
+		return fmt.Errorf("error building kubeconfig: %s", err.Error())
+	}
+
+	cl, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error creating kubernetes client: %s", err.Error())
+	}
+	o.Client = cl
+
+	cmClient, err := clientset.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error creating cert-manager client: %s", err.Error())
+	}
+	o.CMClient = cmClient
+
+	return nil
+}
+
+// Run reads the target Secret, derives a Certificate spec from its current
+// certificate, and creates it.
+func (o *AdoptSecretOptions) Run() error {
+	secret, err := o.Client.CoreV1().Secrets(o.Namespace).Get(o.SecretName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting secret %s/%s: %s", o.Namespace, o.SecretName, err.Error())
+	}
+
+	cert, err := pki.DecodeX509CertificateBytes(secret.Data[api.TLSCertKey])
+	if err != nil {
+		return fmt.Errorf("error parsing certificate in secret %s/%s: %s", o.Namespace, o.SecretName, err.Error())
+	}
+
+	if len(cert.DNSNames) == 0 {
+		return fmt.Errorf("certificate in secret %s/%s has no DNS SAN entries to adopt", o.Namespace, o.SecretName)
+	}
+
+	certificateName := o.CertificateName
+	if certificateName == "" {
+		certificateName = o.SecretName
+	}
+
+	crt := &v1alpha1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      certificateName,
+			Namespace: o.Namespace,
+		},
+		Spec: v1alpha1.CertificateSpec{
+			SecretName: o.SecretName,
+			Domains:    cert.DNSNames,
+			Issuer:     o.IssuerName,
+		},
+	}
+
+	if _, err := o.CMClient.CertmanagerV1alpha1().Certificates(o.Namespace).Create(crt); err != nil {
+		return fmt.Errorf("error creating certificate %s/%s: %s", o.Namespace, certificateName, err.Error())
+	}
+
+	fmt.Fprintf(o.StdOut, "certificate %s/%s created for existing secret %s, valid until %s; cert-manager will take over renewal without re-issuing early\n", o.Namespace, certificateName, o.SecretName, cert.NotAfter)
+	return nil
+}
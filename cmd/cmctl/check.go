@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/client/clientset"
+	"github.com/jetstack-experimental/cert-manager/pkg/crds"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/kube"
+)
+
+// CheckAPIOptions holds the configuration for the `cmctl check api` command.
+type CheckAPIOptions struct {
+	Namespace string
+
+	APIServerHost string
+	Kubeconfig    string
+
+	CRDClient apiextensionsclientset.Interface
+	CMClient  clientset.Interface
+
+	StdOut io.Writer
+	StdErr io.Writer
+}
+
+// NewCmdCheck returns a cobra command grouping cert-manager readiness checks.
+func NewCmdCheck(out, errOut io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check cert-manager's installation state",
+	}
+
+	cmd.AddCommand(NewCmdCheckAPI(out, errOut))
+
+	return cmd
+}
+
+// NewCmdCheckAPI returns a cobra command that confirms cert-manager's
+// CustomResourceDefinitions are installed and its API is serving requests.
+func NewCmdCheckAPI(out, errOut io.Writer) *cobra.Command {
+	o := &CheckAPIOptions{StdOut: out, StdErr: errOut}
+
+	cmd := &cobra.Command{
+		Use:   "api",
+		Short: "Check that cert-manager's CRDs are installed and its API is serving",
+		Long: `check api verifies that every CustomResourceDefinition cert-manager
+requires is installed and up to date, then creates and immediately deletes a
+throwaway Certificate resource to confirm the certmanager.k8s.io API is
+actually accepting requests, rather than just registered.
+
+This build of cert-manager has no admission webhook component, so there is
+nothing to check beyond CRD registration and API availability.
+
+It exits non-zero on the first failure, making it suitable as a Helm
+post-install hook or a CI step that waits for cert-manager to become ready.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(); err != nil {
+				return err
+			}
+
+			return o.Run()
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "default", "namespace to create the throwaway Certificate resource in")
+	cmd.Flags().StringVar(&o.APIServerHost, "master", "", "optional apiserver host address to connect to, if not using a kubeconfig")
+	cmd.Flags().StringVar(&o.Kubeconfig, "kubeconfig", "", "path to a kubeconfig file, if not using in-cluster config")
+
+	return cmd
+}
+
+// Complete finalises CheckAPIOptions, building the apiextensions and
+// cert-manager clients from the configured kubeconfig/master flags.
+func (o *CheckAPIOptions) Complete() error {
+	cfg, err := clientcmd.BuildConfigFromFlags(o.APIServerHost, o.Kubeconfig)
+	if err != nil {
+		return fmt.Errorf("error building kubeconfig: %s", err.Error())
+	}
+
+	crdClient, err := apiextensionsclientset.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error creating apiextensions client: %s", err.Error())
+	}
+	o.CRDClient = crdClient
+
+	cmClient, err := clientset.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error creating cert-manager client: %s", err.Error())
+	}
+	o.CMClient = cmClient
+
+	return nil
+}
+
+// Run verifies cert-manager's CRDs are installed, then round-trips a
+// throwaway Certificate through the API to confirm it is serving requests.
+// The vendored client-go predates server-side dry-run, so an actual
+// create/delete is used in its place.
+func (o *CheckAPIOptions) Run() error {
+	ctx := context.Background()
+
+	for _, crd := range crds.All() {
+		if err := kube.VerifyCRD(ctx, o.CRDClient, crd); err != nil {
+			return err
+		}
+		fmt.Fprintf(o.StdOut, "CustomResourceDefinition %s is installed and up to date\n", crd.Name)
+	}
+
+	probe := &v1alpha1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "cert-manager-check-api-"},
+		Spec: v1alpha1.CertificateSpec{
+			SecretName: "cert-manager-check-api",
+			Domains:    []string{"cert-manager-check-api.invalid"},
+		},
+	}
+
+	created, err := o.CMClient.CertmanagerV1alpha1().Certificates(o.Namespace).Create(probe)
+	if err != nil {
+		return fmt.Errorf("error creating throwaway Certificate to check the API is serving: %s", err.Error())
+	}
+
+	if err := o.CMClient.CertmanagerV1alpha1().Certificates(o.Namespace).Delete(created.Name, &metav1.DeleteOptions{}); err != nil {
+		return fmt.Errorf("error deleting throwaway Certificate %s/%s created to check the API is serving: %s", o.Namespace, created.Name, err.Error())
+	}
+
+	fmt.Fprintln(o.StdOut, "certmanager.k8s.io API is serving requests")
+	return nil
+}
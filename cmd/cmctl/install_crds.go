@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/crds"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/kube"
+)
+
+// InstallCRDsOptions holds the configuration for the `cmctl install-crds`
+// command.
+type InstallCRDsOptions struct {
+	Verify bool
+
+	APIServerHost string
+	Kubeconfig    string
+
+	Client apiextensionsclientset.Interface
+
+	StdOut io.Writer
+	StdErr io.Writer
+}
+
+// NewCmdInstallCRDs returns a cobra command that installs, or with --verify,
+// checks for, the CustomResourceDefinitions cert-manager requires. It is an
+// alternative to passing --install-crds to the cert-manager-controller
+// binary itself, for deployments that would rather install CRDs as a
+// one-off step than on every controller startup.
+func NewCmdInstallCRDs(out, errOut io.Writer) *cobra.Command {
+	o := &InstallCRDsOptions{StdOut: out, StdErr: errOut}
+
+	cmd := &cobra.Command{
+		Use:   "install-crds",
+		Short: "Install the CustomResourceDefinitions cert-manager requires",
+		Long: `install-crds installs (or updates) the CustomResourceDefinitions
+cert-manager requires, equivalent to applying docs/crd.yaml.
+
+With --verify, nothing is installed or modified; the command instead exits
+non-zero if the CustomResourceDefinitions are missing or out of date, for use
+as a startup or CI check.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(); err != nil {
+				return err
+			}
+
+			return o.Run()
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.Verify, "verify", false, "only verify that the CustomResourceDefinitions are already installed and up to date, without modifying anything")
+	cmd.Flags().StringVar(&o.APIServerHost, "master", "", "optional apiserver host address to connect to, if not using a kubeconfig")
+	cmd.Flags().StringVar(&o.Kubeconfig, "kubeconfig", "", "path to a kubeconfig file, if not using in-cluster config")
+
+	return cmd
+}
+
+// Complete finalises InstallCRDsOptions, building an apiextensions client
+// from the configured kubeconfig/master flags.
+func (o *InstallCRDsOptions) Complete() error {
+	cfg, err := clientcmd.BuildConfigFromFlags(o.APIServerHost, o.Kubeconfig)
+	if err != nil {
+		return fmt.Errorf("error building kubeconfig: %s", err.Error())
+	}
+
+	cl, err := apiextensionsclientset.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error creating apiextensions client: %s", err.Error())
+	}
+
+	o.Client = cl
+	return nil
+}
+
+// Run installs, or verifies, every CustomResourceDefinition cert-manager
+// requires.
+func (o *InstallCRDsOptions) Run() error {
+	ctx := context.Background()
+
+	for _, crd := range crds.All() {
+		if o.Verify {
+			if err := kube.VerifyCRD(ctx, o.Client, crd); err != nil {
+				return err
+			}
+			fmt.Fprintf(o.StdOut, "CustomResourceDefinition %s is installed and up to date\n", crd.Name)
+			continue
+		}
+
+		if err := kube.EnsureCRD(ctx, o.Client, crd); err != nil {
+			return fmt.Errorf("error installing CustomResourceDefinition %s: %s", crd.Name, err.Error())
+		}
+		fmt.Fprintf(o.StdOut, "CustomResourceDefinition %s installed\n", crd.Name)
+	}
+
+	return nil
+}
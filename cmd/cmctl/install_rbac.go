@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/rbac"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/kube"
+)
+
+// InstallRBACOptions holds the configuration for the `cmctl install-rbac`
+// command.
+type InstallRBACOptions struct {
+	Verify bool
+
+	APIServerHost string
+	Kubeconfig    string
+
+	Client kubernetes.Interface
+
+	StdOut io.Writer
+	StdErr io.Writer
+}
+
+// NewCmdInstallRBAC returns a cobra command that installs, or with --verify,
+// checks for, the aggregated ClusterRoles cert-manager ships so that
+// namespace admins automatically get sensible permissions on its CRDs. It is
+// the RBAC equivalent of install-crds.
+func NewCmdInstallRBAC(out, errOut io.Writer) *cobra.Command {
+	o := &InstallRBACOptions{StdOut: out, StdErr: errOut}
+
+	cmd := &cobra.Command{
+		Use:   "install-rbac",
+		Short: "Install the aggregated ClusterRoles cert-manager requires",
+		Long: `install-rbac installs (or updates) the aggregated view/edit/admin
+ClusterRoles cert-manager ships for its CRDs, so that they are automatically
+merged into a cluster's built-in view/edit/admin ClusterRoles.
+
+With --verify, nothing is installed or modified; the command instead exits
+non-zero if the ClusterRoles are missing or out of date, for use as a startup
+or CI check.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(); err != nil {
+				return err
+			}
+
+			return o.Run()
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.Verify, "verify", false, "only verify that the ClusterRoles are already installed and up to date, without modifying anything")
+	cmd.Flags().StringVar(&o.APIServerHost, "master", "", "optional apiserver host address to connect to, if not using a kubeconfig")
+	cmd.Flags().StringVar(&o.Kubeconfig, "kubeconfig", "", "path to a kubeconfig file, if not using in-cluster config")
+
+	return cmd
+}
+
+// Complete finalises InstallRBACOptions, building a Kubernetes client from
+// the configured kubeconfig/master flags.
+func (o *InstallRBACOptions) Complete() error {
+	cfg, err := clientcmd.BuildConfigFromFlags(o.APIServerHost, o.Kubeconfig)
+	if err != nil {
+		return fmt.Errorf("error building kubeconfig: %s", err.Error())
+	}
+
+	cl, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error creating kubernetes client: %s", err.Error())
+	}
+
+	o.Client = cl
+	return nil
+}
+
+// Run installs, or verifies, every aggregated ClusterRole cert-manager
+// requires.
+func (o *InstallRBACOptions) Run() error {
+	ctx := context.Background()
+
+	for _, role := range rbac.All() {
+		if o.Verify {
+			if err := kube.VerifyClusterRole(ctx, o.Client, role); err != nil {
+				return err
+			}
+			fmt.Fprintf(o.StdOut, "ClusterRole %s is installed and up to date\n", role.Name)
+			continue
+		}
+
+		if err := kube.EnsureClusterRole(ctx, o.Client, role); err != nil {
+			return fmt.Errorf("error installing ClusterRole %s: %s", role.Name, err.Error())
+		}
+		fmt.Fprintf(o.StdOut, "ClusterRole %s installed\n", role.Name)
+	}
+
+	return nil
+}
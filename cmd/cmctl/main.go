@@ -0,0 +1,16 @@
+package main
+
+import (
+	"os"
+
+	"github.com/golang/glog"
+
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+)
+
+func main() {
+	cmd := NewCmctlCommand(os.Stdout, os.Stderr)
+	if err := cmd.Execute(); err != nil {
+		glog.Exitf(err.Error())
+	}
+}
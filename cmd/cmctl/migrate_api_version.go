@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/client/clientset"
+)
+
+// MigrateAPIVersionOptions holds the configuration for the
+// `cmctl migrate-api-version` command.
+type MigrateAPIVersionOptions struct {
+	// Namespace restricts the migration to a single namespace. Empty (the
+	// default) migrates resources across every namespace.
+	Namespace string
+
+	APIServerHost string
+	Kubeconfig    string
+
+	CMClient clientset.Interface
+
+	StdOut io.Writer
+	StdErr io.Writer
+}
+
+// NewCmdMigrateAPIVersion returns a cobra command that re-writes every
+// Certificate and Issuer resource, forcing the apiserver to re-encode its
+// stored representation using whichever version it currently treats as the
+// storage version.
+//
+// cert-manager's CustomResourceDefinitions currently declare exactly one
+// served and stored version (v1alpha1), so there is nothing to migrate
+// today, and no status.storedVersions field for this command to trim - the
+// vendored CustomResourceDefinition type here predates that field. This
+// command exists so that, once a second version is introduced, operators
+// have a ready-made way to roll every stored object forward after an
+// apiserver upgrade, rather than relying on objects happening to be
+// rewritten by unrelated updates.
+func NewCmdMigrateAPIVersion(out, errOut io.Writer) *cobra.Command {
+	o := &MigrateAPIVersionOptions{StdOut: out, StdErr: errOut}
+
+	cmd := &cobra.Command{
+		Use:   "migrate-api-version",
+		Short: "Re-write stored Certificates and Issuers to the current storage version",
+		Long: `migrate-api-version reads every Certificate and Issuer resource and writes
+it back unchanged, forcing the apiserver to re-encode its stored
+representation using whichever version it currently treats as the storage
+version for that CustomResourceDefinition.
+
+This is useful after an upgrade that changes a CustomResourceDefinition's
+storage version, so that old objects are migrated eagerly instead of
+lingering in the previous stored version until something else happens to
+update them.
+
+cert-manager's CustomResourceDefinitions currently define only a single
+version (v1alpha1), so running this command today is a no-op beyond the
+round-trip itself: there is no older stored version to migrate away from,
+and nothing to remove from status.storedVersions.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(); err != nil {
+				return err
+			}
+
+			return o.Run()
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "namespace to migrate resources in (defaults to all namespaces)")
+	cmd.Flags().StringVar(&o.APIServerHost, "master", "", "optional apiserver host address to connect to, if not using a kubeconfig")
+	cmd.Flags().StringVar(&o.Kubeconfig, "kubeconfig", "", "path to a kubeconfig file, if not using in-cluster config")
+
+	return cmd
+}
+
+// Complete finalises MigrateAPIVersionOptions, building the cert-manager
+// client from the configured kubeconfig/master flags.
+func (o *MigrateAPIVersionOptions) Complete() error {
+	cfg, err := clientcmd.BuildConfigFromFlags(o.APIServerHost, o.Kubeconfig)
+	if err != nil {
+		return fmt.Errorf("error building kubeconfig: %s", err.Error())
+	}
+
+	cmClient, err := clientset.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error creating cert-manager client: %s", err.Error())
+	}
+	o.CMClient = cmClient
+
+	return nil
+}
+
+// Run re-writes every Certificate and Issuer in o.Namespace.
+func (o *MigrateAPIVersionOptions) Run() error {
+	certCount, err := o.migrateCertificates()
+	if err != nil {
+		return err
+	}
+
+	issuerCount, err := o.migrateIssuers()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.StdOut, "migrated %d certificate(s) and %d issuer(s) to the current storage version\n", certCount, issuerCount)
+	return nil
+}
+
+func (o *MigrateAPIVersionOptions) migrateCertificates() (int, error) {
+	client := o.CMClient.CertmanagerV1alpha1().Certificates(o.Namespace)
+
+	crts, err := client.List(metav1.ListOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("error listing certificates: %s", err.Error())
+	}
+
+	for _, crt := range crts.Items {
+		if _, err := client.Update(&crt); err != nil {
+			return 0, fmt.Errorf("error migrating certificate %s/%s: %s", crt.Namespace, crt.Name, err.Error())
+		}
+	}
+
+	return len(crts.Items), nil
+}
+
+func (o *MigrateAPIVersionOptions) migrateIssuers() (int, error) {
+	client := o.CMClient.CertmanagerV1alpha1().Issuers(o.Namespace)
+
+	issuers, err := client.List(metav1.ListOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("error listing issuers: %s", err.Error())
+	}
+
+	for _, iss := range issuers.Items {
+		if _, err := client.Update(&iss); err != nil {
+			return 0, fmt.Errorf("error migrating issuer %s/%s: %s", iss.Namespace, iss.Name, err.Error())
+		}
+	}
+
+	return len(issuers.Items), nil
+}
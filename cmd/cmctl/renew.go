@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/client/clientset"
+)
+
+// RenewOptions holds the configuration for the `cmctl renew` command.
+type RenewOptions struct {
+	Namespace       string
+	CertificateName string
+	APIServerHost   string
+	Kubeconfig      string
+
+	CMClient clientset.Interface
+
+	StdOut io.Writer
+	StdErr io.Writer
+}
+
+// NewCmdRenew returns a cobra command that forces immediate re-issuance of a
+// Certificate, without waiting for it to approach expiry.
+func NewCmdRenew(out, errOut io.Writer) *cobra.Command {
+	o := &RenewOptions{StdOut: out, StdErr: errOut}
+
+	cmd := &cobra.Command{
+		Use:   "renew CERTIFICATE_NAME",
+		Short: "Force immediate re-issuance of a Certificate",
+		Long: `renew marks a Certificate for immediate re-issuance by the cert-manager
+controller, regardless of its remaining validity, without deleting its
+target Secret. This is done by setting the ` + v1alpha1.AnnotationRenewNow + `
+annotation, which the controller removes once the renewal has been
+triggered.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("exactly one argument (the Certificate name) must be provided")
+			}
+			o.CertificateName = args[0]
+
+			if err := o.Complete(); err != nil {
+				return err
+			}
+
+			return o.Run()
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "default", "namespace containing the Certificate to renew")
+	cmd.Flags().StringVar(&o.APIServerHost, "master", "", "optional apiserver host address to connect to, if not using a kubeconfig")
+	cmd.Flags().StringVar(&o.Kubeconfig, "kubeconfig", "", "path to a kubeconfig file, if not using in-cluster config")
+
+	return cmd
+}
+
+// Complete finalises RenewOptions, building a cert-manager client from the
+// configured kubeconfig/master flags.
+func (o *RenewOptions) Complete() error {
+	cfg, err := clientcmd.BuildConfigFromFlags(o.APIServerHost, o.Kubeconfig)
+	if err != nil {
+		return fmt.Errorf("error building kubeconfig: %s", err.Error())
+	}
+
+	cl, err := clientset.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error creating cert-manager client: %s", err.Error())
+	}
+
+	o.CMClient = cl
+	return nil
+}
+
+// Run sets the AnnotationRenewNow annotation on the named Certificate.
+func (o *RenewOptions) Run() error {
+	crt, err := o.CMClient.CertmanagerV1alpha1().Certificates(o.Namespace).Get(o.CertificateName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting certificate %s/%s: %s", o.Namespace, o.CertificateName, err.Error())
+	}
+
+	updated := crt.DeepCopy()
+	if updated.Annotations == nil {
+		updated.Annotations = make(map[string]string)
+	}
+	updated.Annotations[v1alpha1.AnnotationRenewNow] = "true"
+
+	if _, err := o.CMClient.CertmanagerV1alpha1().Certificates(o.Namespace).Update(updated); err != nil {
+		return fmt.Errorf("error updating certificate %s/%s: %s", o.Namespace, o.CertificateName, err.Error())
+	}
+
+	fmt.Fprintf(o.StdOut, "certificate %s/%s marked for renewal\n", o.Namespace, o.CertificateName)
+	return nil
+}
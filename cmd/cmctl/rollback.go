@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/util/kube"
+)
+
+// RollbackOptions holds the configuration for the `cmctl rollback` command.
+type RollbackOptions struct {
+	Namespace     string
+	SecretName    string
+	APIServerHost string
+	Kubeconfig    string
+
+	Client kubernetes.Interface
+
+	StdOut io.Writer
+	StdErr io.Writer
+}
+
+// NewCmdRollback returns a cobra command that restores the most recently
+// superseded cert/key pair for a Certificate's target Secret, from the
+// revision history cert-manager records on every issuance or renewal.
+func NewCmdRollback(out, errOut io.Writer) *cobra.Command {
+	o := &RollbackOptions{StdOut: out, StdErr: errOut}
+
+	cmd := &cobra.Command{
+		Use:   "rollback SECRET_NAME",
+		Short: "Restore the previous certificate and private key for a Secret",
+		Long: `rollback restores the most recently superseded certificate and private
+key for a Secret, from the revision history cert-manager records each time it
+issues or renews a certificate.
+
+This is intended as an emergency escape hatch for when a freshly issued
+certificate breaks a consumer. cert-manager will re-issue over the top of the
+restored Secret again at its next reconcile unless the Certificate resource
+is also fixed, or its issuer is temporarily unable to issue.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("exactly one argument (the Secret name) must be provided")
+			}
+			o.SecretName = args[0]
+
+			if err := o.Complete(); err != nil {
+				return err
+			}
+
+			return o.Run()
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "default", "namespace containing the Secret to roll back")
+	cmd.Flags().StringVar(&o.APIServerHost, "master", "", "optional apiserver host address to connect to, if not using a kubeconfig")
+	cmd.Flags().StringVar(&o.Kubeconfig, "kubeconfig", "", "path to a kubeconfig file, if not using in-cluster config")
+
+	return cmd
+}
+
+// Complete finalises RollbackOptions, building a Kubernetes client from the
+// configured kubeconfig/master flags.
+func (o *RollbackOptions) Complete() error {
+	cfg, err := clientcmd.BuildConfigFromFlags(o.APIServerHost, o.Kubeconfig)
+	if err != nil {
+		return fmt.Errorf("error building kubeconfig: %s", err.Error())
+	}
+
+	cl, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error creating kubernetes client: %s", err.Error())
+	}
+
+	o.Client = cl
+	return nil
+}
+
+// Run restores the most recent revision from the Secret's history
+// annotation, pushing the Secret's current data onto the front of the
+// remaining history so the rollback itself can be undone.
+func (o *RollbackOptions) Run() error {
+	secret, err := o.Client.CoreV1().Secrets(o.Namespace).Get(o.SecretName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting secret %s/%s: %s", o.Namespace, o.SecretName, err.Error())
+	}
+
+	history, err := kube.SecretHistoryFromSecret(secret)
+	if err != nil {
+		return fmt.Errorf("error reading revision history for secret %s/%s: %s", o.Namespace, o.SecretName, err.Error())
+	}
+
+	if len(history) == 0 {
+		return fmt.Errorf("secret %s/%s has no previous revisions to roll back to", o.Namespace, o.SecretName)
+	}
+
+	previous := history[0]
+	remaining := append([]kube.SecretRevision{{Data: secret.Data}}, history[1:]...)
+
+	updated := secret.DeepCopy()
+	updated.Data = previous.Data
+	if err := setSecretHistory(updated, remaining); err != nil {
+		return fmt.Errorf("error updating revision history for secret %s/%s: %s", o.Namespace, o.SecretName, err.Error())
+	}
+
+	if _, err := o.Client.CoreV1().Secrets(o.Namespace).Update(updated); err != nil {
+		return fmt.Errorf("error updating secret %s/%s: %s", o.Namespace, o.SecretName, err.Error())
+	}
+
+	fmt.Fprintf(o.StdOut, "secret %s/%s rolled back to its previous revision\n", o.Namespace, o.SecretName)
+	return nil
+}
+
+func setSecretHistory(secret *api.Secret, history []kube.SecretRevision) error {
+	encoded, err := kube.EncodeSecretHistory(history)
+	if err != nil {
+		return err
+	}
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	secret.Annotations[kube.SecretHistoryAnnotationKey] = encoded
+	return nil
+}
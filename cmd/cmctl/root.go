@@ -0,0 +1,30 @@
+package main
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+// NewCmctlCommand is a CLI handler for cmctl, the cert-manager operator
+// tool. It is distinct from the cert-manager-controller binary: it talks to
+// the Kubernetes API directly to perform one-off operational tasks rather
+// than continuously reconciling resources.
+func NewCmctlCommand(out, errOut io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cmctl",
+		Short: "cmctl is a command line tool to help operate cert-manager",
+	}
+
+	cmd.AddCommand(NewCmdRollback(out, errOut))
+	cmd.AddCommand(NewCmdRenew(out, errOut))
+	cmd.AddCommand(NewCmdInstallCRDs(out, errOut))
+	cmd.AddCommand(NewCmdInstallRBAC(out, errOut))
+	cmd.AddCommand(NewCmdACME(out, errOut))
+	cmd.AddCommand(NewCmdCheck(out, errOut))
+	cmd.AddCommand(NewCmdAdoptSecret(out, errOut))
+	cmd.AddCommand(NewCmdStatus(out, errOut))
+	cmd.AddCommand(NewCmdMigrateAPIVersion(out, errOut))
+
+	return cmd
+}
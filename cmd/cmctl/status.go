@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/client/clientset"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/pki"
+)
+
+// StatusIssuersOptions holds the configuration for the `cmctl status issuers`
+// command.
+type StatusIssuersOptions struct {
+	// Namespace restricts the listing to a single namespace. Empty (the
+	// default) lists Issuers across every namespace.
+	Namespace string
+
+	APIServerHost string
+	Kubeconfig    string
+
+	Client   kubernetes.Interface
+	CMClient clientset.Interface
+
+	StdOut io.Writer
+	StdErr io.Writer
+}
+
+// NewCmdStatus returns a cobra command grouping read-only cert-manager
+// inventory reports.
+func NewCmdStatus(out, errOut io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Report on the state of cert-manager resources",
+	}
+
+	cmd.AddCommand(NewCmdStatusIssuers(out, errOut))
+
+	return cmd
+}
+
+// NewCmdStatusIssuers returns a cobra command that lists every Issuer
+// cert-manager knows about, aggregated across namespaces, for fleet-wide PKI
+// inventory.
+func NewCmdStatusIssuers(out, errOut io.Writer) *cobra.Command {
+	o := &StatusIssuersOptions{StdOut: out, StdErr: errOut}
+
+	cmd := &cobra.Command{
+		Use:   "issuers",
+		Short: "List all Issuers, their type, readiness and signing material",
+		Long: `status issuers lists every Issuer resource across the cluster (or a single
+namespace, with --namespace), showing its type, readiness, and the details
+relevant to that type: the ACME account URL and email for acme issuers, or
+the signing certificate's fingerprint and expiry for ca issuers.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(); err != nil {
+				return err
+			}
+
+			return o.Run()
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "namespace to list Issuers in (defaults to all namespaces)")
+	cmd.Flags().StringVar(&o.APIServerHost, "master", "", "optional apiserver host address to connect to, if not using a kubeconfig")
+	cmd.Flags().StringVar(&o.Kubeconfig, "kubeconfig", "", "path to a kubeconfig file, if not using in-cluster config")
+
+	return cmd
+}
+
+// Complete finalises StatusIssuersOptions, building the Kubernetes and
+// cert-manager clients from the configured kubeconfig/master flags.
+func (o *StatusIssuersOptions) Complete() error {
+	cfg, err := clientcmd.BuildConfigFromFlags(o.APIServerHost, o.Kubeconfig)
+	if err != nil {
+		return fmt.Errorf("error building kubeconfig: %s", err.Error())
+	}
+
+	cl, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error creating kubernetes client: %s", err.Error())
+	}
+	o.Client = cl
+
+	cmClient, err := clientset.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error creating cert-manager client: %s", err.Error())
+	}
+	o.CMClient = cmClient
+
+	return nil
+}
+
+// Run lists every Issuer visible to o.Namespace and prints a row of
+// inventory detail for each.
+func (o *StatusIssuersOptions) Run() error {
+	issuers, err := o.CMClient.CertmanagerV1alpha1().Issuers(o.Namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing issuers: %s", err.Error())
+	}
+
+	w := tabwriter.NewWriter(o.StdOut, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tTYPE\tREADY\tDETAIL")
+
+	for _, iss := range issuers.Items {
+		ready := "False"
+		if iss.HasCondition(v1alpha1.IssuerCondition{Type: v1alpha1.IssuerConditionReady, Status: v1alpha1.ConditionTrue}) {
+			ready = "True"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", iss.Namespace, iss.Name, issuerType(&iss), ready, o.issuerDetail(&iss))
+	}
+
+	return w.Flush()
+}
+
+// issuerType returns the configured issuer type of iss (acme, ca, stepca,
+// est, hub or fake), matching pkg/issuer/const.go's nameForIssuer, or
+// "unknown" if none of its type fields are set.
+func issuerType(iss *v1alpha1.Issuer) string {
+	switch {
+	case iss.Spec.ACME != nil:
+		return "acme"
+	case iss.Spec.CA != nil:
+		return "ca"
+	case iss.Spec.StepCA != nil:
+		return "stepca"
+	case iss.Spec.EST != nil:
+		return "est"
+	case iss.Spec.Hub != nil:
+		return "hub"
+	case iss.Spec.Fake != nil:
+		return "fake"
+	}
+	return "unknown"
+}
+
+// issuerDetail returns a short human-readable summary of the signing
+// material behind iss: the ACME account URL and email for acme issuers, or
+// the signing certificate's fingerprint and expiry for ca issuers, read from
+// its signing Secret. Any other issuer type, or an error reading the
+// signing Secret, produces a placeholder rather than failing the whole
+// listing.
+func (o *StatusIssuersOptions) issuerDetail(iss *v1alpha1.Issuer) string {
+	switch {
+	case iss.Spec.ACME != nil:
+		uri := iss.Status.ACME.URI
+		if iss.Status.ACME == nil || uri == "" {
+			uri = "<no account registered>"
+		}
+		return fmt.Sprintf("account=%s email=%s", uri, iss.Spec.ACME.Email)
+
+	case iss.Spec.CA != nil:
+		secretNamespace := iss.Namespace
+		if iss.Spec.CA.SecretNamespace != "" {
+			secretNamespace = iss.Spec.CA.SecretNamespace
+		}
+
+		secret, err := o.Client.CoreV1().Secrets(secretNamespace).Get(iss.Spec.CA.SecretRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Sprintf("<error reading signing secret: %s>", err.Error())
+		}
+
+		cert, err := pki.DecodeX509CertificateBytes(secret.Data[api.TLSCertKey])
+		if err != nil {
+			return fmt.Sprintf("<error parsing signing certificate: %s>", err.Error())
+		}
+
+		return fmt.Sprintf("fingerprint=%s expiry=%s", pki.Fingerprint(cert), cert.NotAfter)
+
+	default:
+		return "-"
+	}
+}
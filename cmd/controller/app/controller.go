@@ -1,28 +1,42 @@
 package app
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/pprof"
 	"os"
 	"sync"
 
 	"github.com/golang/glog"
 	"k8s.io/api/core/v1"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
-	"k8s.io/client-go/tools/leaderelection"
+	k8sleaderelection "k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/client-go/tools/record"
 
 	"github.com/jetstack-experimental/cert-manager/cmd/controller/app/options"
+	"github.com/jetstack-experimental/cert-manager/pkg/acmeserver"
+	"github.com/jetstack-experimental/cert-manager/pkg/auditlog"
 	clientset "github.com/jetstack-experimental/cert-manager/pkg/client/clientset"
 	intscheme "github.com/jetstack-experimental/cert-manager/pkg/client/clientset/scheme"
 	"github.com/jetstack-experimental/cert-manager/pkg/controller"
+	"github.com/jetstack-experimental/cert-manager/pkg/crds"
 	"github.com/jetstack-experimental/cert-manager/pkg/issuer"
+	"github.com/jetstack-experimental/cert-manager/pkg/leaderelection"
+	"github.com/jetstack-experimental/cert-manager/pkg/metrics"
+	"github.com/jetstack-experimental/cert-manager/pkg/rbac"
+	"github.com/jetstack-experimental/cert-manager/pkg/rollout"
+	"github.com/jetstack-experimental/cert-manager/pkg/scep"
 	"github.com/jetstack-experimental/cert-manager/pkg/util/kube"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/pki"
 )
 
 const controllerAgentName = "cert-manager-controller"
@@ -34,6 +48,30 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) {
 		glog.Fatalf(err.Error())
 	}
 
+	if opts.InstallCRDs {
+		if err := installCRDs(kubeCfg); err != nil {
+			glog.Fatalf("error installing CustomResourceDefinitions: %s", err.Error())
+		}
+	}
+
+	if opts.InstallRBAC {
+		if err := installRBAC(kubeCfg); err != nil {
+			glog.Fatalf("error installing RBAC ClusterRoles: %s", err.Error())
+		}
+	}
+
+	pki.SetMaxConcurrentKeyGen(opts.MaxConcurrentKeyGen)
+
+	startMetricsServer(opts.MetricsListenAddress, opts.EnablePprof, ctx.CMClient)
+
+	if opts.SCEPListenAddress != "" {
+		startSCEPServer(opts.SCEPListenAddress, ctx.Client, ctx.CMClient, opts.Namespace, opts.SCEPIssuerName)
+	}
+
+	if opts.ACMEServerListenAddress != "" {
+		startACMEServer(opts.ACMEServerListenAddress, ctx.Client, ctx.CMClient, opts.Namespace, opts.ACMEServerIssuerName, opts.ACMEServerBaseURL)
+	}
+
 	run := func(_ <-chan struct{}) {
 		var wg sync.WaitGroup
 		var controllers = make(map[string]controller.Interface)
@@ -46,7 +84,7 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) {
 				defer wg.Done()
 				glog.V(4).Infof("Starting %s controller", n)
 
-				err := fn(2, stopCh)
+				err := fn(opts.NumberOfConcurrentWorkers, stopCh)
 
 				if err != nil {
 					glog.Fatalf("error running %s controller: %s", n, err.Error())
@@ -56,7 +94,16 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) {
 		glog.V(4).Infof("Starting shared informer factory")
 		ctx.SharedInformerFactory.Start(stopCh)
 		wg.Wait()
-		glog.Fatalf("Control loops exited")
+
+		select {
+		case <-stopCh:
+			// stopCh was closed, so all control loops above have already
+			// drained their queues and finished any in-flight work - this is
+			// an expected, graceful shutdown.
+			glog.V(2).Infof("Control loops exited cleanly on shutdown")
+		default:
+			glog.Fatalf("Control loops exited")
+		}
 	}
 
 	if !opts.LeaderElect {
@@ -70,13 +117,12 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) {
 		glog.Fatalf("error creating leader election client: %s", err.Error())
 	}
 
-	startLeaderElection(opts, leaderElectionClient, ctx.Recorder, run)
-	panic("unreachable")
+	startLeaderElection(opts, leaderElectionClient, ctx.Recorder, run, stopCh)
 }
 
 func buildControllerContext(opts *options.ControllerOptions) (*controller.Context, *rest.Config, error) {
 	// Load the users Kubernetes config
-	kubeCfg, err := KubeConfig(opts.APIServerHost)
+	kubeCfg, err := KubeConfig(opts.APIServerHost, opts.KubeAPIQPS, opts.KubeAPIBurst)
 
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating rest config: %s", err.Error())
@@ -106,6 +152,16 @@ func buildControllerContext(opts *options.ControllerOptions) (*controller.Contex
 	eventBroadcaster.StartRecordingToSink(&corev1.EventSinkImpl{Interface: cl.CoreV1().Events("")})
 	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: controllerAgentName})
 
+	auditLog, err := auditlog.NewFile(opts.AuditLogPath, opts.AuditLogURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error setting up audit log: %s", err.Error())
+	}
+
+	var rolloutTrigger *rollout.Trigger
+	if opts.EnableRolloutRestart {
+		rolloutTrigger = rollout.New(cl)
+	}
+
 	sharedInformerFactory := kube.NewSharedInformerFactory()
 	return &controller.Context{
 		Client:                cl,
@@ -119,11 +175,53 @@ func buildControllerContext(opts *options.ControllerOptions) (*controller.Contex
 			SharedInformerFactory: sharedInformerFactory,
 			Namespace:             opts.Namespace,
 		}),
-		Namespace: opts.Namespace,
+		AuditLog:           auditLog,
+		RolloutTrigger:     rolloutTrigger,
+		RenewBeforeJitter:  opts.RenewBeforeJitter,
+		RenewalClockSkew:   opts.RenewalClockSkew,
+		Namespace:          opts.Namespace,
+		StrictFIPS:         opts.StrictFIPS,
+		JanitorResourceTTL: opts.JanitorResourceTTL,
 	}, kubeCfg, nil
 }
 
-func startLeaderElection(opts *options.ControllerOptions, leaderElectionClient kubernetes.Interface, recorder record.EventRecorder, run func(<-chan struct{})) {
+// installCRDs installs (or updates) every CustomResourceDefinition
+// cert-manager requires against the cluster kubeCfg points at.
+func installCRDs(kubeCfg *rest.Config) error {
+	cl, err := apiextensionsclientset.NewForConfig(kubeCfg)
+	if err != nil {
+		return fmt.Errorf("error creating apiextensions client: %s", err.Error())
+	}
+
+	for _, crd := range crds.All() {
+		glog.V(2).Infof("Installing CustomResourceDefinition %s", crd.Name)
+		if err := kube.EnsureCRD(context.Background(), cl, crd); err != nil {
+			return fmt.Errorf("error installing CustomResourceDefinition %s: %s", crd.Name, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// installRBAC installs (or updates) every aggregated ClusterRole
+// cert-manager requires against the cluster kubeCfg points at.
+func installRBAC(kubeCfg *rest.Config) error {
+	cl, err := kubernetes.NewForConfig(kubeCfg)
+	if err != nil {
+		return fmt.Errorf("error creating kubernetes client: %s", err.Error())
+	}
+
+	for _, role := range rbac.All() {
+		glog.V(2).Infof("Installing ClusterRole %s", role.Name)
+		if err := kube.EnsureClusterRole(context.Background(), cl, role); err != nil {
+			return fmt.Errorf("error installing ClusterRole %s: %s", role.Name, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func startLeaderElection(opts *options.ControllerOptions, leaderElectionClient kubernetes.Interface, recorder record.EventRecorder, run func(<-chan struct{}), stopCh <-chan struct{}) {
 	// Identity used to distinguish between multiple controller manager instances
 	id, err := os.Hostname()
 	if err != nil {
@@ -143,26 +241,106 @@ func startLeaderElection(opts *options.ControllerOptions, leaderElectionClient k
 		},
 	}
 
-	// Try and become the leader and start controller manager loops
-	leaderelection.RunOrDie(leaderelection.LeaderElectionConfig{
+	// Try and become the leader and start controller manager loops. RunOrDie
+	// blocks until OnStartedLeading's run() has returned and the resulting
+	// OnStoppedLeading callback has completed.
+	le := leaderelection.RunOrDie(k8sleaderelection.LeaderElectionConfig{
 		Lock:          &rl,
 		LeaseDuration: opts.LeaderElectionLeaseDuration,
 		RenewDeadline: opts.LeaderElectionRenewDeadline,
 		RetryPeriod:   opts.LeaderElectionRetryPeriod,
-		Callbacks: leaderelection.LeaderCallbacks{
+		Callbacks: k8sleaderelection.LeaderCallbacks{
 			OnStartedLeading: run,
 			OnStoppedLeading: func() {
-				glog.Fatalf("leaderelection lost")
+				select {
+				case <-stopCh:
+					// We stopped leading because we are shutting down, not
+					// because another instance took over unexpectedly.
+					glog.V(2).Infof("leaderelection stopped as part of a graceful shutdown")
+				default:
+					glog.Fatalf("leaderelection lost")
+				}
 			},
 		},
 	})
+
+	// Release the lease promptly on a graceful shutdown, rather than making
+	// the next instance wait out the full LeaseDuration before it can take
+	// over.
+	le.Release()
+}
+
+// startMetricsServer starts an HTTP server serving the /metrics endpoint in
+// a background goroutine. Failures to bind are logged but do not prevent
+// the controller from starting, as metrics are not required for operation.
+// If enablePprof is set, the net/http/pprof profiling endpoints and a
+// /debug/queues endpoint reporting controller workqueue depths are also
+// exposed on the same listen address.
+func startMetricsServer(listenAddress string, enablePprof bool, cmClient clientset.Interface) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.New(cmClient).Handler())
+
+	if enablePprof {
+		glog.Infof("enabling pprof and queue-depth debug endpoints on %s - this should not be exposed publicly", listenAddress)
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+		mux.HandleFunc("/debug/queues", debugQueueDepths)
+	}
+
+	go func() {
+		glog.V(2).Infof("Starting metrics server on %s", listenAddress)
+		if err := http.ListenAndServe(listenAddress, mux); err != nil {
+			glog.Errorf("error serving metrics: %s", err.Error())
+		}
+	}()
+}
+
+// startSCEPServer starts an HTTP server serving the SCEP responder (see
+// pkg/scep) in a background goroutine. Failures to bind are logged but do
+// not prevent the controller from starting, matching startMetricsServer.
+func startSCEPServer(listenAddress string, client kubernetes.Interface, cmClient clientset.Interface, namespace, issuerName string) {
+	s := scep.New(client, cmClient, namespace, issuerName)
+
+	go func() {
+		glog.V(2).Infof("Starting SCEP server on %s", listenAddress)
+		if err := http.ListenAndServe(listenAddress, s.Handler()); err != nil {
+			glog.Errorf("error serving SCEP: %s", err.Error())
+		}
+	}()
+}
+
+// startACMEServer starts an HTTP server serving the ACME responder (see
+// pkg/acmeserver) in a background goroutine. Failures to bind are logged
+// but do not prevent the controller from starting, matching
+// startMetricsServer.
+func startACMEServer(listenAddress string, client kubernetes.Interface, cmClient clientset.Interface, namespace, issuerName, baseURL string) {
+	s := acmeserver.New(client, cmClient, namespace, issuerName, baseURL)
+
+	go func() {
+		glog.V(2).Infof("Starting ACME server on %s", listenAddress)
+		if err := http.ListenAndServe(listenAddress, s.Handler()); err != nil {
+			glog.Errorf("error serving ACME: %s", err.Error())
+		}
+	}()
+}
+
+// debugQueueDepths serves the current depth of every registered controller
+// workqueue as JSON, for diagnosing controllers that are falling behind.
+func debugQueueDepths(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(controller.QueueDepths()); err != nil {
+		glog.Errorf("error encoding queue depths: %s", err.Error())
+	}
 }
 
 // KubeConfig will return a rest.Config for communicating with the Kubernetes API server.
 // If apiServerHost is specified, a config without authentication that is configured
 // to talk to the apiServerHost URL will be returned. Else, the in-cluster config will be loaded,
 // and failing this, the config will be loaded from the users local kubeconfig directory
-func KubeConfig(apiServerHost string) (*rest.Config, error) {
+func KubeConfig(apiServerHost string, qps float32, burst int) (*rest.Config, error) {
 	var err error
 	var cfg *rest.Config
 
@@ -183,5 +361,8 @@ func KubeConfig(apiServerHost string) (*rest.Config, error) {
 		}
 	}
 
+	cfg.QPS = qps
+	cfg.Burst = burst
+
 	return cfg, nil
 }
@@ -10,6 +10,98 @@ type ControllerOptions struct {
 	APIServerHost string
 	Namespace     string
 
+	KubeAPIQPS   float32
+	KubeAPIBurst int
+
+	NumberOfConcurrentWorkers int
+
+	MetricsListenAddress string
+
+	EnablePprof bool
+
+	// SCEPListenAddress, if set, starts a SCEP responder (see pkg/scep) on
+	// the given host and port, serving enrollment requests against
+	// SCEPIssuerName in Namespace. If not set, no SCEP responder is
+	// started.
+	SCEPListenAddress string
+	// SCEPIssuerName is the name of the CA Issuer that the SCEP responder
+	// enrols devices against. Only used if SCEPListenAddress is set.
+	SCEPIssuerName string
+
+	// ACMEServerListenAddress, if set, starts an ACME server (see
+	// pkg/acmeserver) on the given host and port, serving certificates
+	// from ACMEServerIssuerName in Namespace. If not set, no ACME server
+	// is started.
+	ACMEServerListenAddress string
+	// ACMEServerIssuerName is the name of the CA Issuer that the ACME
+	// server issues certificates from. Only used if
+	// ACMEServerListenAddress is set.
+	ACMEServerIssuerName string
+	// ACMEServerBaseURL is the externally reachable base URL the ACME
+	// server is exposed at, e.g. "https://ca.example.com/acme". It is
+	// used to build the absolute URLs returned in ACME responses. Only
+	// used if ACMEServerListenAddress is set.
+	ACMEServerBaseURL string
+
+	// AuditLogPath, if set, is the file that a tamper-evident record of
+	// every certificate issued or renewed is appended to.
+	AuditLogPath string
+	// AuditLogURL, if set, is an HTTP endpoint that the same audit
+	// records are also POSTed to as JSON.
+	AuditLogURL string
+
+	// EnableRolloutRestart, if true, causes the certificates controller to
+	// trigger a rolling restart (see pkg/rollout) of any Deployment or
+	// StatefulSet referencing a Certificate's target Secret whenever that
+	// certificate is renewed, for applications that don't reload their
+	// certificate from disk automatically.
+	EnableRolloutRestart bool
+
+	// RenewBeforeJitter is the upper bound of a random per-Certificate
+	// offset added to the renewal window, so that a large number of
+	// Certificates that became due for renewal at the same instant (e.g.
+	// issued together at cluster bootstrap) don't all attempt renewal
+	// simultaneously. Zero (the default) disables jitter.
+	RenewBeforeJitter time.Duration
+
+	// RenewalClockSkew is an additional fixed margin added to every
+	// Certificate's renewal window, on top of RenewBeforeJitter, to
+	// compensate for clock drift between this controller and whatever
+	// issued the certificates it manages. Zero (the default) assumes
+	// clocks are in sync.
+	RenewalClockSkew time.Duration
+
+	// MaxConcurrentKeyGen bounds, independently for each RSA key size, how
+	// many private keys of that size may be generated concurrently across
+	// all reconciles. RSA key generation is CPU-bound and gets
+	// significantly more expensive at larger key sizes, so an unbounded
+	// burst of new Certificates can starve every other reconcile worker on
+	// a resource-constrained node (e.g. a small ARM instance). Zero (the
+	// default) imposes no limit.
+	MaxConcurrentKeyGen int
+
+	// InstallCRDs, if true, causes the controller to install (or update)
+	// the CustomResourceDefinitions it requires on startup, before any
+	// controllers are started, rather than expecting them to have already
+	// been applied from docs/crd.yaml.
+	InstallCRDs bool
+
+	// InstallRBAC, if true, causes the controller to install (or update)
+	// the aggregated view/edit/admin ClusterRoles it ships for its CRDs on
+	// startup, before any controllers are started, rather than expecting
+	// them to have already been applied.
+	InstallRBAC bool
+
+	// StrictFIPS, if true, causes the certificates controller to reject
+	// Certificate specs that request a key size that is not FIPS 140-2
+	// approved, rather than issuing a non-compliant certificate.
+	StrictFIPS bool
+
+	// JanitorResourceTTL is how long a leftover ACME HTTP-01 solver
+	// Service/Ingress/Job is kept around after creation before the janitor
+	// controller deletes it as stale.
+	JanitorResourceTTL time.Duration
+
 	LeaderElect                 bool
 	LeaderElectionNamespace     string
 	LeaderElectionLeaseDuration time.Duration
@@ -21,6 +113,31 @@ const (
 	defaultAPIServerHost = ""
 	defaultNamespace     = ""
 
+	defaultKubeAPIQPS   = 20.0
+	defaultKubeAPIBurst = 50
+
+	defaultNumberOfConcurrentWorkers = 2
+
+	defaultMetricsListenAddress = ":9402"
+
+	defaultEnablePprof = false
+
+	defaultEnableRolloutRestart = false
+
+	defaultInstallCRDs = false
+
+	defaultInstallRBAC = false
+
+	defaultStrictFIPS = false
+
+	defaultRenewBeforeJitter = 0 * time.Second
+
+	defaultRenewalClockSkew = 0 * time.Second
+
+	defaultMaxConcurrentKeyGen = 0
+
+	defaultJanitorResourceTTL = 1 * time.Hour
+
 	defaultLeaderElect                 = true
 	defaultLeaderElectionNamespace     = "kube-system"
 	defaultLeaderElectionLeaseDuration = 15 * time.Second
@@ -32,6 +149,19 @@ func NewControllerOptions() *ControllerOptions {
 	return &ControllerOptions{
 		APIServerHost:               defaultAPIServerHost,
 		Namespace:                   defaultNamespace,
+		KubeAPIQPS:                  defaultKubeAPIQPS,
+		KubeAPIBurst:                defaultKubeAPIBurst,
+		NumberOfConcurrentWorkers:   defaultNumberOfConcurrentWorkers,
+		MetricsListenAddress:        defaultMetricsListenAddress,
+		EnablePprof:                 defaultEnablePprof,
+		EnableRolloutRestart:        defaultEnableRolloutRestart,
+		InstallCRDs:                 defaultInstallCRDs,
+		InstallRBAC:                 defaultInstallRBAC,
+		StrictFIPS:                  defaultStrictFIPS,
+		RenewBeforeJitter:           defaultRenewBeforeJitter,
+		RenewalClockSkew:            defaultRenewalClockSkew,
+		MaxConcurrentKeyGen:         defaultMaxConcurrentKeyGen,
+		JanitorResourceTTL:          defaultJanitorResourceTTL,
 		LeaderElect:                 defaultLeaderElect,
 		LeaderElectionNamespace:     defaultLeaderElectionNamespace,
 		LeaderElectionLeaseDuration: defaultLeaderElectionLeaseDuration,
@@ -48,6 +178,95 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 		"Optional namespace to monitor resources within. This can be used to limit the scope "+
 		"of cert-manager to a single namespace. If not specified, all namespaces will be watched")
 
+	fs.Float32Var(&s.KubeAPIQPS, "kube-api-qps", defaultKubeAPIQPS, ""+
+		"QPS to use while talking with the Kubernetes apiserver")
+	fs.IntVar(&s.KubeAPIBurst, "kube-api-burst", defaultKubeAPIBurst, ""+
+		"Burst to use while talking with the Kubernetes apiserver")
+	fs.IntVar(&s.NumberOfConcurrentWorkers, "concurrent-syncs", defaultNumberOfConcurrentWorkers, ""+
+		"The number of certificates that are allowed to be issued or renewed concurrently, "+
+		"per controller. This caps the number of in-flight issuances so a large fleet doesn't "+
+		"thunder against the issuer after a controller restart.")
+
+	fs.StringVar(&s.MetricsListenAddress, "metrics-listen-address", defaultMetricsListenAddress, ""+
+		"The host and port that the metrics endpoint should listen on.")
+	fs.BoolVar(&s.EnablePprof, "enable-pprof", defaultEnablePprof, ""+
+		"If true, the net/http/pprof profiling endpoints and a controller queue-depth "+
+		"debug endpoint will be exposed on the metrics listen address, for diagnosing "+
+		"memory/CPU issues in large clusters. This should not be enabled on a publicly "+
+		"reachable address.")
+
+	fs.StringVar(&s.SCEPListenAddress, "scep-listen-address", "", ""+
+		"The host and port that the SCEP responder should listen on. If not set, the "+
+		"SCEP responder is not started.")
+	fs.StringVar(&s.SCEPIssuerName, "scep-issuer-name", "", ""+
+		"The name of the CA Issuer, in --namespace, that the SCEP responder enrols "+
+		"devices against. Required if --scep-listen-address is set.")
+
+	fs.StringVar(&s.ACMEServerListenAddress, "acme-server-listen-address", "", ""+
+		"The host and port that the ACME server should listen on. If not set, the "+
+		"ACME server is not started.")
+	fs.StringVar(&s.ACMEServerIssuerName, "acme-server-issuer-name", "", ""+
+		"The name of the CA Issuer, in --namespace, that the ACME server issues "+
+		"certificates from. Required if --acme-server-listen-address is set.")
+	fs.StringVar(&s.ACMEServerBaseURL, "acme-server-base-url", "", ""+
+		"The externally reachable base URL the ACME server is exposed at, used to "+
+		"build the URLs returned in ACME responses. Required if "+
+		"--acme-server-listen-address is set.")
+
+	fs.StringVar(&s.AuditLogPath, "audit-log-path", "", ""+
+		"A file to append a tamper-evident, hash-chained record of every certificate "+
+		"issued or renewed to. If not set, no file based audit log is kept.")
+	fs.StringVar(&s.AuditLogURL, "audit-log-url", "", ""+
+		"An HTTP endpoint to also POST each audit record to as JSON. If not set, "+
+		"audit records are not pushed anywhere.")
+
+	fs.BoolVar(&s.EnableRolloutRestart, "enable-rollout-restart", defaultEnableRolloutRestart, ""+
+		"If true, whenever a certificate is renewed, any Deployment or StatefulSet in the "+
+		"same namespace referencing its target Secret will be triggered to perform a "+
+		"rolling restart, for applications that don't reload their certificate from disk "+
+		"automatically. Disabled by default, as rolling restarts are disruptive.")
+
+	fs.BoolVar(&s.InstallCRDs, "install-crds", defaultInstallCRDs, ""+
+		"If true, install (or update) the CustomResourceDefinitions cert-manager requires "+
+		"on startup, before any controllers are started. This simplifies bare-manifest "+
+		"deployments that don't separately apply docs/crd.yaml; it is skipped by default "+
+		"as most deployments manage CRD installation themselves (e.g. via Helm).")
+
+	fs.BoolVar(&s.InstallRBAC, "install-rbac", defaultInstallRBAC, ""+
+		"If true, install (or update) the aggregated view/edit/admin ClusterRoles "+
+		"cert-manager ships for its CRDs on startup, before any controllers are started. "+
+		"It is skipped by default as most deployments manage RBAC installation themselves "+
+		"(e.g. via Helm).")
+
+	fs.BoolVar(&s.StrictFIPS, "strict-fips-mode", defaultStrictFIPS, ""+
+		"If true, reject Certificate specs that request a key size that is not FIPS 140-2 "+
+		"approved (2048, 3072 or 4096 bit RSA keys) with a clear error, instead of issuing "+
+		"a non-compliant certificate. Disabled by default.")
+
+	fs.DurationVar(&s.RenewBeforeJitter, "renew-before-jitter", defaultRenewBeforeJitter, ""+
+		"The upper bound of a random per-certificate offset added to the renewal window, "+
+		"so that a large number of certificates that became due for renewal at the same "+
+		"instant (e.g. issued together at cluster bootstrap) don't all attempt renewal "+
+		"simultaneously. Each certificate always computes the same offset, so its "+
+		"renewal time remains stable across resyncs. Disabled (zero) by default.")
+
+	fs.DurationVar(&s.RenewalClockSkew, "renewal-clock-skew", defaultRenewalClockSkew, ""+
+		"An additional fixed margin added to every certificate's renewal window, on top "+
+		"of --renew-before-jitter, to compensate for clock drift between this controller "+
+		"and whatever issued the certificates it manages. Disabled (zero) by default.")
+
+	fs.IntVar(&s.MaxConcurrentKeyGen, "max-concurrent-keygen", defaultMaxConcurrentKeyGen, ""+
+		"The maximum number of RSA private keys of any single key size that may be "+
+		"generated concurrently, across all reconciles, to avoid a burst of new "+
+		"Certificates saturating CPU on resource-constrained nodes (e.g. small ARM "+
+		"instances). Each key size is bounded independently. Unlimited (zero) by default.")
+
+	fs.DurationVar(&s.JanitorResourceTTL, "janitor-resource-ttl", defaultJanitorResourceTTL, ""+
+		"How long a leftover ACME HTTP-01 solver Service, Ingress or Job is kept around after "+
+		"creation before the janitor controller deletes it as stale. Solver resources are "+
+		"normally cleaned up as soon as their challenge completes; this only catches ones "+
+		"orphaned by a crash or a deleted Certificate.")
+
 	fs.BoolVar(&s.LeaderElect, "leader-elect", true, ""+
 		"If true, cert-manager will perform leader election between instances to ensure no more "+
 		"than one instance of cert-manager operates at a time")
@@ -12,8 +12,13 @@ import (
 	"github.com/jetstack-experimental/cert-manager/cmd/controller/app/options"
 	_ "github.com/jetstack-experimental/cert-manager/pkg/controller/certificates"
 	_ "github.com/jetstack-experimental/cert-manager/pkg/controller/issuers"
+	_ "github.com/jetstack-experimental/cert-manager/pkg/controller/janitor"
 	_ "github.com/jetstack-experimental/cert-manager/pkg/issuer/acme"
 	_ "github.com/jetstack-experimental/cert-manager/pkg/issuer/ca"
+	_ "github.com/jetstack-experimental/cert-manager/pkg/issuer/est"
+	_ "github.com/jetstack-experimental/cert-manager/pkg/issuer/fake"
+	_ "github.com/jetstack-experimental/cert-manager/pkg/issuer/hub"
+	_ "github.com/jetstack-experimental/cert-manager/pkg/issuer/stepca"
 )
 
 type CertManagerControllerOptions struct {
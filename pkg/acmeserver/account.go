@@ -0,0 +1,57 @@
+package acmeserver
+
+import (
+	"crypto"
+	"fmt"
+	"sync"
+)
+
+// account is a registered ACME account, keyed by the RFC 7638 thumbprint of
+// its public key.
+type account struct {
+	id  string
+	key crypto.PublicKey
+}
+
+// accountStore holds registered accounts in memory. Like nonceStore, this
+// only works correctly with a single running instance of the server.
+type accountStore struct {
+	mu       sync.RWMutex
+	accounts map[string]*account
+}
+
+func newAccountStore() *accountStore {
+	return &accountStore{accounts: make(map[string]*account)}
+}
+
+// getOrCreate returns the existing account for jwk if one has already
+// registered with this key, otherwise it registers and returns a new one.
+func (s *accountStore) getOrCreate(jwk *jsonWebKey) (*account, error) {
+	id, err := jwk.thumbprint()
+	if err != nil {
+		return nil, err
+	}
+	pub, err := jwk.publicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if acc, ok := s.accounts[id]; ok {
+		return acc, nil
+	}
+	acc := &account{id: id, key: pub}
+	s.accounts[id] = acc
+	return acc, nil
+}
+
+func (s *accountStore) get(id string) (*account, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	acc, ok := s.accounts[id]
+	if !ok {
+		return nil, fmt.Errorf("account %q not found", id)
+	}
+	return acc, nil
+}
@@ -0,0 +1,192 @@
+package acmeserver
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// jwsRequest is the flattened JWS structure ACME clients POST as their
+// request body (RFC 8555 section 6.2).
+type jwsRequest struct {
+	Protected string `json:"protected"`
+	Payload   string `json:"payload"`
+	Signature string `json:"signature"`
+}
+
+// jwsHeader is the subset of the JWS protected header ACME uses.
+type jwsHeader struct {
+	Alg   string      `json:"alg"`
+	Nonce string      `json:"nonce"`
+	URL   string      `json:"url"`
+	JWK   *jsonWebKey `json:"jwk,omitempty"`
+	Kid   string      `json:"kid,omitempty"`
+}
+
+// jsonWebKey is the subset of RFC 7517 needed to carry the RSA or EC P-256
+// public keys ACME accounts are keyed by.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// EC
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// publicKey decodes the Go crypto.PublicKey this JWK represents.
+func (k *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64URLDecode(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding RSA modulus: %s", err.Error())
+		}
+		e, err := base64URLDecode(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding RSA exponent: %s", err.Error())
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported EC curve %q", k.Crv)
+		}
+		x, err := base64URLDecode(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding EC x coordinate: %s", err.Error())
+		}
+		y, err := base64URLDecode(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding EC y coordinate: %s", err.Error())
+		}
+		return &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported JWK key type %q", k.Kty)
+}
+
+// thumbprint computes the RFC 7638 JWK thumbprint, used as a stable
+// identifier for the account this key belongs to.
+func (k *jsonWebKey) thumbprint() (string, error) {
+	var canonical interface{}
+	switch k.Kty {
+	case "RSA":
+		canonical = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{E: k.E, Kty: k.Kty, N: k.N}
+	case "EC":
+		canonical = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{Crv: k.Crv, Kty: k.Kty, X: k.X, Y: k.Y}
+	default:
+		return "", fmt.Errorf("unsupported JWK key type %q", k.Kty)
+	}
+
+	b, err := json.Marshal(canonical)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return base64URLEncode(sum[:]), nil
+}
+
+// verifiedJWS is the result of successfully verifying a jwsRequest.
+type verifiedJWS struct {
+	header  jwsHeader
+	payload []byte
+}
+
+// verifyJWS verifies req's signature. If req's protected header carries an
+// embedded jwk, that key is used and returned; otherwise lookupKey is
+// called with the header's kid to resolve the account's public key.
+func verifyJWS(req *jwsRequest, lookupKey func(kid string) (crypto.PublicKey, error)) (*verifiedJWS, error) {
+	protectedJSON, err := base64URLDecode(req.Protected)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding protected header: %s", err.Error())
+	}
+
+	var header jwsHeader
+	if err := json.Unmarshal(protectedJSON, &header); err != nil {
+		return nil, fmt.Errorf("error parsing protected header: %s", err.Error())
+	}
+
+	var pub crypto.PublicKey
+	if header.JWK != nil {
+		pub, err = header.JWK.publicKey()
+	} else if header.Kid != "" {
+		pub, err = lookupKey(header.Kid)
+	} else {
+		return nil, fmt.Errorf("protected header must carry either jwk or kid")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64URLDecode(req.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding signature: %s", err.Error())
+	}
+
+	signingInput := req.Protected + "." + req.Payload
+	digest := sha256.Sum256([]byte(signingInput))
+
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		if header.Alg != "RS256" {
+			return nil, fmt.Errorf("unsupported alg %q for RSA key", header.Alg)
+		}
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return nil, fmt.Errorf("signature verification failed: %s", err.Error())
+		}
+	case *ecdsa.PublicKey:
+		if header.Alg != "ES256" {
+			return nil, fmt.Errorf("unsupported alg %q for EC key", header.Alg)
+		}
+		if len(signature) != 64 {
+			return nil, fmt.Errorf("invalid ES256 signature length %d", len(signature))
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(key, digest[:], r, s) {
+			return nil, fmt.Errorf("signature verification failed")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", pub)
+	}
+
+	payload, err := base64URLDecode(req.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding payload: %s", err.Error())
+	}
+
+	return &verifiedJWS{header: header, payload: payload}, nil
+}
+
+func base64URLDecode(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+func base64URLEncode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
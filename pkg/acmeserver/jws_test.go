@@ -0,0 +1,120 @@
+package acmeserver
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+	"testing"
+)
+
+func mustGenerateRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error generating RSA key: %s", err)
+	}
+	return key
+}
+
+func signJWS(t *testing.T, key *rsa.PrivateKey, header jwsHeader, payload []byte) *jwsRequest {
+	t.Helper()
+
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		t.Fatalf("error marshalling header: %s", err)
+	}
+
+	protected := base64URLEncode(headerJSON)
+	encodedPayload := base64URLEncode(payload)
+
+	signingInput := protected + "." + encodedPayload
+	digest := sha256.Sum256([]byte(signingInput))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("error signing: %s", err)
+	}
+
+	return &jwsRequest{
+		Protected: protected,
+		Payload:   encodedPayload,
+		Signature: base64URLEncode(signature),
+	}
+}
+
+func rsaJWK(key *rsa.PrivateKey) *jsonWebKey {
+	return &jsonWebKey{
+		Kty: "RSA",
+		N:   base64URLEncode(key.PublicKey.N.Bytes()),
+		E:   base64URLEncode(encodeExponent(key.PublicKey.E)),
+	}
+}
+
+// encodeExponent encodes e as the minimal big-endian byte string a JWK
+// expects, which is enough for the exponents (typically 65537) that
+// rsa.GenerateKey produces.
+func encodeExponent(e int) []byte {
+	return []byte{byte(e >> 16), byte(e >> 8), byte(e)}
+}
+
+func TestVerifyJWS(t *testing.T) {
+	key := mustGenerateRSAKey(t)
+	jwk := rsaJWK(key)
+
+	req := signJWS(t, key, jwsHeader{Alg: "RS256", Nonce: "abc", URL: "https://example.com/new-account", JWK: jwk}, []byte(`{"foo":"bar"}`))
+
+	verified, err := verifyJWS(req, nil)
+	if err != nil {
+		t.Fatalf("unexpected error verifying valid JWS: %s", err)
+	}
+	if string(verified.payload) != `{"foo":"bar"}` {
+		t.Errorf("unexpected payload: %s", verified.payload)
+	}
+}
+
+func TestVerifyJWSRejectsBadSignature(t *testing.T) {
+	key := mustGenerateRSAKey(t)
+	jwk := rsaJWK(key)
+
+	req := signJWS(t, key, jwsHeader{Alg: "RS256", Nonce: "abc", JWK: jwk}, []byte(`{"foo":"bar"}`))
+	req.Payload = base64URLEncode([]byte(`{"foo":"tampered"}`))
+
+	if _, err := verifyJWS(req, nil); err == nil {
+		t.Errorf("expected error verifying tampered JWS, got nil")
+	}
+}
+
+func TestVerifyJWSResolvesKidViaLookup(t *testing.T) {
+	key := mustGenerateRSAKey(t)
+	req := signJWS(t, key, jwsHeader{Alg: "RS256", Nonce: "abc", Kid: "account-1"}, []byte(`{}`))
+
+	lookup := func(kid string) (crypto.PublicKey, error) {
+		if kid != "account-1" {
+			t.Fatalf("unexpected kid: %s", kid)
+		}
+		return &key.PublicKey, nil
+	}
+
+	if _, err := verifyJWS(req, lookup); err != nil {
+		t.Fatalf("unexpected error verifying JWS with kid: %s", err)
+	}
+}
+
+func TestJSONWebKeyThumbprintIsStable(t *testing.T) {
+	key := mustGenerateRSAKey(t)
+	jwk := rsaJWK(key)
+
+	t1, err := jwk.thumbprint()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	t2, err := jwk.thumbprint()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if t1 != t2 {
+		t.Errorf("expected thumbprint to be stable, got %q and %q", t1, t2)
+	}
+}
@@ -0,0 +1,43 @@
+package acmeserver
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"sync"
+)
+
+// nonceStore issues and consumes single-use anti-replay nonces (RFC 8555
+// section 6.5). It is held in memory, so is only correct when a single
+// instance of the ACME server is running; nonces do not survive a restart.
+type nonceStore struct {
+	mu     sync.Mutex
+	active map[string]struct{}
+}
+
+func newNonceStore() *nonceStore {
+	return &nonceStore{active: make(map[string]struct{})}
+}
+
+func (n *nonceStore) issue() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	nonce := base64.RawURLEncoding.EncodeToString(b)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.active[nonce] = struct{}{}
+	return nonce, nil
+}
+
+// consume returns true and invalidates nonce if it was outstanding.
+func (n *nonceStore) consume(nonce string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if _, ok := n.active[nonce]; !ok {
+		return false
+	}
+	delete(n.active, nonce)
+	return true
+}
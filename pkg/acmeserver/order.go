@@ -0,0 +1,68 @@
+package acmeserver
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+)
+
+const (
+	orderStatusReady = "ready"
+	orderStatusValid = "valid"
+)
+
+// order is an in-flight or completed certificate request. Since this
+// server issues from a trusted internal CA rather than performing domain
+// control validation, an order is immediately "ready" for finalization
+// once created - there is no pending/processing challenge state.
+type order struct {
+	id      string
+	domains []string
+	status  string
+
+	certPEM []byte
+	caPEM   []byte
+}
+
+// orderStore holds orders in memory, like accountStore and nonceStore.
+type orderStore struct {
+	mu     sync.RWMutex
+	orders map[string]*order
+}
+
+func newOrderStore() *orderStore {
+	return &orderStore{orders: make(map[string]*order)}
+}
+
+func (s *orderStore) create(domains []string) (*order, error) {
+	id, err := randomID()
+	if err != nil {
+		return nil, err
+	}
+
+	o := &order{id: id, domains: domains, status: orderStatusReady}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orders[id] = o
+	return o, nil
+}
+
+func (s *orderStore) get(id string) (*order, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	o, ok := s.orders[id]
+	if !ok {
+		return nil, fmt.Errorf("order %q not found", id)
+	}
+	return o, nil
+}
+
+func randomID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
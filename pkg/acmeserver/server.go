@@ -0,0 +1,400 @@
+// Package acmeserver exposes an ACME (RFC 8555) compatible endpoint backed
+// by a cert-manager CA Issuer, so ACME clients that live outside the
+// cluster (or software, like certbot or traefik, that only knows how to
+// speak ACME) can obtain certificates from the same internal CA that
+// in-cluster Certificate resources use.
+//
+// This server issues from a trusted internal CA rather than a publicly
+// trusted one, so it deliberately does not perform domain control
+// validation: an order is authorized as soon as its JWS is verified,
+// which proves possession of the requesting account's private key. There
+// is no pending/processing challenge state - authorizations are created
+// already valid, and finalize signs the certificate immediately. This is
+// an appropriate trust model for an internal CA fronting clients that
+// already have some other route to cluster trust, but would not be safe
+// to expose as a publicly trusted CA.
+package acmeserver
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/golang/glog"
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/client/clientset"
+	"github.com/jetstack-experimental/cert-manager/pkg/issuer/ca"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/pki"
+)
+
+// Server answers ACME requests against a single cert-manager CA Issuer.
+type Server struct {
+	client   kubernetes.Interface
+	cmClient clientset.Interface
+
+	namespace  string
+	issuerName string
+
+	// baseURL is the externally reachable URL this server is served at,
+	// e.g. "https://ca.example.com/acme". It is used to build the
+	// absolute URLs returned in the directory and Location headers.
+	baseURL string
+
+	nonces   *nonceStore
+	accounts *accountStore
+	orders   *orderStore
+}
+
+// New returns a Server that answers ACME requests using the CA Issuer
+// named issuerName in namespace, advertising itself at baseURL.
+func New(client kubernetes.Interface, cmClient clientset.Interface, namespace, issuerName, baseURL string) *Server {
+	return &Server{
+		client:     client,
+		cmClient:   cmClient,
+		namespace:  namespace,
+		issuerName: issuerName,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		nonces:     newNonceStore(),
+		accounts:   newAccountStore(),
+		orders:     newOrderStore(),
+	}
+}
+
+func (s *Server) url(path string) string {
+	return s.baseURL + path
+}
+
+// Handler returns an http.Handler serving the ACME endpoints. Path
+// parameters (order and authorization IDs) are extracted manually, rather
+// than with the pattern based routing added to net/http in Go 1.22, since
+// this repository currently targets an older Go toolchain.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/acme/directory", s.serveDirectory)
+	mux.HandleFunc("/acme/new-nonce", s.serveNewNonce)
+	mux.HandleFunc("/acme/new-account", s.serveNewAccount)
+	mux.HandleFunc("/acme/new-order", s.serveNewOrder)
+	mux.HandleFunc("/acme/authz/", s.serveAuthorization)
+	mux.HandleFunc("/acme/order/", s.serveOrder)
+	mux.HandleFunc("/acme/cert/", s.serveCertificate)
+	return mux
+}
+
+func (s *Server) serveDirectory(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"newNonce":   s.url("/acme/new-nonce"),
+		"newAccount": s.url("/acme/new-account"),
+		"newOrder":   s.url("/acme/new-order"),
+	})
+}
+
+func (s *Server) serveNewNonce(w http.ResponseWriter, r *http.Request) {
+	s.issueNonce(w)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) issueNonce(w http.ResponseWriter) {
+	nonce, err := s.nonces.issue()
+	if err != nil {
+		glog.Errorf("error issuing nonce: %s", err.Error())
+		return
+	}
+	w.Header().Set("Replay-Nonce", nonce)
+}
+
+// readJWS decodes and verifies the JWS request body, resolving the
+// signing key either from an embedded jwk or, if resolveKid is non-nil,
+// via a kid referencing a previously registered account.
+func (s *Server) readJWS(r *http.Request, lookupKey func(kid string) (crypto.PublicKey, error)) (*verifiedJWS, error) {
+	var req jwsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("error parsing request body: %s", err.Error())
+	}
+
+	verified, err := verifyJWS(&req, lookupKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.nonces.consume(verified.header.Nonce) {
+		return nil, fmt.Errorf("nonce %q is invalid or already used", verified.header.Nonce)
+	}
+
+	return verified, nil
+}
+
+func (s *Server) serveNewAccount(w http.ResponseWriter, r *http.Request) {
+	s.issueNonce(w)
+
+	verified, err := s.readJWS(r, func(kid string) (crypto.PublicKey, error) {
+		return nil, fmt.Errorf("new-account requires an embedded jwk, not a kid")
+	})
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, "malformed", err.Error())
+		return
+	}
+
+	// verifyJWS already required the protected header to carry either a
+	// jwk or a kid; new-account always signs with jwk, so it is safe to
+	// use here.
+	acc, err := s.accounts.getOrCreate(verified.header.JWK)
+	if err != nil {
+		writeProblem(w, http.StatusInternalServerError, "serverInternal", err.Error())
+		return
+	}
+
+	w.Header().Set("Location", s.url("/acme/account/"+acc.id))
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"status": "valid",
+	})
+}
+
+// jwsRequestPreview is the subset of an ACME request payload this server
+// reads directly; most fields (e.g. newAccount's contact) are accepted
+// but otherwise ignored, since this server doesn't need them to issue
+// certificates.
+type jwsRequestPreview struct {
+	Identifiers []identifier `json:"identifiers,omitempty"`
+	CSR         string       `json:"csr,omitempty"`
+}
+
+type identifier struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+func (s *Server) lookupAccountKey(kid string) (crypto.PublicKey, error) {
+	id := kid
+	if i := strings.LastIndex(kid, "/"); i != -1 {
+		id = kid[i+1:]
+	}
+	acc, err := s.accounts.get(id)
+	if err != nil {
+		return nil, err
+	}
+	return acc.key, nil
+}
+
+func (s *Server) serveNewOrder(w http.ResponseWriter, r *http.Request) {
+	s.issueNonce(w)
+
+	verified, err := s.readJWS(r, s.lookupAccountKey)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, "malformed", err.Error())
+		return
+	}
+
+	var body jwsRequestPreview
+	if err := json.Unmarshal(verified.payload, &body); err != nil {
+		writeProblem(w, http.StatusBadRequest, "malformed", err.Error())
+		return
+	}
+
+	var domains []string
+	for _, id := range body.Identifiers {
+		if id.Type != "dns" {
+			writeProblem(w, http.StatusBadRequest, "unsupportedIdentifier", fmt.Sprintf("identifier type %q is not supported", id.Type))
+			return
+		}
+		domains = append(domains, id.Value)
+	}
+	if len(domains) == 0 {
+		writeProblem(w, http.StatusBadRequest, "malformed", "order must contain at least one identifier")
+		return
+	}
+
+	o, err := s.orders.create(domains)
+	if err != nil {
+		writeProblem(w, http.StatusInternalServerError, "serverInternal", err.Error())
+		return
+	}
+
+	w.Header().Set("Location", s.url("/acme/order/"+o.id))
+	writeJSON(w, http.StatusCreated, s.orderResponse(o))
+}
+
+func (s *Server) orderResponse(o *order) map[string]interface{} {
+	var identifiers []identifier
+	for _, d := range o.domains {
+		identifiers = append(identifiers, identifier{Type: "dns", Value: d})
+	}
+
+	resp := map[string]interface{}{
+		"status":         o.status,
+		"identifiers":    identifiers,
+		"authorizations": []string{s.url("/acme/authz/" + o.id)},
+		"finalize":       s.url("/acme/order/" + o.id + "/finalize"),
+	}
+	if o.status == orderStatusValid {
+		resp["certificate"] = s.url("/acme/cert/" + o.id)
+	}
+	return resp
+}
+
+// serveAuthorization returns an authorization that is already valid,
+// since this server authorizes orders based on JWS signature verification
+// alone (see the package doc comment).
+func (s *Server) serveAuthorization(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/acme/authz/")
+	o, err := s.orders.get(id)
+	if err != nil {
+		writeProblem(w, http.StatusNotFound, "malformed", err.Error())
+		return
+	}
+	if len(o.domains) == 0 {
+		writeProblem(w, http.StatusNotFound, "malformed", "authorization not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status":     "valid",
+		"identifier": identifier{Type: "dns", Value: o.domains[0]},
+		"challenges": []map[string]string{},
+	})
+}
+
+func (s *Server) serveOrder(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/acme/order/")
+
+	if strings.HasSuffix(path, "/finalize") {
+		s.finalizeOrder(w, r, strings.TrimSuffix(path, "/finalize"))
+		return
+	}
+
+	o, err := s.orders.get(path)
+	if err != nil {
+		writeProblem(w, http.StatusNotFound, "malformed", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, s.orderResponse(o))
+}
+
+func (s *Server) finalizeOrder(w http.ResponseWriter, r *http.Request, id string) {
+	s.issueNonce(w)
+
+	o, err := s.orders.get(id)
+	if err != nil {
+		writeProblem(w, http.StatusNotFound, "malformed", err.Error())
+		return
+	}
+
+	verified, err := s.readJWS(r, s.lookupAccountKey)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, "malformed", err.Error())
+		return
+	}
+
+	var body jwsRequestPreview
+	if err := json.Unmarshal(verified.payload, &body); err != nil {
+		writeProblem(w, http.StatusBadRequest, "malformed", err.Error())
+		return
+	}
+
+	csrDER, err := base64URLDecode(body.CSR)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, "malformed", fmt.Sprintf("error decoding csr: %s", err.Error()))
+		return
+	}
+
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, "malformed", fmt.Sprintf("error parsing csr: %s", err.Error()))
+		return
+	}
+
+	certPEM, caPEM, err := s.signCSR(csr, o.domains)
+	if err != nil {
+		writeProblem(w, http.StatusInternalServerError, "serverInternal", err.Error())
+		return
+	}
+
+	o.certPEM = certPEM
+	o.caPEM = caPEM
+	o.status = orderStatusValid
+
+	writeJSON(w, http.StatusOK, s.orderResponse(o))
+}
+
+// signCSR signs csr against the configured CA Issuer's signing keypair,
+// returning the leaf certificate and CA certificate, both PEM encoded.
+// Unlike the CA issuer controller, this reads the Issuer and its signing
+// Secret directly rather than via a lister, matching pkg/scep's approach:
+// this server has no informer set up of its own.
+func (s *Server) signCSR(csr *x509.CertificateRequest, domains []string) (certPEM, caPEM []byte, err error) {
+	iss, err := s.cmClient.CertmanagerV1alpha1().Issuers(s.namespace).Get(s.issuerName, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting issuer %q: %s", s.issuerName, err.Error())
+	}
+	if iss.Spec.CA == nil {
+		return nil, nil, fmt.Errorf("issuer %q is not a CA issuer: ACME server mode is only supported for CA issuers", s.issuerName)
+	}
+
+	secret, err := s.client.CoreV1().Secrets(s.namespace).Get(iss.Spec.CA.SecretRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting issuer signing keypair: %s", err.Error())
+	}
+
+	signerCert, err := pki.DecodeX509CertificateBytes(secret.Data[api.TLSCertKey])
+	if err != nil {
+		return nil, nil, fmt.Errorf("error decoding issuer signing certificate: %s", err.Error())
+	}
+
+	signerKey, err := pki.DecodePKCS1PrivateKeyBytes(secret.Data[api.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, nil, fmt.Errorf("error decoding issuer signing key: %s", err.Error())
+	}
+
+	crt := &v1alpha1.Certificate{
+		Spec: v1alpha1.CertificateSpec{
+			Domains: domains,
+		},
+	}
+
+	certPEM, _, err = ca.SignCertificate(crt, signerCert, csr.PublicKey, signerKey, iss.Spec.CA.SerialNumberBits, clock.RealClock{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error signing certificate: %s", err.Error())
+	}
+
+	return certPEM, secret.Data[api.TLSCertKey], nil
+}
+
+func (s *Server) serveCertificate(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/acme/cert/")
+
+	o, err := s.orders.get(id)
+	if err != nil || o.status != orderStatusValid {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pem-certificate-chain")
+	w.Write(o.certPEM)
+	w.Write(o.caPEM)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		glog.Errorf("error encoding response: %s", err.Error())
+	}
+}
+
+// writeProblem writes an RFC 7807 problem document, as ACME (RFC 8555
+// section 6.7) requires for errors.
+func writeProblem(w http.ResponseWriter, status int, problemType, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{
+		"type":   "urn:ietf:params:acme:error:" + problemType,
+		"detail": detail,
+	})
+}
@@ -5,4 +5,72 @@ const (
 	// ingress resource that signals the resource should have an automatically
 	// provisioned TLS certificate
 	AnnotationIngressACMETLS = "certmanager.kubernetes.io/enabled"
+
+	// AnnotationIssuePaused is an annotation that can be added to a
+	// Certificate resource, with the value "true", to suspend issuance and
+	// renewal for that Certificate. This is useful during incident response
+	// or migrations, where a controller repeatedly re-issuing a certificate
+	// would be undesirable.
+	AnnotationIssuePaused = "certmanager.kubernetes.io/issue-paused"
+
+	// AnnotationRenewNow is an annotation that can be added to a Certificate
+	// resource, with the value "true", to force immediate re-issuance
+	// regardless of the certificate's remaining validity. It is removed
+	// automatically once the forced renewal has been triggered.
+	AnnotationRenewNow = "certmanager.kubernetes.io/renew-now"
+
+	// AnnotationIssuerName is set by the certificates controller on the
+	// Secret it writes an issued certificate to, recording the name of
+	// the Issuer that produced it. It is used to detect when a
+	// Certificate's spec.issuer has been changed to point at a different
+	// Issuer, so re-issuance can be triggered immediately rather than
+	// waiting for the existing certificate to approach expiry.
+	AnnotationIssuerName = "certmanager.kubernetes.io/issuer-name"
+
+	// AnnotationCertificateChecksum is set by the certificates controller on
+	// the Secret it writes an issued certificate to, recording a checksum of
+	// the certificate data. It changes whenever the certificate is
+	// re-issued or renewed, so tools that watch Secrets for changes (for
+	// example to trigger a rolling restart of Pods consuming them) can
+	// detect rotation cheaply, without having to parse and compare the
+	// certificate itself.
+	AnnotationCertificateChecksum = "certmanager.kubernetes.io/certificate-checksum"
+
+	// AnnotationDefaultIssuer can be added to a Namespace resource, with the
+	// name of an Issuer in that namespace as its value, to let namespace
+	// admins set a default issuer for that namespace. A Certificate in that
+	// namespace with an empty spec.issuer then inherits this value, rather
+	// than having to name the Issuer explicitly.
+	AnnotationDefaultIssuer = "certmanager.kubernetes.io/default-issuer"
+
+	// AnnotationACMERolloverAccountKey can be added to an ACME Issuer
+	// resource, with the value "true", to roll the ACME account over onto a
+	// newly generated private key, for example after the existing key is
+	// suspected to have been compromised. It is removed automatically once
+	// the rollover has been triggered.
+	AnnotationACMERolloverAccountKey = "certmanager.kubernetes.io/acme-rollover-account-key"
+
+	// AnnotationACMEDeactivateAccount can be added to an ACME Issuer
+	// resource, with the value "true", to deactivate the ACME account with
+	// the ACME server, for example as part of decommissioning an issuer. It
+	// is removed automatically once the deactivation has been attempted.
+	AnnotationACMEDeactivateAccount = "certmanager.kubernetes.io/acme-deactivate-account"
+
+	// AnnotationCurrentSecretName is set by the certificates controller on a
+	// Certificate resource whose spec.immutableSecretRotation is true,
+	// recording the name of the Secret that currently holds the active
+	// certificate. Consumers that need to follow rotations of such a
+	// Certificate should watch this annotation rather than assuming the
+	// certificate is always written to spec.secretName.
+	AnnotationCurrentSecretName = "certmanager.kubernetes.io/current-secret-name"
+
+	// AnnotationAllowedReferenceNamespaces is set on a Secret to grant CA
+	// issuers in other namespaces permission to read it as their
+	// CAIssuer.SecretRef, via CAIssuer.SecretNamespace. Its value is a
+	// comma-separated list of namespaces allowed to reference it, or "*"
+	// to allow any namespace. Without this annotation, a cross-namespace
+	// SecretRef is refused, so CA key material in a locked-down namespace
+	// cannot be read by an Issuer elsewhere in the cluster unless its
+	// namespace has been explicitly granted access.
+	AnnotationAllowedReferenceNamespaces = "certmanager.kubernetes.io/allowed-reference-namespaces"
 )
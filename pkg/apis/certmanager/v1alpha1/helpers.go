@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang/glog"
@@ -24,15 +25,66 @@ func (a *ACMEIssuerDNS01Config) Provider(name string) (*ACMEIssuerDNS01Provider,
 	return nil, fmt.Errorf("provider '%s' not found", name)
 }
 
+// ConfigForDomain returns the ACMECertificateDomainConfig that should be
+// used to solve a challenge for domain. A cfg.Domains entry matches domain
+// either exactly, or as a parent zone of domain (e.g. a "example.com" entry
+// matches "foo.example.com"), so that a delegated subzone handled by a
+// different DNS account does not need to be enumerated ahead of time. When
+// more than one entry matches, the most specific (longest) match wins.
 func (a *ACMECertificateConfig) ConfigForDomain(domain string) ACMECertificateDomainConfig {
+	var best ACMECertificateDomainConfig
+	bestMatch := ""
+
 	for _, cfg := range a.Config {
 		for _, d := range cfg.Domains {
-			if d == domain {
-				return cfg
+			if d != domain && !strings.HasSuffix(domain, "."+d) {
+				continue
+			}
+			if len(d) > len(bestMatch) {
+				bestMatch = d
+				best = cfg
 			}
 		}
 	}
-	return ACMECertificateDomainConfig{}
+
+	return best
+}
+
+// Default Secret data keys used to store an issued certificate, matching
+// the Kubernetes TLS Secret conventions (corev1.TLSCertKey, corev1.TLSPrivateKeyKey
+// and corev1.ServiceAccountRootCAKey), for Certificates that do not
+// override them via Spec.SecretKeys.
+const (
+	DefaultCertificateKey = "tls.crt"
+	DefaultPrivateKeyKey  = "tls.key"
+	DefaultCAKey          = "ca.crt"
+)
+
+// CertificateKey returns the Secret data key the issued certificate should
+// be stored under, honoring Spec.SecretKeys.CertificateKey if set.
+func (c *Certificate) CertificateKey() string {
+	if c.Spec.SecretKeys != nil && c.Spec.SecretKeys.CertificateKey != "" {
+		return c.Spec.SecretKeys.CertificateKey
+	}
+	return DefaultCertificateKey
+}
+
+// PrivateKeyKey returns the Secret data key the private key should be
+// stored under, honoring Spec.SecretKeys.PrivateKeyKey if set.
+func (c *Certificate) PrivateKeyKey() string {
+	if c.Spec.SecretKeys != nil && c.Spec.SecretKeys.PrivateKeyKey != "" {
+		return c.Spec.SecretKeys.PrivateKeyKey
+	}
+	return DefaultPrivateKeyKey
+}
+
+// CAKey returns the Secret data key the issuing CA certificate should be
+// stored under, honoring Spec.SecretKeys.CAKey if set.
+func (c *Certificate) CAKey() string {
+	if c.Spec.SecretKeys != nil && c.Spec.SecretKeys.CAKey != "" {
+		return c.Spec.SecretKeys.CAKey
+	}
+	return DefaultCAKey
 }
 
 func (c *CertificateStatus) ACMEStatus() *CertificateACMEStatus {
@@ -117,23 +169,21 @@ func (crt *Certificate) UpdateStatusCondition(conditionType CertificateCondition
 
 	t := time.Now()
 
-	if len(crt.Status.Conditions) == 0 {
-		glog.Infof("Setting lastTransitionTime for Certificate %q condition %q to %v", crt.Name, conditionType, t)
-		newCondition.LastTransitionTime = metav1.NewTime(t)
-		crt.Status.Conditions = []CertificateCondition{newCondition}
-	} else {
-		for i, cond := range crt.Status.Conditions {
-			if cond.Type == conditionType {
-				if cond.Status != newCondition.Status {
-					glog.Infof("Found status change for Certificate %q condition %q: %q -> %q; setting lastTransitionTime to %v", crt.Name, conditionType, cond.Status, status, t)
-					newCondition.LastTransitionTime = metav1.NewTime(t)
-				} else {
-					newCondition.LastTransitionTime = cond.LastTransitionTime
-				}
-
-				crt.Status.Conditions[i] = newCondition
-				break
+	for i, cond := range crt.Status.Conditions {
+		if cond.Type == conditionType {
+			if cond.Status != newCondition.Status {
+				glog.Infof("Found status change for Certificate %q condition %q: %q -> %q; setting lastTransitionTime to %v", crt.Name, conditionType, cond.Status, status, t)
+				newCondition.LastTransitionTime = metav1.NewTime(t)
+			} else {
+				newCondition.LastTransitionTime = cond.LastTransitionTime
 			}
+
+			crt.Status.Conditions[i] = newCondition
+			return
 		}
 	}
+
+	glog.Infof("Setting lastTransitionTime for Certificate %q condition %q to %v", crt.Name, conditionType, t)
+	newCondition.LastTransitionTime = metav1.NewTime(t)
+	crt.Status.Conditions = append(crt.Status.Conditions, newCondition)
 }
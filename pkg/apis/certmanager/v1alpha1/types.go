@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -25,6 +26,12 @@ import (
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +resource:path=issuers
 
+// Issuer is always namespace-scoped: a Certificate can only reference an
+// Issuer in its own namespace (see c.issuerLister.Issuers(crt.Namespace) in
+// the certificates controller). There is currently no cluster-scoped
+// ClusterIssuer equivalent, so a tenant can never mint certificates from an
+// Issuer defined in another namespace, and a namespace allow/deny list on
+// the Issuer spec would have nothing to restrict.
 type Issuer struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -45,13 +52,249 @@ type IssuerList struct {
 
 // IssuerSpec is the specification of an Issuer. This includes any
 // configuration required for the issuer.
+// TODO: a Vault issuer is not implemented yet. When it is added, its config
+// struct should include a Namespace field for targeting a Vault Enterprise
+// namespace other than root, since many Vault Enterprise deployments don't
+// allow issuing from the root namespace.
 type IssuerSpec struct {
-	ACME *ACMEIssuer `json:"acme,omitempty"`
-	CA   *CAIssuer   `json:"ca,omitempty"`
+	ACME   *ACMEIssuer   `json:"acme,omitempty"`
+	CA     *CAIssuer     `json:"ca,omitempty"`
+	StepCA *StepCAIssuer `json:"stepca,omitempty"`
+	EST    *ESTIssuer    `json:"est,omitempty"`
+	Hub    *HubIssuer    `json:"hub,omitempty"`
+	Fake   *FakeIssuer   `json:"fake,omitempty"`
+
+	// PolicyWebhook, if set, is consulted before every issuance or renewal
+	// performed by this issuer, regardless of its type. The controller
+	// POSTs a description of the pending certificate to the configured URL
+	// and only proceeds with signing if the endpoint allows it, enabling
+	// external governance such as an OPA/Gatekeeper policy.
+	PolicyWebhook *PolicyWebhook `json:"policyWebhook,omitempty"`
+
+	// MaxDuration, if set, caps the requested validity period of
+	// Certificates issued by this issuer (see CertificateSpec.Duration), as
+	// a duration string (e.g. "2160h" for 90 days). Certificates requesting
+	// a longer duration are handled according to MaxDurationPolicy. If not
+	// set, no cap is enforced.
+	MaxDuration string `json:"maxDuration,omitempty"`
+
+	// MaxDurationPolicy determines what happens when a Certificate requests
+	// a duration longer than MaxDuration. One of "Clamp" (silently reduce
+	// the requested duration to MaxDuration, the default) or "Reject" (fail
+	// the issuance with an error).
+	MaxDurationPolicy string `json:"maxDurationPolicy,omitempty"`
+
+	// SyntheticProbe, if set, opts this issuer in to a periodic synthetic
+	// issuance that exercises its full issuance path end-to-end,
+	// independently of any real Certificate resources. This is intended to
+	// surface a broken issuer (expired account, revoked CA, misconfigured
+	// DNS credentials, etc.) via monitoring before it causes a real
+	// certificate's renewal to fail.
+	SyntheticProbe *SyntheticProbeConfig `json:"syntheticProbe,omitempty"`
+}
+
+// SyntheticProbeConfig configures the periodic synthetic canary issuance
+// for an Issuer.
+type SyntheticProbeConfig struct {
+	// DNSName is the domain name requested on the synthetic certificate.
+	// The certificate is never persisted or served, so this does not need
+	// to be a domain actually used by anything, but for ACME issuers it
+	// must still be one this issuer is able to complete a challenge for.
+	DNSName string `json:"dnsName"`
+
+	// IntervalSeconds is how often to perform the synthetic issuance.
+	// Defaults to 3600 (1 hour) if not set.
+	IntervalSeconds int64 `json:"intervalSeconds,omitempty"`
+}
+
+// PolicyWebhook configures an external HTTP(S) endpoint that decides
+// whether a certificate is allowed to be issued or renewed.
+type PolicyWebhook struct {
+	// URL is the HTTP(S) endpoint that will be POSTed a PolicyWebhookRequest
+	// before each issuance or renewal.
+	URL string `json:"url"`
+
+	// FailurePolicy determines what happens if the webhook cannot be
+	// reached, or does not respond within the request timeout. One of
+	// "Fail" (deny the issuance, the default) or "Ignore" (proceed with
+	// issuance as if the webhook had allowed it).
+	FailurePolicy string `json:"failurePolicy,omitempty"`
 }
 
 type CAIssuer struct {
 	SecretRef LocalObjectReference `json:"secretRef"`
+
+	// SecretNamespace, if set, reads SecretRef from this namespace instead
+	// of the Issuer's own namespace, so CA key material can be kept in a
+	// separate, locked-down namespace and shared by CA issuers elsewhere in
+	// the cluster. The referenced Secret must carry the
+	// AnnotationAllowedReferenceNamespaces annotation naming this issuer's
+	// namespace (or "*"), or issuance fails with an error rather than
+	// silently falling back to the Issuer's own namespace: without this
+	// explicit grant, anyone able to create an Issuer could otherwise read
+	// CA key material from any namespace in the cluster.
+	SecretNamespace string `json:"secretNamespace,omitempty"`
+
+	// CATrustBundleOverlap is the duration that the previous signing CA
+	// certificate will continue to be included alongside the current one in
+	// the target secret's ca.crt entry after a rotation of SecretRef. This
+	// allows clients that trust the bundle time to pick up the new root
+	// before the old one is removed. If not set, no overlap is performed and
+	// ca.crt will always only contain the current signing CA certificate.
+	CATrustBundleOverlap string `json:"caTrustBundleOverlap,omitempty"`
+
+	// SerialNumberBits is the bit length of the random serial number
+	// assigned to certificates issued by this issuer. Some devices and
+	// regulatory regimes constrain the encoding of the serial number (for
+	// example, requiring no more than 20 octets, i.e. 160 bits, or a fixed
+	// shorter length). If not set, a 128-bit serial number is used.
+	SerialNumberBits int `json:"serialNumberBits,omitempty"`
+
+	// RotationConcurrency is the maximum number of dependent Certificates
+	// that will be re-issued concurrently when this issuer's SecretRef is
+	// rotated to a new signing keypair. If not set, all dependent
+	// Certificates are re-issued at once.
+	RotationConcurrency int `json:"rotationConcurrency,omitempty"`
+
+	// CTLogURL, if set, is the base URL of an RFC 6962 Certificate
+	// Transparency log (which may be an internally operated log rather
+	// than a public one) that every certificate issued by this issuer is
+	// submitted to. The log's returned SCT is embedded in the issued
+	// certificate, giving organizations that require CT-style
+	// auditability of their internal PKI a verifiable log of everything
+	// this issuer has signed. If not set, certificates are issued
+	// without CT submission.
+	CTLogURL string `json:"ctLogURL,omitempty"`
+
+	// DelegatedNamespaces, if set, causes this issuer to automatically
+	// provision a Name Constraints-limited intermediate signing
+	// certificate and a matching Issuer for each listed tenant namespace,
+	// giving that namespace its own signing authority that cannot be used
+	// to mint certificates for anyone else's domains. This is the closest
+	// equivalent available in this tree to delegating signing authority
+	// from a cluster-scoped issuer, since there is no ClusterIssuer type
+	// (see the note on the Issuer type) - the intermediate is instead
+	// delegated from this namespace-scoped CA issuer directly.
+	DelegatedNamespaces []CADelegatedNamespace `json:"delegatedNamespaces,omitempty"`
+
+	// SignerExpiryThreshold is how long before the signing certificate in
+	// SecretRef reaches its own NotAfter that the issuer is flagged with the
+	// SignerExpiringSoon condition, as a duration string (e.g. "720h" for 30
+	// days). If not set, defaults to 720h.
+	SignerExpiryThreshold string `json:"signerExpiryThreshold,omitempty"`
+
+	// AllowedDNSZones, if set, restricts this issuer to signing
+	// certificates for domains that are equal to, or a subdomain of, one of
+	// the listed zones (e.g. "example.com" allows "example.com" and
+	// "foo.example.com", but not "notexample.com"). A Certificate
+	// requesting any domain outside these zones is refused with a
+	// CertificateConditionDenied condition instead of being issued,
+	// preventing a namespace that can create Certificates against this
+	// issuer from minting certificates for domains it has no business
+	// requesting. If not set, this issuer may sign for any domain.
+	AllowedDNSZones []string `json:"allowedDNSZones,omitempty"`
+}
+
+// CADelegatedNamespace configures the automatic provisioning of a
+// Name Constraints-limited intermediate CA for a single tenant namespace,
+// as described on CAIssuer.DelegatedNamespaces.
+type CADelegatedNamespace struct {
+	// Namespace is the tenant namespace that the intermediate CA Secret
+	// and Issuer are provisioned into.
+	Namespace string `json:"namespace"`
+
+	// Domains restricts the intermediate CA to signing certificates for
+	// these DNS domains (and their subdomains) via an x509 Name
+	// Constraints extension. A client that enforces Name Constraints will
+	// reject a certificate issued by this intermediate for any other
+	// domain.
+	Domains []string `json:"domains"`
+
+	// SecretName is the name of the Secret that the intermediate CA
+	// keypair is written to in Namespace. Defaults to
+	// "<issuer name>-delegated-ca" if not set.
+	SecretName string `json:"secretName,omitempty"`
+
+	// IssuerName is the name of the Issuer created in Namespace to use
+	// the provisioned intermediate CA. Defaults to the same value as
+	// SecretName if not set.
+	IssuerName string `json:"issuerName,omitempty"`
+}
+
+// StepCAIssuer contains the specification for an issuer that requests
+// certificates from a Smallstep step-ca server's provisioner API. Only JWK
+// provisioners are supported; there is no OIDC provisioner equivalent.
+type StepCAIssuer struct {
+	// URL is the base URL of the step-ca server, e.g. "https://ca.example.com".
+	URL string `json:"url"`
+
+	// CABundle is a PEM encoded CA bundle which will be used to validate the
+	// step-ca server's TLS certificate, in addition to the system trust
+	// store. This is typically step-ca's own root certificate, since
+	// step-ca commonly serves its API using a certificate it issued itself.
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// Provisioner is the name of the step-ca provisioner that this issuer
+	// authenticates as when requesting certificates.
+	Provisioner string `json:"provisioner"`
+
+	// ProvisionerPasswordSecretRef references a Secret key containing the
+	// decryption password for Provisioner, an encrypted JWK provisioner.
+	// It is used to mint the one-time token step-ca requires on every sign
+	// request.
+	ProvisionerPasswordSecretRef SecretKeySelector `json:"provisionerPasswordSecretRef"`
+}
+
+// ESTIssuer contains the specification for an issuer that enrols
+// certificates from an EST (RFC 7030) server, such as EJBCA or Microsoft
+// NDES, using the simpleenroll operation.
+type ESTIssuer struct {
+	// URL is the base URL of the EST server, e.g.
+	// "https://ca.example.com/.well-known/est".
+	URL string `json:"url"`
+
+	// CABundle is a PEM encoded CA bundle which will be used to validate
+	// the EST server's TLS certificate, in addition to the system trust
+	// store.
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// Username is the username to authenticate to the EST server with,
+	// using HTTP Basic authentication.
+	Username string `json:"username,omitempty"`
+
+	// PasswordSecretRef references a Secret key containing the password
+	// to authenticate to the EST server with, using HTTP Basic
+	// authentication.
+	PasswordSecretRef SecretKeySelector `json:"passwordSecretRef,omitempty"`
+}
+
+// HubIssuer contains the specification for an issuer that forwards signing
+// requests to a hub cluster's cert-manager (see pkg/issuer/hub and
+// pkg/hubserver), authenticated with a bearer token, instead of holding CA
+// credentials locally. This is the spoke side of a hub/spoke multi-cluster
+// deployment: the private key is always generated locally in the spoke
+// cluster, and only the CSR is ever sent to the hub, so CA credentials
+// never need to exist outside the hub cluster.
+type HubIssuer struct {
+	// URL is the base URL of the hub cluster's signing endpoint, e.g.
+	// "https://cert-manager-hub.example.com".
+	URL string `json:"url"`
+
+	// CABundle is a PEM encoded CA bundle which will be used to validate
+	// the hub's TLS certificate, in addition to the system trust store.
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// TokenSecretRef references a Secret key containing the bearer token
+	// this spoke cluster authenticates to the hub with.
+	TokenSecretRef SecretKeySelector `json:"tokenSecretRef"`
+}
+
+// FakeIssuer contains the specification for an issuer that instantly
+// issues self-signed certificates with no external dependencies, for use
+// in CI clusters and other environments where a real CA or ACME staging
+// server is unavailable or undesirable. It has no configuration of its
+// own; its presence (even as an empty object) selects the fake issuer.
+type FakeIssuer struct {
 }
 
 // ACMEIssuer contains the specification for an ACME issuer
@@ -63,10 +306,79 @@ type ACMEIssuer struct {
 	// PrivateKey is the name of a secret containing the private key for this
 	// user account.
 	PrivateKey string `json:"privateKey"`
+	// HTTPProxy is the URL of an HTTP or HTTPS proxy to use for all outbound
+	// requests to this issuer's ACME server. If not set, the HTTPS_PROXY,
+	// HTTP_PROXY and NO_PROXY environment variables are honoured instead.
+	HTTPProxy string `json:"httpProxy,omitempty"`
+	// CABundle is a PEM encoded CA bundle which will be used to validate the
+	// ACME server's TLS certificate, in addition to the system trust store.
+	// Mutually exclusive with CABundleSecretRef.
+	CABundle []byte `json:"caBundle,omitempty"`
+	// CABundleSecretRef is a reference to a Secret containing a PEM encoded
+	// CA bundle to use as per CABundle. Mutually exclusive with CABundle.
+	CABundleSecretRef *SecretKeySelector `json:"caBundleSecretRef,omitempty"`
+	// SkipTLSVerify will disable validation of the ACME server's TLS
+	// certificate. This is insecure and should only be used in air-gapped lab
+	// environments that cannot provision a certificate for their ACME server
+	// that is trusted by the controller. A warning event and status condition
+	// is emitted on every reconcile while this is enabled.
+	SkipTLSVerify bool `json:"skipTLSVerify,omitempty"`
+	// HTTP01SelfCheckAddressFamily restricts the IP address family used when
+	// performing the HTTP01 self check prior to notifying the ACME server that
+	// a challenge is ready to be validated. One of "" (try both address
+	// families, preferring IPv4), "ipv4" or "ipv6". This is needed on
+	// single-stack IPv6 clusters, where a self check that only ever dials
+	// IPv4 addresses can never succeed.
+	HTTP01SelfCheckAddressFamily string `json:"http01SelfCheckAddressFamily,omitempty"`
+	// HTTP01 configures how the Pod created to solve HTTP01 challenges is
+	// run, for clusters that enforce restricted Pod Security Standards or
+	// otherwise need more control over that Pod than cert-manager's
+	// defaults provide.
+	HTTP01 *ACMEIssuerHTTP01Config `json:"http01,omitempty"`
+	// ExcludeRootCAFromChain, if true, causes any self-signed root CA
+	// certificate present in the chain the ACME server returns to be
+	// omitted from the issued tls.crt, rather than served to clients. Most
+	// ACME servers don't send the root at all, but some do; serving it is
+	// unnecessary extra handshake bytes, since clients are expected to
+	// already trust it out of band, and some strict clients reject it
+	// outright. The chain is also always reordered leaf-first if the
+	// server sent it out of order, regardless of this setting.
+	ExcludeRootCAFromChain bool `json:"excludeRootCAFromChain,omitempty"`
 	// DNS-01 config
 	DNS01 *ACMEIssuerDNS01Config `json:"dns-01"`
 }
 
+// ACMEIssuerHTTP01Config configures the HTTP01 challenge solver resources
+// created by this issuer.
+type ACMEIssuerHTTP01Config struct {
+	// SolverPod configures the Pod created to run the acmesolver container
+	// that answers HTTP01 challenge requests.
+	SolverPod *ACMEIssuerHTTP01SolverPodConfig `json:"solverPod,omitempty"`
+}
+
+// ACMEIssuerHTTP01SolverPodConfig configures the Pod created to run the
+// acmesolver container that answers HTTP01 challenge requests.
+type ACMEIssuerHTTP01SolverPodConfig struct {
+	// SecurityContext is applied to the solver Pod, allowing e.g.
+	// runAsNonRoot to be set so the Pod satisfies a cluster's restricted
+	// Pod Security Standard. If not set, no Pod level SecurityContext is
+	// applied.
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+
+	// ContainerSecurityContext is applied to the acmesolver container,
+	// allowing e.g. readOnlyRootFilesystem to be set. If not set, no
+	// container level SecurityContext is applied.
+	ContainerSecurityContext *corev1.SecurityContext `json:"containerSecurityContext,omitempty"`
+
+	// PodDisruptionBudget, if true, causes a PodDisruptionBudget with
+	// maxUnavailable: 0 to be created alongside the solver Pod, so the
+	// cluster's eviction API refuses to voluntarily evict it mid-challenge
+	// rather than letting the challenge silently fail. Disabled by
+	// default, as the solver Pod is short-lived and self-heals via its Job
+	// on failure.
+	PodDisruptionBudget bool `json:"podDisruptionBudget,omitempty"`
+}
+
 // ACMEIssuerDNS01Config is a structure containing the ACME DNS configuration
 // option. One and only one of the fields within it should be set, when the
 // ACME challenge type is set to dns-01
@@ -77,9 +389,21 @@ type ACMEIssuerDNS01Config struct {
 type ACMEIssuerDNS01Provider struct {
 	Name string `json:"name"`
 
-	CloudDNS   *ACMEIssuerDNS01ProviderCloudDNS   `json:"clouddns,omitempty"`
-	Cloudflare *ACMEIssuerDNS01ProviderCloudflare `json:"cloudflare,omitempty"`
-	Route53    *ACMEIssuerDNS01ProviderRoute53    `json:"route53,omitempty"`
+	CloudDNS     *ACMEIssuerDNS01ProviderCloudDNS     `json:"clouddns,omitempty"`
+	Cloudflare   *ACMEIssuerDNS01ProviderCloudflare   `json:"cloudflare,omitempty"`
+	Route53      *ACMEIssuerDNS01ProviderRoute53      `json:"route53,omitempty"`
+	DigitalOcean *ACMEIssuerDNS01ProviderDigitalOcean `json:"digitalocean,omitempty"`
+	Akamai       *ACMEIssuerDNS01ProviderAkamai       `json:"akamai,omitempty"`
+
+	// PropagationTimeout is the length of time to wait for a DNS-01 TXT
+	// record to propagate before giving up, as a duration string (e.g.
+	// "120s"). If not set, the provider's default timeout is used.
+	PropagationTimeout string `json:"propagationTimeout,omitempty"`
+
+	// PollingInterval is how often to re-check for DNS-01 TXT record
+	// propagation while waiting, as a duration string (e.g. "2s"). If not
+	// set, the provider's default interval is used.
+	PollingInterval string `json:"pollingInterval,omitempty"`
 }
 
 // ACMEIssuerDNS01ProviderCloudDNS is a structure containing the DNS
@@ -103,12 +427,68 @@ type ACMEIssuerDNS01ProviderRoute53 struct {
 	SecretAccessKey SecretKeySelector `json:"secretAccessKey"`
 	HostedZoneID    string            `json:"hostedZoneID"`
 	Region          string            `json:"region"`
+
+	// Role, if set, is the ARN of an IAM role to assume via STS AssumeRole
+	// using the credentials above, before managing Route 53 records. This
+	// allows the hosted zone to live in an AWS account other than the one
+	// the provided credentials belong to.
+	Role string `json:"role,omitempty"`
+
+	// ExternalID, if set, is passed as the external ID when assuming Role,
+	// as required by some cross-account role trust policies to protect
+	// against the confused deputy problem.
+	ExternalID string `json:"externalID,omitempty"`
+}
+
+// ACMEIssuerDNS01ProviderDigitalOcean is a structure containing the DNS
+// configuration for DigitalOcean
+type ACMEIssuerDNS01ProviderDigitalOcean struct {
+	Token SecretKeySelector `json:"token"`
+}
+
+// ACMEIssuerDNS01ProviderAkamai is a structure containing the DNS
+// configuration for Akamai EdgeDNS
+type ACMEIssuerDNS01ProviderAkamai struct {
+	ClientToken  SecretKeySelector `json:"clientToken"`
+	ClientSecret SecretKeySelector `json:"clientSecret"`
+	AccessToken  SecretKeySelector `json:"accessToken"`
+	Host         string            `json:"host"`
 }
 
 // IssuerStatus contains status information about an Issuer
 type IssuerStatus struct {
 	Conditions []IssuerCondition `json:"conditions"`
 	ACME       *ACMEIssuerStatus `json:"acme,omitempty"`
+
+	// SyntheticProbe records the result of the most recent synthetic probe
+	// issuance, for issuers with IssuerSpec.SyntheticProbe configured.
+	SyntheticProbe *SyntheticProbeStatus `json:"syntheticProbe,omitempty"`
+
+	// SignerExpiry is the NotAfter of the signing certificate in
+	// CAIssuer.SecretRef, set whenever the IssuerConditionSignerExpiringSoon
+	// condition is true, for CA issuers. It is cleared once the signing
+	// certificate is rotated.
+	SignerExpiry *metav1.Time `json:"signerExpiry,omitempty"`
+}
+
+// SyntheticProbeStatus records the result of the most recent synthetic
+// probe issuance performed for an Issuer.
+type SyntheticProbeStatus struct {
+	// LastProbeTime is when the most recent synthetic probe issuance was
+	// attempted.
+	LastProbeTime metav1.Time `json:"lastProbeTime"`
+
+	// Succeeded is whether the most recent synthetic probe issuance
+	// completed without error.
+	Succeeded bool `json:"succeeded"`
+
+	// DurationSeconds is how long the most recent synthetic probe issuance
+	// took to complete, in seconds.
+	DurationSeconds float64 `json:"durationSeconds"`
+
+	// Error is the error message from the most recent synthetic probe
+	// issuance, set only if Succeeded is false.
+	Error string `json:"error,omitempty"`
 }
 
 // IssuerCondition contains condition information for an Issuer.
@@ -139,6 +519,13 @@ const (
 	// IssuerConditionReady represents the fact that a given Issuer condition
 	// is in ready state.
 	IssuerConditionReady IssuerConditionType = "Ready"
+
+	// IssuerConditionSignerExpiringSoon indicates that a CA issuer's signing
+	// certificate (CAIssuer.SecretRef) is within CAIssuer.SignerExpiryThreshold
+	// of its own NotAfter. The issuer remains Ready and continues to issue
+	// certificates - this is an early warning so the signing CA can be
+	// rotated before it actually expires.
+	IssuerConditionSignerExpiringSoon IssuerConditionType = "SignerExpiringSoon"
 )
 
 // ConditionStatus represents a condition's status.
@@ -194,21 +581,179 @@ type CertificateList struct {
 type CertificateSpec struct {
 	// Domains is a list of domains to obtain a certificate for
 	Domains []string `json:"domains"`
+	// IPAddresses is a list of IP addresses to request as subjectAltNames on
+	// the certificate, in addition to Domains. Only issuers that support IP
+	// identifiers (e.g. ACME issuers implementing RFC 8738) are able to
+	// fulfil this; other issuers ignore it.
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+	// EmailAddresses is a list of email addresses to request as
+	// subjectAltNames on the certificate, in addition to Domains. Setting
+	// this requests an S/MIME-style certificate: the issued certificate's
+	// ExtKeyUsage will include emailProtection alongside the issuer's usual
+	// usages. Only issuers that construct the certificate's extensions
+	// themselves (such as the CA issuer) are able to fulfil this; other
+	// issuers ignore it.
+	EmailAddresses []string `json:"emailAddresses,omitempty"`
+
+	// CodeSigning requests a code-signing certificate: the issued
+	// certificate's ExtKeyUsage will be codeSigning instead of the issuer's
+	// usual serverAuth/clientAuth-style usages, for build systems that need
+	// short-lived signing certs from the CA issuer. Only issuers that
+	// construct the certificate's extensions themselves (such as the CA
+	// issuer) are able to fulfil this; other issuers ignore it.
+	CodeSigning bool `json:"codeSigning,omitempty"`
+
+	// CommonNameOnly requests a certificate with no subjectAltName
+	// extension at all, identified only by its CommonName (set to the
+	// first entry of Domains). This is for legacy devices that cannot
+	// parse a SAN extension; it produces a certificate that modern TLS
+	// clients (which ignore CommonName entirely since RFC 6125) will
+	// refuse to validate. Only the CA issuer supports this; other issuers
+	// reject a Certificate that sets it, since they require a SAN
+	// extension to validate domain control.
+	CommonNameOnly bool `json:"commonNameOnly,omitempty"`
+
 	// Secret is the name of the secret resource to store this secret in
 	SecretName string `json:"secretName"`
+
+	// KeySize is the RSA key size, in bits, to use when generating a
+	// private key for this Certificate. If not set, a 2048-bit key is
+	// used. If the Secret named by SecretName already contains a private
+	// key of a different size, it is regenerated at the next issuance or
+	// renewal rather than reused.
+	KeySize int `json:"keySize,omitempty"`
 	// Issuer is the name of the issuer resource to use to obtain this
-	// certificate
-	Issuer string `json:"issuer"`
+	// certificate. If not set, the Issuer named by this Certificate's
+	// namespace's AnnotationDefaultIssuer annotation is used instead; if
+	// that is also unset, issuance fails.
+	Issuer string `json:"issuer,omitempty"`
+
+	// Duration is the requested validity period of the certificate, as a
+	// duration string (e.g. "2160h"). Issuers that support requesting a
+	// specific validity period (such as ACME servers that accept notAfter
+	// on new orders) will use this value instead of their own default. If
+	// not set, or not supported by the issuer, the issuer's default
+	// validity period is used.
+	Duration string `json:"duration,omitempty"`
+
+	// NotBeforeSkew backdates the NotBefore field of issued certificates by
+	// the given duration (e.g. "5m"), so that certificates are already
+	// considered valid by nodes whose clock lags behind the issuing
+	// controller's. If not set, no backdating is applied. Only supported by
+	// issuers (such as the CA issuer) that construct the certificate's
+	// validity window themselves.
+	NotBeforeSkew string `json:"notBeforeSkew,omitempty"`
+
+	// SecretKeys allows customizing the keys under which the issued
+	// certificate data is stored in the target Secret's Data map. This is
+	// useful for legacy applications that expect specific filenames (e.g.
+	// "cert.pem"/"key.pem") rather than the Kubernetes TLS Secret
+	// convention. Any key left unset falls back to its default.
+	SecretKeys *CertificateSecretKeys `json:"secretKeys,omitempty"`
+
+	// SecretType is the Kubernetes Secret "type" field to use for the
+	// target Secret. If not set, defaults to "kubernetes.io/tls". Set this
+	// to "Opaque" for workloads that expect a generic Secret rather than
+	// the kubernetes.io/tls type.
+	SecretType string `json:"secretType,omitempty"`
+
+	// ImmutableSecretRotation, if true, changes how the target Secret is
+	// rotated: instead of overwriting SecretName in place on every
+	// issuance or renewal, a new Secret is created per issuance, named
+	// "<SecretName>-<checksum>" and marked immutable, and
+	// AnnotationCurrentSecretName is updated on this Certificate to point
+	// at it. Previously created Secrets are left in place and are not
+	// garbage collected by cert-manager. This is for workloads that are
+	// sensitive to a Secret's contents changing in place (for example,
+	// because they only read a mounted Secret volume once at startup), and
+	// need to pick up each rotation as a new object instead.
+	ImmutableSecretRotation bool `json:"immutableSecretRotation,omitempty"`
+
+	// KMS, if set, requests that this Certificate's private key be
+	// generated and held by an external KMS/HSM, with only a reference
+	// written to the target Secret rather than the raw key material, for
+	// workloads that consume keys via PKCS#11 rather than reading them
+	// from a file. No KMS provider is currently implemented; setting this
+	// field causes issuance and renewal to fail with a clear error until
+	// one is, rather than silently falling back to a locally generated
+	// key.
+	KMS *CertificateKMSConfig `json:"kms,omitempty"`
+
+	// JKS, if set, requests that a "truststore.jks" entry containing this
+	// Certificate's issuing CA bundle be written to the target Secret
+	// alongside the PEM data, for Java clients that load their trust
+	// material from a Java KeyStore rather than PEM files. Only supported
+	// by issuers (such as the CA issuer) that supply a CA bundle; if no CA
+	// bundle is available, no truststore.jks entry is written.
+	JKS *CertificateJKS `json:"jks,omitempty"`
+
+	// Notify, if set, requests that a JSON notification be POSTed to the
+	// given webhook URL on issuance, renewal and failure of this
+	// Certificate, so that alerting systems (e.g. Slack, PagerDuty) can be
+	// wired up per-Certificate without watching Kubernetes Events. Unlike
+	// an Issuer's PolicyWebhook, a failing or unreachable notify webhook is
+	// logged and otherwise ignored: it never blocks or fails issuance.
+	Notify *CertificateNotifyWebhook `json:"notify,omitempty"`
 
 	ACME *ACMECertificateConfig `json:"acme,omitempty"`
 }
 
+// CertificateJKS configures generation of a JKS truststore entry in the
+// target Secret of a Certificate.
+type CertificateJKS struct {
+	// PasswordSecretRef selects the key of a Secret containing the
+	// password to protect the generated truststore.jks with. If unset,
+	// the well known default JKS password "changeit" is used.
+	PasswordSecretRef *SecretKeySelector `json:"passwordSecretRef,omitempty"`
+}
+
+// CertificateNotifyWebhook configures a per-Certificate notification sink.
+type CertificateNotifyWebhook struct {
+	// URL is the endpoint a JSON notification payload is POSTed to.
+	URL string `json:"url"`
+}
+
+// CertificateKMSConfig selects an external KMS/HSM provider that should
+// generate and hold a Certificate's private key, in place of the
+// controller generating one locally.
+type CertificateKMSConfig struct {
+	// Provider names the KMS provider to use, for example "pkcs11". No
+	// providers are currently implemented by this controller.
+	Provider string `json:"provider"`
+
+	// KeyID is the provider-specific identifier under which the key should
+	// be generated or found, if required by Provider.
+	KeyID string `json:"keyId,omitempty"`
+}
+
+// CertificateSecretKeys allows customizing the Secret data keys used to
+// store an issued certificate. Unset fields fall back to the Kubernetes TLS
+// Secret conventions.
+type CertificateSecretKeys struct {
+	// CertificateKey is the Secret data key the issued certificate is
+	// stored under. Defaults to "tls.crt".
+	CertificateKey string `json:"certificate,omitempty"`
+
+	// PrivateKeyKey is the Secret data key the private key is stored
+	// under. Defaults to "tls.key".
+	PrivateKeyKey string `json:"privateKey,omitempty"`
+
+	// CAKey is the Secret data key the issuing CA certificate (if any) is
+	// stored under. Defaults to "ca.crt".
+	CAKey string `json:"ca,omitempty"`
+}
+
 // ACMEConfig contains the configuration for the ACME certificate provider
 type ACMECertificateConfig struct {
 	Config []ACMECertificateDomainConfig `json:"config"`
 }
 
 type ACMECertificateDomainConfig struct {
+	// Domains lists the domains this solver config applies to. A domain
+	// here matches a requested domain either exactly, or as its parent
+	// zone (e.g. "example.com" also matches "foo.example.com"), so a
+	// delegated subzone handled by a different DNS account can share this
+	// issuer without being listed explicitly.
 	Domains []string                     `json:"domains"`
 	HTTP01  *ACMECertificateHTTP01Config `json:"http-01,omitempty"`
 	DNS01   *ACMECertificateDNS01Config  `json:"dns-01,omitempty"`
@@ -227,6 +772,30 @@ type ACMECertificateDNS01Config struct {
 type CertificateStatus struct {
 	Conditions []CertificateCondition `json:"conditions"`
 	ACME       *CertificateACMEStatus `json:"acme,omitempty"`
+
+	// NotAfter is the expiry date of the current certificate stored in the
+	// target Secret, as recorded the last time it was issued or renewed.
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// LastIssuance records the outcome and duration of the most recent
+	// issuance or renewal attempt for this Certificate, used to derive
+	// issuance latency SLO metrics (see pkg/metrics).
+	LastIssuance *CertificateIssuanceStatus `json:"lastIssuance,omitempty"`
+}
+
+// CertificateIssuanceStatus records the outcome of a single issuance or
+// renewal attempt.
+type CertificateIssuanceStatus struct {
+	// StartTime is when this issuance or renewal attempt began.
+	StartTime metav1.Time `json:"startTime"`
+
+	// DurationSeconds is how long the attempt took to reach a terminal
+	// outcome, measured from StartTime.
+	DurationSeconds float64 `json:"durationSeconds"`
+
+	// Succeeded records whether the attempt resulted in the certificate
+	// being issued or renewed successfully.
+	Succeeded bool `json:"succeeded"`
 }
 
 // CertificateCondition contains condition information for an Certificate.
@@ -257,11 +826,66 @@ const (
 	// CertificateConditionReady represents the fact that a given Certificate condition
 	// is in ready state.
 	CertificateConditionReady CertificateConditionType = "Ready"
+
+	// CertificateConditionConflict indicates that another Certificate in the
+	// same namespace targets the same spec.secretName, and issuance/renewal
+	// for this Certificate has been paused to avoid the two Certificates
+	// overwriting each other's Secret contents.
+	CertificateConditionConflict CertificateConditionType = "Conflict"
+
+	// CertificateConditionPaused indicates that issuance and renewal for this
+	// Certificate has been suspended via the AnnotationIssuePaused annotation.
+	CertificateConditionPaused CertificateConditionType = "Paused"
+
+	// CertificateConditionSignerExpiringSoon mirrors
+	// IssuerConditionSignerExpiringSoon onto every Certificate issued by that
+	// CA issuer, so the warning surfaces on the resource operators actually
+	// watch rather than only on the Issuer.
+	CertificateConditionSignerExpiringSoon CertificateConditionType = "SignerExpiringSoon"
+
+	// CertificateConditionDenied indicates that issuance for this
+	// Certificate was refused because one or more of its spec.domains fall
+	// outside the issuing CA issuer's AllowedDNSZones.
+	CertificateConditionDenied CertificateConditionType = "Denied"
 )
 
 // CertificateACMEStatus holds the status for an ACME issuer
 type CertificateACMEStatus struct {
 	Authorizations []ACMEDomainAuthorization `json:"acme"`
+
+	// PendingChallenges records challenge resources that are currently being
+	// presented to prove domain ownership, so that they can be cleaned up by
+	// a later reconcile even if the controller is restarted before its own
+	// cleanup runs.
+	PendingChallenges []ACMEPendingChallenge `json:"pendingChallenges,omitempty"`
+
+	// LastAuthorizationError records the most recent upstream ACME problem
+	// document encountered while obtaining a domain authorization, so that
+	// failures (for example an "urn:ietf:params:acme:error:dns" response)
+	// are self-explanatory from status alone, without having to correlate
+	// truncated Event strings with controller logs.
+	LastAuthorizationError *ACMEAuthorizationError `json:"lastAuthorizationError,omitempty"`
+}
+
+// ACMEAuthorizationError records the details of an ACME problem document
+// (RFC 7807) returned by the ACME server while authorizing a domain.
+type ACMEAuthorizationError struct {
+	// Domain is the domain the authorization attempt was for.
+	Domain string `json:"domain"`
+
+	// Type is the problem document's "type" field, typically a URN such as
+	// "urn:ietf:params:acme:error:dns". Empty if the ACME server did not
+	// return a structured problem document.
+	Type string `json:"type,omitempty"`
+
+	// Detail is the problem document's human-readable "detail" field, or
+	// the underlying error's message if no structured problem document was
+	// returned.
+	Detail string `json:"detail"`
+
+	// StatusCode is the HTTP status code the ACME server responded with.
+	// Zero if the error did not originate from an ACME server response.
+	StatusCode int `json:"statusCode,omitempty"`
 }
 
 // ACMEDomainAuthorization holds information about an ACME issuers domain
@@ -269,6 +893,30 @@ type CertificateACMEStatus struct {
 type ACMEDomainAuthorization struct {
 	Domain string `json:"domain"`
 	URI    string `json:"uri"`
+	// StartTime records when this authorization attempt was first saved,
+	// so a reconcile that finds it still not valid after
+	// authorizationTimeout can discard it and request a fresh
+	// authorization (with a fresh challenge and key), instead of retrying
+	// one that has stalled and will never complete.
+	StartTime metav1.Time `json:"startTime,omitempty"`
+}
+
+// ACMEPendingChallenge records enough information about an in-progress
+// ACME challenge to clean up the resources it created (e.g. a DNS-01 TXT
+// record or HTTP-01 Service/Ingress/Job), even if the controller process
+// that presented the challenge is restarted before it can run its own
+// cleanup.
+type ACMEPendingChallenge struct {
+	// Domain is the domain the challenge is proving ownership of.
+	Domain string `json:"domain"`
+	// Type is the ACME challenge type, e.g. "http-01" or "dns-01".
+	Type string `json:"type"`
+	// Token is the challenge token, as assigned by the ACME server.
+	Token string `json:"token"`
+	// Key is the expected key authorization for this challenge.
+	Key string `json:"key"`
+	// StartTime is when this challenge was first presented.
+	StartTime metav1.Time `json:"startTime"`
 }
 
 type LocalObjectReference struct {
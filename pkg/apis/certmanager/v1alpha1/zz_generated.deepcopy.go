@@ -1,3 +1,4 @@
+//go:build !ignore_autogenerated
 // +build !ignore_autogenerated
 
 /*
@@ -21,6 +22,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	reflect "reflect"
@@ -80,6 +83,14 @@ func RegisterDeepCopies(scheme *runtime.Scheme) error {
 			in.(*ACMEIssuerDNS01ProviderRoute53).DeepCopyInto(out.(*ACMEIssuerDNS01ProviderRoute53))
 			return nil
 		}, InType: reflect.TypeOf(&ACMEIssuerDNS01ProviderRoute53{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*ACMEIssuerDNS01ProviderDigitalOcean).DeepCopyInto(out.(*ACMEIssuerDNS01ProviderDigitalOcean))
+			return nil
+		}, InType: reflect.TypeOf(&ACMEIssuerDNS01ProviderDigitalOcean{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*ACMEIssuerDNS01ProviderAkamai).DeepCopyInto(out.(*ACMEIssuerDNS01ProviderAkamai))
+			return nil
+		}, InType: reflect.TypeOf(&ACMEIssuerDNS01ProviderAkamai{})},
 		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
 			in.(*ACMEIssuerStatus).DeepCopyInto(out.(*ACMEIssuerStatus))
 			return nil
@@ -246,6 +257,22 @@ func (in *ACMECertificateHTTP01Config) DeepCopy() *ACMECertificateHTTP01Config {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEAuthorizationError) DeepCopyInto(out *ACMEAuthorizationError) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEAuthorizationError.
+func (in *ACMEAuthorizationError) DeepCopy() *ACMEAuthorizationError {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEAuthorizationError)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEDomainAuthorization) DeepCopyInto(out *ACMEDomainAuthorization) {
 	*out = *in
@@ -262,9 +289,49 @@ func (in *ACMEDomainAuthorization) DeepCopy() *ACMEDomainAuthorization {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEPendingChallenge) DeepCopyInto(out *ACMEPendingChallenge) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEPendingChallenge.
+func (in *ACMEPendingChallenge) DeepCopy() *ACMEPendingChallenge {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEPendingChallenge)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEIssuer) DeepCopyInto(out *ACMEIssuer) {
 	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(SecretKeySelector)
+			**out = **in
+		}
+	}
+	if in.HTTP01 != nil {
+		in, out := &in.HTTP01, &out.HTTP01
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ACMEIssuerHTTP01Config)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	if in.DNS01 != nil {
 		in, out := &in.DNS01, &out.DNS01
 		if *in == nil {
@@ -340,6 +407,24 @@ func (in *ACMEIssuerDNS01Provider) DeepCopyInto(out *ACMEIssuerDNS01Provider) {
 			**out = **in
 		}
 	}
+	if in.DigitalOcean != nil {
+		in, out := &in.DigitalOcean, &out.DigitalOcean
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ACMEIssuerDNS01ProviderDigitalOcean)
+			**out = **in
+		}
+	}
+	if in.Akamai != nil {
+		in, out := &in.Akamai, &out.Akamai
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ACMEIssuerDNS01ProviderAkamai)
+			**out = **in
+		}
+	}
 	return
 }
 
@@ -404,6 +489,101 @@ func (in *ACMEIssuerDNS01ProviderRoute53) DeepCopy() *ACMEIssuerDNS01ProviderRou
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEIssuerDNS01ProviderDigitalOcean) DeepCopyInto(out *ACMEIssuerDNS01ProviderDigitalOcean) {
+	*out = *in
+	out.Token = in.Token
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEIssuerDNS01ProviderDigitalOcean.
+func (in *ACMEIssuerDNS01ProviderDigitalOcean) DeepCopy() *ACMEIssuerDNS01ProviderDigitalOcean {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEIssuerDNS01ProviderDigitalOcean)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEIssuerDNS01ProviderAkamai) DeepCopyInto(out *ACMEIssuerDNS01ProviderAkamai) {
+	*out = *in
+	out.ClientToken = in.ClientToken
+	out.ClientSecret = in.ClientSecret
+	out.AccessToken = in.AccessToken
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEIssuerDNS01ProviderAkamai.
+func (in *ACMEIssuerDNS01ProviderAkamai) DeepCopy() *ACMEIssuerDNS01ProviderAkamai {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEIssuerDNS01ProviderAkamai)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEIssuerHTTP01Config) DeepCopyInto(out *ACMEIssuerHTTP01Config) {
+	*out = *in
+	if in.SolverPod != nil {
+		in, out := &in.SolverPod, &out.SolverPod
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ACMEIssuerHTTP01SolverPodConfig)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEIssuerHTTP01Config.
+func (in *ACMEIssuerHTTP01Config) DeepCopy() *ACMEIssuerHTTP01Config {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEIssuerHTTP01Config)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEIssuerHTTP01SolverPodConfig) DeepCopyInto(out *ACMEIssuerHTTP01SolverPodConfig) {
+	*out = *in
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(corev1.PodSecurityContext)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.ContainerSecurityContext != nil {
+		in, out := &in.ContainerSecurityContext, &out.ContainerSecurityContext
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(corev1.SecurityContext)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEIssuerHTTP01SolverPodConfig.
+func (in *ACMEIssuerHTTP01SolverPodConfig) DeepCopy() *ACMEIssuerHTTP01SolverPodConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEIssuerHTTP01SolverPodConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEIssuerStatus) DeepCopyInto(out *ACMEIssuerStatus) {
 	*out = *in
@@ -424,6 +604,18 @@ func (in *ACMEIssuerStatus) DeepCopy() *ACMEIssuerStatus {
 func (in *CAIssuer) DeepCopyInto(out *CAIssuer) {
 	*out = *in
 	out.SecretRef = in.SecretRef
+	if in.DelegatedNamespaces != nil {
+		in, out := &in.DelegatedNamespaces, &out.DelegatedNamespaces
+		*out = make([]CADelegatedNamespace, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AllowedDNSZones != nil {
+		in, out := &in.AllowedDNSZones, &out.AllowedDNSZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -437,6 +629,27 @@ func (in *CAIssuer) DeepCopy() *CAIssuer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CADelegatedNamespace) DeepCopyInto(out *CADelegatedNamespace) {
+	*out = *in
+	if in.Domains != nil {
+		in, out := &in.Domains, &out.Domains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CADelegatedNamespace.
+func (in *CADelegatedNamespace) DeepCopy() *CADelegatedNamespace {
+	if in == nil {
+		return nil
+	}
+	out := new(CADelegatedNamespace)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Certificate) DeepCopyInto(out *Certificate) {
 	*out = *in
@@ -474,6 +687,18 @@ func (in *CertificateACMEStatus) DeepCopyInto(out *CertificateACMEStatus) {
 		*out = make([]ACMEDomainAuthorization, len(*in))
 		copy(*out, *in)
 	}
+	if in.PendingChallenges != nil {
+		in, out := &in.PendingChallenges, &out.PendingChallenges
+		*out = make([]ACMEPendingChallenge, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastAuthorizationError != nil {
+		in, out := &in.LastAuthorizationError, &out.LastAuthorizationError
+		*out = new(ACMEAuthorizationError)
+		**out = **in
+	}
 	return
 }
 
@@ -504,6 +729,23 @@ func (in *CertificateCondition) DeepCopy() *CertificateCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateIssuanceStatus) DeepCopyInto(out *CertificateIssuanceStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateIssuanceStatus.
+func (in *CertificateIssuanceStatus) DeepCopy() *CertificateIssuanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateIssuanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateList) DeepCopyInto(out *CertificateList) {
 	*out = *in
@@ -546,6 +788,34 @@ func (in *CertificateSpec) DeepCopyInto(out *CertificateSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.IPAddresses != nil {
+		in, out := &in.IPAddresses, &out.IPAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EmailAddresses != nil {
+		in, out := &in.EmailAddresses, &out.EmailAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecretKeys != nil {
+		in, out := &in.SecretKeys, &out.SecretKeys
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(CertificateSecretKeys)
+			**out = **in
+		}
+	}
+	if in.KMS != nil {
+		in, out := &in.KMS, &out.KMS
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(CertificateKMSConfig)
+			**out = **in
+		}
+	}
 	if in.ACME != nil {
 		in, out := &in.ACME, &out.ACME
 		if *in == nil {
@@ -555,9 +825,100 @@ func (in *CertificateSpec) DeepCopyInto(out *CertificateSpec) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.JKS != nil {
+		in, out := &in.JKS, &out.JKS
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(CertificateJKS)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Notify != nil {
+		in, out := &in.Notify, &out.Notify
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(CertificateNotifyWebhook)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateJKS) DeepCopyInto(out *CertificateJKS) {
+	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(SecretKeySelector)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateJKS.
+func (in *CertificateJKS) DeepCopy() *CertificateJKS {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateJKS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateKMSConfig) DeepCopyInto(out *CertificateKMSConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateKMSConfig.
+func (in *CertificateKMSConfig) DeepCopy() *CertificateKMSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateKMSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateNotifyWebhook) DeepCopyInto(out *CertificateNotifyWebhook) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateNotifyWebhook.
+func (in *CertificateNotifyWebhook) DeepCopy() *CertificateNotifyWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateNotifyWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateSecretKeys) DeepCopyInto(out *CertificateSecretKeys) {
+	*out = *in
 	return
 }
 
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateSecretKeys.
+func (in *CertificateSecretKeys) DeepCopy() *CertificateSecretKeys {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateSecretKeys)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateSpec.
 func (in *CertificateSpec) DeepCopy() *CertificateSpec {
 	if in == nil {
@@ -580,6 +941,23 @@ func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = (*in).DeepCopy()
+		}
+	}
+	if in.LastIssuance != nil {
+		in, out := &in.LastIssuance, &out.LastIssuance
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(CertificateIssuanceStatus)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	return
 }
 
@@ -691,6 +1069,60 @@ func (in *IssuerSpec) DeepCopyInto(out *IssuerSpec) {
 			*out = nil
 		} else {
 			*out = new(CAIssuer)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.StepCA != nil {
+		in, out := &in.StepCA, &out.StepCA
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(StepCAIssuer)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.EST != nil {
+		in, out := &in.EST, &out.EST
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ESTIssuer)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Hub != nil {
+		in, out := &in.Hub, &out.Hub
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(HubIssuer)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Fake != nil {
+		in, out := &in.Fake, &out.Fake
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(FakeIssuer)
+			**out = **in
+		}
+	}
+	if in.PolicyWebhook != nil {
+		in, out := &in.PolicyWebhook, &out.PolicyWebhook
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PolicyWebhook)
+			**out = **in
+		}
+	}
+	if in.SyntheticProbe != nil {
+		in, out := &in.SyntheticProbe, &out.SyntheticProbe
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(SyntheticProbeConfig)
 			**out = **in
 		}
 	}
@@ -726,6 +1158,24 @@ func (in *IssuerStatus) DeepCopyInto(out *IssuerStatus) {
 			**out = **in
 		}
 	}
+	if in.SyntheticProbe != nil {
+		in, out := &in.SyntheticProbe, &out.SyntheticProbe
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(SyntheticProbeStatus)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.SignerExpiry != nil {
+		in, out := &in.SignerExpiry, &out.SignerExpiry
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(metav1.Time)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	return
 }
 
@@ -755,6 +1205,22 @@ func (in *LocalObjectReference) DeepCopy() *LocalObjectReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyWebhook) DeepCopyInto(out *PolicyWebhook) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyWebhook.
+func (in *PolicyWebhook) DeepCopy() *PolicyWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretKeySelector) DeepCopyInto(out *SecretKeySelector) {
 	*out = *in
@@ -771,3 +1237,118 @@ func (in *SecretKeySelector) DeepCopy() *SecretKeySelector {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESTIssuer) DeepCopyInto(out *ESTIssuer) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	out.PasswordSecretRef = in.PasswordSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESTIssuer.
+func (in *ESTIssuer) DeepCopy() *ESTIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(ESTIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FakeIssuer) DeepCopyInto(out *FakeIssuer) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FakeIssuer.
+func (in *FakeIssuer) DeepCopy() *FakeIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(FakeIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HubIssuer) DeepCopyInto(out *HubIssuer) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	out.TokenSecretRef = in.TokenSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HubIssuer.
+func (in *HubIssuer) DeepCopy() *HubIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(HubIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepCAIssuer) DeepCopyInto(out *StepCAIssuer) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	out.ProvisionerPasswordSecretRef = in.ProvisionerPasswordSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepCAIssuer.
+func (in *StepCAIssuer) DeepCopy() *StepCAIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(StepCAIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyntheticProbeConfig) DeepCopyInto(out *SyntheticProbeConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyntheticProbeConfig.
+func (in *SyntheticProbeConfig) DeepCopy() *SyntheticProbeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SyntheticProbeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyntheticProbeStatus) DeepCopyInto(out *SyntheticProbeStatus) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyntheticProbeStatus.
+func (in *SyntheticProbeStatus) DeepCopy() *SyntheticProbeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SyntheticProbeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,98 @@
+// Package validation implements the validation rules applied by the
+// certificates and issuers controllers to Certificate and Issuer resources
+// before acting on them. It is exported as a stable library so that
+// external tooling (GitOps pre-commit checks, Helm chart linters) can
+// validate manifests offline against the same rules, without needing a
+// running cluster.
+package validation
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/pki"
+)
+
+// ValidateCertificate validates crt.Spec and returns a list of any problems
+// found. An empty list means crt is valid.
+func ValidateCertificate(crt *v1alpha1.Certificate) field.ErrorList {
+	return ValidateCertificateSpec(&crt.Spec, field.NewPath("spec"))
+}
+
+// ValidateCertificateSpec validates spec and returns a list of any problems
+// found, with each error's field path rooted at fldPath.
+func ValidateCertificateSpec(spec *v1alpha1.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	if spec.SecretName == "" {
+		el = append(el, field.Required(fldPath.Child("secretName"), "must be set"))
+	}
+
+	if len(spec.Domains) == 0 && len(spec.IPAddresses) == 0 && len(spec.EmailAddresses) == 0 {
+		el = append(el, field.Required(fldPath.Child("domains"), "at least one of domains, ipAddresses or emailAddresses must be set"))
+	}
+
+	domainsPath := fldPath.Child("domains")
+	for i, domain := range spec.Domains {
+		normalized, err := pki.NormalizeDomain(domain)
+		if err != nil {
+			el = append(el, field.Invalid(domainsPath.Index(i), domain, err.Error()))
+			continue
+		}
+		if err := pki.ValidateDomain(normalized); err != nil {
+			el = append(el, field.Invalid(domainsPath.Index(i), domain, err.Error()))
+		}
+	}
+
+	if spec.CommonNameOnly && len(spec.Domains) == 0 {
+		el = append(el, field.Required(domainsPath, "must set at least one domain to use as the CommonName when commonNameOnly is set"))
+	}
+
+	if spec.SecretType != "" && spec.SecretType != "kubernetes.io/tls" && spec.SecretType != "Opaque" {
+		el = append(el, field.NotSupported(fldPath.Child("secretType"), spec.SecretType, []string{"kubernetes.io/tls", "Opaque"}))
+	}
+
+	return el
+}
+
+// ValidateIssuer validates iss.Spec and returns a list of any problems
+// found. An empty list means iss is valid.
+func ValidateIssuer(iss *v1alpha1.Issuer) field.ErrorList {
+	return ValidateIssuerSpec(&iss.Spec, field.NewPath("spec"))
+}
+
+// ValidateIssuerSpec validates spec and returns a list of any problems
+// found, with each error's field path rooted at fldPath.
+func ValidateIssuerSpec(spec *v1alpha1.IssuerSpec, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	configured := 0
+	for _, set := range []bool{spec.ACME != nil, spec.CA != nil, spec.StepCA != nil, spec.EST != nil, spec.Hub != nil, spec.Fake != nil} {
+		if set {
+			configured++
+		}
+	}
+	if configured == 0 {
+		el = append(el, field.Required(fldPath, "must configure exactly one of acme, ca, stepca, est, hub or fake"))
+	} else if configured > 1 {
+		el = append(el, field.Invalid(fldPath, spec, "must configure exactly one of acme, ca, stepca, est, hub or fake"))
+	}
+
+	if spec.ACME != nil {
+		acmePath := fldPath.Child("acme")
+		if spec.ACME.Server == "" {
+			el = append(el, field.Required(acmePath.Child("server"), "must be set"))
+		}
+		if spec.ACME.PrivateKey == "" {
+			el = append(el, field.Required(acmePath.Child("privateKey"), "must be set"))
+		}
+	}
+
+	if spec.CA != nil {
+		if spec.CA.SecretRef.Name == "" {
+			el = append(el, field.Required(fldPath.Child("ca", "secretRef", "name"), "must be set"))
+		}
+	}
+
+	return el
+}
@@ -0,0 +1,117 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
+
+func TestValidateCertificateSpec(t *testing.T) {
+	tests := map[string]struct {
+		spec    v1alpha1.CertificateSpec
+		wantErr bool
+	}{
+		"valid": {
+			spec: v1alpha1.CertificateSpec{
+				SecretName: "example-tls",
+				Domains:    []string{"example.com"},
+			},
+		},
+		"missing secretName": {
+			spec: v1alpha1.CertificateSpec{
+				Domains: []string{"example.com"},
+			},
+			wantErr: true,
+		},
+		"no identifiers": {
+			spec: v1alpha1.CertificateSpec{
+				SecretName: "example-tls",
+			},
+			wantErr: true,
+		},
+		"domain is a public suffix": {
+			spec: v1alpha1.CertificateSpec{
+				SecretName: "example-tls",
+				Domains:    []string{"co.uk"},
+			},
+			wantErr: true,
+		},
+		"invalid secretType": {
+			spec: v1alpha1.CertificateSpec{
+				SecretName: "example-tls",
+				Domains:    []string{"example.com"},
+				SecretType: "NotARealType",
+			},
+			wantErr: true,
+		},
+		"ipAddresses only is valid": {
+			spec: v1alpha1.CertificateSpec{
+				SecretName:  "example-tls",
+				IPAddresses: []string{"10.0.0.1"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			el := ValidateCertificateSpec(&test.spec, nil)
+			if test.wantErr && len(el) == 0 {
+				t.Errorf("expected an error, got none")
+			}
+			if !test.wantErr && len(el) > 0 {
+				t.Errorf("expected no error, got: %v", el)
+			}
+		})
+	}
+}
+
+func TestValidateIssuerSpec(t *testing.T) {
+	tests := map[string]struct {
+		spec    v1alpha1.IssuerSpec
+		wantErr bool
+	}{
+		"valid acme": {
+			spec: v1alpha1.IssuerSpec{
+				ACME: &v1alpha1.ACMEIssuer{
+					Server:     "https://acme.example.com/directory",
+					PrivateKey: "acme-key",
+				},
+			},
+		},
+		"no issuer type configured": {
+			spec:    v1alpha1.IssuerSpec{},
+			wantErr: true,
+		},
+		"two issuer types configured": {
+			spec: v1alpha1.IssuerSpec{
+				ACME: &v1alpha1.ACMEIssuer{Server: "https://acme.example.com/directory", PrivateKey: "acme-key"},
+				CA:   &v1alpha1.CAIssuer{SecretRef: v1alpha1.LocalObjectReference{Name: "ca-key"}},
+			},
+			wantErr: true,
+		},
+		"acme missing server": {
+			spec: v1alpha1.IssuerSpec{
+				ACME: &v1alpha1.ACMEIssuer{PrivateKey: "acme-key"},
+			},
+			wantErr: true,
+		},
+		"ca missing secretRef name": {
+			spec: v1alpha1.IssuerSpec{
+				CA: &v1alpha1.CAIssuer{},
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			el := ValidateIssuerSpec(&test.spec, nil)
+			if test.wantErr && len(el) == 0 {
+				t.Errorf("expected an error, got none")
+			}
+			if !test.wantErr && len(el) > 0 {
+				t.Errorf("expected no error, got: %v", el)
+			}
+		})
+	}
+}
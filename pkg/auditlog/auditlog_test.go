@@ -0,0 +1,67 @@
+package auditlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRecordChainsHashes(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, "")
+
+	if err := l.Record(EventIssued, "default", "example", "ca-issuer", "01", []string{"example.com"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := l.Record(EventRenewed, "default", "example", "ca-issuer", "02", []string{"example.com"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+
+	var first, second Record
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("error unmarshalling first record: %s", err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatalf("error unmarshalling second record: %s", err)
+	}
+
+	if first.PrevHash != "" {
+		t.Errorf("expected first record to have no PrevHash, got %q", first.PrevHash)
+	}
+	if first.Hash == "" {
+		t.Errorf("expected first record to have a Hash")
+	}
+	if second.PrevHash != first.Hash {
+		t.Errorf("expected second record's PrevHash %q to equal first record's Hash %q", second.PrevHash, first.Hash)
+	}
+}
+
+func TestRecordDetectsTamperingWithHash(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, "")
+
+	if err := l.Record(EventIssued, "default", "example", "ca-issuer", "01", []string{"example.com"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var rec Record
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("error unmarshalling record: %s", err)
+	}
+
+	tampered := rec
+	tampered.Serial = "ff"
+	recomputed, err := hashRecord(tampered)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if recomputed == rec.Hash {
+		t.Errorf("expected tampering with a field to change the computed hash")
+	}
+}
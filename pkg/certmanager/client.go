@@ -0,0 +1,120 @@
+// Package certmanager provides a standalone Go API for issuing certificates
+// signed by a CA keypair, without requiring a running cert-manager
+// controller or access to a Kubernetes API server. It is a thin wrapper
+// around the signing logic in pkg/issuer/ca, intended for other controllers
+// or operators that need to issue certificates programmatically.
+package certmanager
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/issuer/ca"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/pki"
+)
+
+// Request describes a certificate to be issued.
+type Request struct {
+	// Domains is a list of domains to obtain a certificate for.
+	Domains []string
+	// IPAddresses is a list of IP addresses to request as subjectAltNames
+	// on the certificate, in addition to Domains.
+	IPAddresses []string
+	// Duration is the requested validity period of the certificate, as a
+	// duration string (e.g. "2160h"). If not set, a 1 year certificate is
+	// issued.
+	Duration string
+	// NotBeforeSkew backdates the certificate's NotBefore field by the
+	// given duration (e.g. "5m"), for clock-skew tolerance. If not set, no
+	// backdating is applied.
+	NotBeforeSkew string
+	// KeySize is the RSA key size, in bits, to generate for this
+	// certificate. If not set, a 2048-bit key is used.
+	KeySize int
+}
+
+// Result is a successfully issued certificate and the private key generated
+// for it, both PEM encoded.
+type Result struct {
+	PrivateKey  []byte
+	Certificate []byte
+}
+
+// Client issues certificates signed by a fixed CA keypair.
+type Client struct {
+	caCert *x509.Certificate
+	caKey  *rsa.PrivateKey
+
+	// SerialNumberBits is the bit length of the random serial number
+	// assigned to issued certificates. If not set, a 128-bit serial number
+	// is used.
+	SerialNumberBits int
+
+	// Clock is used to determine the current time when computing a
+	// certificate's NotBefore/NotAfter. If not set, the real system clock
+	// is used; tests may override it with a clock.FakeClock.
+	Clock clock.Clock
+}
+
+// NewClient returns a Client that signs certificates using the given PEM
+// encoded CA certificate and private key.
+func NewClient(caCertPEM, caKeyPEM []byte) (*Client, error) {
+	caCert, err := pki.DecodeX509CertificateBytes(caCertPEM)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding CA certificate: %s", err.Error())
+	}
+
+	caKey, err := pki.DecodePKCS1PrivateKeyBytes(caKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding CA private key: %s", err.Error())
+	}
+
+	return &Client{caCert: caCert, caKey: caKey, Clock: clock.RealClock{}}, nil
+}
+
+// Issue generates a private key and a certificate signed by the Client's CA
+// keypair, satisfying req.
+func (c *Client) Issue(ctx context.Context, req *Request) (*Result, error) {
+	if len(req.Domains) == 0 && len(req.IPAddresses) == 0 {
+		return nil, fmt.Errorf("no domains or IP addresses specified")
+	}
+
+	keySize := req.KeySize
+	if keySize == 0 {
+		keySize = pki.DefaultRSAKeySize
+	}
+
+	key, err := pki.GenerateRSAPrivateKey(keySize)
+	if err != nil {
+		return nil, fmt.Errorf("error generating private key: %s", err.Error())
+	}
+
+	crt := &v1alpha1.Certificate{
+		Spec: v1alpha1.CertificateSpec{
+			Domains:       req.Domains,
+			IPAddresses:   req.IPAddresses,
+			Duration:      req.Duration,
+			NotBeforeSkew: req.NotBeforeSkew,
+		},
+	}
+
+	clk := c.Clock
+	if clk == nil {
+		clk = clock.RealClock{}
+	}
+
+	certPem, _, err := ca.SignCertificate(crt, c.caCert, &key.PublicKey, c.caKey, c.SerialNumberBits, clk)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		PrivateKey:  pki.EncodePKCS1PrivateKey(key),
+		Certificate: certPem,
+	}, nil
+}
@@ -10,6 +10,23 @@ import (
 	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
 )
 
+// defaultIssuerName returns the name of the Issuer recorded in namespace's
+// v1alpha1.AnnotationDefaultIssuer annotation, for use by a Certificate in
+// that namespace whose spec.issuer is unset.
+func (c *Controller) defaultIssuerName(namespace string) (string, error) {
+	ns, err := c.namespaceLister.Get(namespace)
+	if err != nil {
+		return "", fmt.Errorf(messageNoDefaultIssuer, namespace)
+	}
+
+	issuerName := ns.Annotations[v1alpha1.AnnotationDefaultIssuer]
+	if issuerName == "" {
+		return "", fmt.Errorf(messageNoDefaultIssuer, namespace)
+	}
+
+	return issuerName, nil
+}
+
 func (c *Controller) certificatesForSecret(secret *corev1.Secret) ([]*v1alpha1.Certificate, error) {
 	crts, err := c.certificateLister.List(labels.NewSelector())
 
@@ -30,6 +47,80 @@ func (c *Controller) certificatesForSecret(secret *corev1.Secret) ([]*v1alpha1.C
 	return affected, nil
 }
 
+// certificatesSharingSecretName returns the other Certificates in the same
+// namespace as crt that also target crt.Spec.SecretName, excluding crt
+// itself.
+func (c *Controller) certificatesSharingSecretName(crt *v1alpha1.Certificate) ([]*v1alpha1.Certificate, error) {
+	crts, err := c.certificateLister.List(labels.NewSelector())
+
+	if err != nil {
+		return nil, fmt.Errorf("error listing certificiates: %s", err.Error())
+	}
+
+	var conflicting []*v1alpha1.Certificate
+	for _, other := range crts {
+		if other.Namespace != crt.Namespace || other.Name == crt.Name {
+			continue
+		}
+		if other.Spec.SecretName == crt.Spec.SecretName {
+			conflicting = append(conflicting, other)
+		}
+	}
+
+	return conflicting, nil
+}
+
+// issuersForCASecret returns the CA issuers in the same namespace as secret
+// that use it as their signing keypair.
+func (c *Controller) issuersForCASecret(secret *corev1.Secret) ([]*v1alpha1.Issuer, error) {
+	issuers, err := c.issuerLister.List(labels.NewSelector())
+
+	if err != nil {
+		return nil, fmt.Errorf("error listing issuers: %s", err.Error())
+	}
+
+	var affected []*v1alpha1.Issuer
+	for _, iss := range issuers {
+		if iss.Namespace != secret.Namespace {
+			continue
+		}
+		if iss.Spec.CA != nil && iss.Spec.CA.SecretRef.Name == secret.Name {
+			affected = append(affected, iss)
+		}
+	}
+
+	return affected, nil
+}
+
+// certificatesForIssuer returns the Certificates in the issuer's namespace
+// that reference it.
+func (c *Controller) certificatesForIssuer(iss *v1alpha1.Issuer) ([]*v1alpha1.Certificate, error) {
+	crts, err := c.certificateLister.List(labels.NewSelector())
+
+	if err != nil {
+		return nil, fmt.Errorf("error listing certificiates: %s", err.Error())
+	}
+
+	var affected []*v1alpha1.Certificate
+	for _, crt := range crts {
+		if crt.Namespace != iss.Namespace {
+			continue
+		}
+		issuerName := crt.Spec.Issuer
+		if issuerName == "" {
+			// Ignore the error here: a Certificate with no spec.issuer and
+			// no usable namespace default simply isn't referencing any
+			// issuer yet, so it can't be affected by this one.
+			issuerName, _ = c.defaultIssuerName(crt.Namespace)
+		}
+		if issuerName == iss.Name {
+			affected = append(affected, crt)
+		}
+	}
+
+	return affected, nil
+}
+
 func (c *Controller) certificatesForIngress(ing *extv1beta1.Ingress) ([]*v1alpha1.Certificate, error) {
 	crts, err := c.certificateLister.List(labels.NewSelector())
 
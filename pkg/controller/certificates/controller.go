@@ -10,6 +10,7 @@ import (
 	extv1beta1 "k8s.io/api/extensions/v1beta1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	coreinformers "k8s.io/client-go/informers/core/v1"
@@ -22,11 +23,13 @@ import (
 	"k8s.io/client-go/util/workqueue"
 
 	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager"
+	"github.com/jetstack-experimental/cert-manager/pkg/auditlog"
 	"github.com/jetstack-experimental/cert-manager/pkg/client/clientset"
 	cminformers "github.com/jetstack-experimental/cert-manager/pkg/client/informers/certmanager/v1alpha1"
 	cmlisters "github.com/jetstack-experimental/cert-manager/pkg/client/listers/certmanager/v1alpha1"
 	controllerpkg "github.com/jetstack-experimental/cert-manager/pkg/controller"
 	"github.com/jetstack-experimental/cert-manager/pkg/issuer"
+	"github.com/jetstack-experimental/cert-manager/pkg/rollout"
 	"github.com/jetstack-experimental/cert-manager/pkg/scheduler"
 )
 
@@ -36,6 +39,36 @@ type Controller struct {
 	issuerFactory issuer.Factory
 	recorder      record.EventRecorder
 
+	// auditLog, if non-nil, is written a tamper-evident record of every
+	// certificate issued or renewed.
+	auditLog *auditlog.Logger
+
+	// rolloutTrigger, if non-nil, is used to trigger a rolling restart of
+	// Deployments/StatefulSets referencing a Certificate's target Secret
+	// whenever that certificate is renewed.
+	rolloutTrigger *rollout.Trigger
+
+	// renewBeforeJitter, if non-zero, is the upper bound of a deterministic
+	// per-Certificate offset added to renewBefore, so that a large number
+	// of Certificates that all became due for renewal at the same instant
+	// (e.g. issued together at cluster bootstrap) don't all attempt
+	// renewal simultaneously.
+	renewBeforeJitter time.Duration
+
+	// renewalClockSkew is an additional fixed margin added to every
+	// Certificate's renewal window, on top of renewBeforeJitter, to
+	// compensate for clock drift between this controller and whatever
+	// issued the certificates it manages.
+	renewalClockSkew time.Duration
+
+	// strictFIPS, if true, causes issuance and renewal to be rejected for
+	// any Certificate whose requested key size is not FIPS 140-2 approved.
+	strictFIPS bool
+
+	// clock is used to determine the current time, and is overridden in
+	// tests so that renewal scheduling can be verified deterministically.
+	clock clock.Clock
+
 	// To allow injection for testing.
 	syncHandler func(key string) error
 
@@ -51,6 +84,9 @@ type Controller struct {
 	ingressInformerSynced cache.InformerSynced
 	ingressLister         extlisters.IngressLister
 
+	namespaceInformerSynced cache.InformerSynced
+	namespaceLister         corelisters.NamespaceLister
+
 	queue              workqueue.RateLimitingInterface
 	scheduledWorkQueue scheduler.ScheduledWorkQueue
 	workerWg           sync.WaitGroup
@@ -63,14 +99,21 @@ func New(
 	issuersInformer cache.SharedIndexInformer,
 	secretsInformer cache.SharedIndexInformer,
 	ingressInformer cache.SharedIndexInformer,
+	namespacesInformer cache.SharedIndexInformer,
 	client kubernetes.Interface,
 	cmClient clientset.Interface,
 	issuerFactory issuer.Factory,
 	recorder record.EventRecorder,
+	auditLog *auditlog.Logger,
+	rolloutTrigger *rollout.Trigger,
+	renewBeforeJitter time.Duration,
+	renewalClockSkew time.Duration,
+	strictFIPS bool,
 ) *Controller {
-	ctrl := &Controller{client: client, cmClient: cmClient, issuerFactory: issuerFactory, recorder: recorder}
+	ctrl := &Controller{client: client, cmClient: cmClient, issuerFactory: issuerFactory, recorder: recorder, auditLog: auditLog, rolloutTrigger: rolloutTrigger, renewBeforeJitter: renewBeforeJitter, renewalClockSkew: renewalClockSkew, strictFIPS: strictFIPS, clock: clock.RealClock{}}
 	ctrl.syncHandler = ctrl.processNextWorkItem
 	ctrl.queue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "certificates")
+	controllerpkg.RegisterQueue("certificates", ctrl.queue)
 	// Create a scheduled work queue that calls the ctrl.queue.Add method for
 	// each object in the queue. This is used to schedule re-checks of
 	// Certificate resources when they get near to expiry
@@ -84,6 +127,8 @@ func New(
 	ctrl.issuerLister = cmlisters.NewIssuerLister(issuersInformer.GetIndexer())
 
 	secretsInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: ctrl.secretDeleted})
+	secretsInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{UpdateFunc: ctrl.caSecretUpdated})
+	secretsInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{UpdateFunc: ctrl.certificateSecretUpdated})
 	ctrl.secretInformerSynced = secretsInformer.HasSynced
 	ctrl.secretLister = corelisters.NewSecretLister(secretsInformer.GetIndexer())
 
@@ -91,6 +136,9 @@ func New(
 	ctrl.ingressInformerSynced = ingressInformer.HasSynced
 	ctrl.ingressLister = extlisters.NewIngressLister(ingressInformer.GetIndexer())
 
+	ctrl.namespaceInformerSynced = namespacesInformer.HasSynced
+	ctrl.namespaceLister = corelisters.NewNamespaceLister(namespacesInformer.GetIndexer())
+
 	return ctrl
 }
 
@@ -145,7 +193,8 @@ func (c *Controller) Run(workers int, stopCh <-chan struct{}) error {
 	if !cache.WaitForCacheSync(stopCh,
 		c.secretInformerSynced,
 		c.certificateInformerSynced,
-		c.ingressInformerSynced) {
+		c.ingressInformerSynced,
+		c.namespaceInformerSynced) {
 		return fmt.Errorf("error waiting for informer caches to sync")
 	}
 
@@ -271,10 +320,24 @@ func init() {
 					cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
 				),
 			),
+			ctx.SharedInformerFactory.InformerFor(
+				ctx.Namespace,
+				metav1.GroupVersionKind{Version: "v1", Kind: "Namespace"},
+				coreinformers.NewNamespaceInformer(
+					ctx.Client,
+					time.Second*30,
+					cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+				),
+			),
 			ctx.Client,
 			ctx.CMClient,
 			ctx.IssuerFactory,
 			ctx.Recorder,
+			ctx.AuditLog,
+			ctx.RolloutTrigger,
+			ctx.RenewBeforeJitter,
+			ctx.RenewalClockSkew,
+			ctx.StrictFIPS,
 		).Run
 	})
 }
@@ -0,0 +1,75 @@
+package certificates
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
+
+	"github.com/golang/glog"
+)
+
+// caSecretRotationStagger is the delay applied between successive batches
+// of dependent Certificates when rolling out a CA rotation, to smooth out
+// the load on the issuer rather than re-issuing every dependent Certificate
+// at once.
+const caSecretRotationStagger = 5 * time.Second
+
+// caSecretUpdated is called whenever a Secret resource is updated. If the
+// Secret is used as the signing keypair for one or more CA issuers, all
+// Certificates that reference those issuers are marked for re-issuance and
+// rolled out in batches no larger than the issuer's RotationConcurrency.
+func (c *Controller) caSecretUpdated(old, new interface{}) {
+	oldSecret, ok := old.(*corev1.Secret)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("Object is not a Secret object %#v", old))
+		return
+	}
+	newSecret, ok := new.(*corev1.Secret)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("Object is not a Secret object %#v", new))
+		return
+	}
+	if oldSecret.ResourceVersion == newSecret.ResourceVersion {
+		return
+	}
+
+	issuers, err := c.issuersForCASecret(newSecret)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("Error looking up Issuers for rotated CA Secret %s/%s: %s", newSecret.Namespace, newSecret.Name, err.Error()))
+		return
+	}
+
+	for _, iss := range issuers {
+		crts, err := c.certificatesForIssuer(iss)
+		if err != nil {
+			runtime.HandleError(fmt.Errorf("Error looking up Certificates for Issuer %s/%s: %s", iss.Namespace, iss.Name, err.Error()))
+			continue
+		}
+		if len(crts) == 0 {
+			continue
+		}
+
+		concurrency := iss.Spec.CA.RotationConcurrency
+		if concurrency <= 0 {
+			concurrency = len(crts)
+		}
+
+		glog.Infof("Detected rotation of signing keypair for CA issuer %s/%s, rolling out re-issuance to %d Certificates with concurrency %d", iss.Namespace, iss.Name, len(crts), concurrency)
+
+		for i, crt := range crts {
+			key, err := keyFunc(crt)
+			if err != nil {
+				runtime.HandleError(err)
+				continue
+			}
+			batch := i / concurrency
+			if batch == 0 {
+				c.queue.Add(key)
+			} else {
+				c.scheduledWorkQueue.Add(key, time.Duration(batch)*caSecretRotationStagger)
+			}
+		}
+	}
+}
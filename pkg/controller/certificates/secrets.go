@@ -0,0 +1,48 @@
+package certificates
+
+import (
+	"bytes"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
+)
+
+// certificateSecretUpdated is called whenever a Secret resource is updated.
+// If its certificate data has changed - for example because an operator
+// manually replaced it, or rolled it back to an older revision - any
+// Certificates targeting it are re-queued, so their renewal timer is
+// recomputed from the Secret's new notAfter rather than continuing to use
+// whatever was previously scheduled against the certificate that used to be
+// there.
+func (c *Controller) certificateSecretUpdated(old, new interface{}) {
+	oldSecret, ok := old.(*corev1.Secret)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("Object is not a Secret object %#v", old))
+		return
+	}
+	newSecret, ok := new.(*corev1.Secret)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("Object is not a Secret object %#v", new))
+		return
+	}
+
+	if bytes.Equal(oldSecret.Data[corev1.TLSCertKey], newSecret.Data[corev1.TLSCertKey]) {
+		return
+	}
+
+	crts, err := c.certificatesForSecret(newSecret)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("Error looking up Certificates observing Secret: %s/%s", newSecret.Namespace, newSecret.Name))
+		return
+	}
+
+	for _, crt := range crts {
+		key, err := keyFunc(crt)
+		if err != nil {
+			runtime.HandleError(err)
+			continue
+		}
+		c.queue.Add(key)
+	}
+}
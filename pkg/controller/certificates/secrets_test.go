@@ -0,0 +1,63 @@
+package certificates
+
+import (
+	"testing"
+
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
+
+// TestCertificateSecretUpdated verifies that certificateSecretUpdated
+// re-queues Certificates targeting a Secret whose certificate data has
+// changed, and leaves the queue untouched when it has not (e.g. an update
+// that only touched annotations).
+func TestCertificateSecretUpdated(t *testing.T) {
+	crt := &v1alpha1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+		Spec:       v1alpha1.CertificateSpec{SecretName: "test-tls"},
+	}
+	unrelated := &v1alpha1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "unrelated"},
+		Spec:       v1alpha1.CertificateSpec{SecretName: "unrelated-tls"},
+	}
+
+	c := &Controller{
+		certificateLister: newTestCertificateLister(t, crt, unrelated),
+		queue:             workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+	}
+
+	oldSecret := &api.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test-tls"},
+		Data:       map[string][]byte{api.TLSCertKey: []byte("old")},
+	}
+
+	t.Run("requeues when the certificate data has changed", func(t *testing.T) {
+		newSecret := oldSecret.DeepCopy()
+		newSecret.Data[api.TLSCertKey] = []byte("new")
+
+		c.certificateSecretUpdated(oldSecret, newSecret)
+
+		if c.queue.Len() != 1 {
+			t.Fatalf("expected exactly one item to be queued, got %d", c.queue.Len())
+		}
+		key, _ := c.queue.Get()
+		c.queue.Done(key)
+		if key != "testns/test" {
+			t.Errorf("expected %q to be queued, got %q", "testns/test", key)
+		}
+	})
+
+	t.Run("does nothing when the certificate data is unchanged", func(t *testing.T) {
+		newSecret := oldSecret.DeepCopy()
+		newSecret.Annotations = map[string]string{"foo": "bar"}
+
+		c.certificateSecretUpdated(oldSecret, newSecret)
+
+		if c.queue.Len() != 0 {
+			t.Errorf("expected nothing to be queued, got %d items", c.queue.Len())
+		}
+	})
+}
@@ -1,9 +1,14 @@
 package certificates
 
 import (
+	"context"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	api "k8s.io/api/core/v1"
@@ -14,14 +19,25 @@ import (
 
 	"github.com/golang/glog"
 	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/auditlog"
 	"github.com/jetstack-experimental/cert-manager/pkg/issuer"
+	"github.com/jetstack-experimental/cert-manager/pkg/issuer/notify"
+	"github.com/jetstack-experimental/cert-manager/pkg/issuer/policy"
 	"github.com/jetstack-experimental/cert-manager/pkg/util"
 	"github.com/jetstack-experimental/cert-manager/pkg/util/errors"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/jks"
 	"github.com/jetstack-experimental/cert-manager/pkg/util/kube"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/pki"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/trace"
 )
 
 const renewBefore = time.Hour * 24 * 30
 
+// syncTimeout bounds how long a single Sync call, and the issuer calls it
+// makes, are allowed to run for, so that a hung ACME server or Kubernetes
+// API call can't wedge a worker indefinitely.
+const syncTimeout = time.Minute * 10
+
 const (
 	errorIssuerNotFound       = "ErrorIssuerNotFound"
 	errorIssuerNotReady       = "ErrorIssuerNotReady"
@@ -29,13 +45,26 @@ const (
 	errorCheckCertificate     = "ErrorCheckCertificate"
 	errorGetCertificate       = "ErrorGetCertificate"
 	errorPreparingCertificate = "ErrorPrepareCertificate"
+	errorPolicyWebhook        = "ErrorPolicyWebhook"
+	errorMaxDuration          = "ErrorMaxDuration"
+	errorSecretNameConflict   = "ErrorSecretNameConflict"
+	errorMisissuedCertificate = "MisissuedCertificate"
+	errorInvalidDomains       = "ErrorInvalidDomains"
 	errorIssuingCertificate   = "ErrorIssueCertificate"
 	errorRenewingCertificate  = "ErrorRenewCertificate"
 	errorSavingCertificate    = "ErrorSaveCertificate"
+	errorFIPSPolicy           = "ErrorFIPSPolicy"
+	errorNoDefaultIssuer      = "ErrorNoDefaultIssuer"
+	errorKMSNotSupported      = "ErrorKMSNotSupported"
+	errorJKSTrustStore        = "ErrorJKSTrustStore"
 
 	reasonPreparingCertificate = "PrepareCertificate"
 	reasonIssuingCertificate   = "IssueCertificate"
 	reasonRenewingCertificate  = "RenewCertificate"
+	reasonIssuancePaused       = "IssuancePaused"
+	reasonIssuanceResumed      = "IssuanceResumed"
+	reasonSignerExpiringSoon   = "SignerExpiringSoon"
+	reasonSignerExpiryOK       = "SignerExpiryOK"
 
 	successCeritificateIssued  = "CeritifcateIssued"
 	successCeritificateRenewed = "CeritifcateRenewed"
@@ -43,10 +72,21 @@ const (
 
 	messageIssuerNotFound            = "Issuer %s does not exist"
 	messageIssuerNotReady            = "Issuer %s not ready"
+	messageNoDefaultIssuer           = "spec.issuer is not set, and namespace %q has no " + v1alpha1.AnnotationDefaultIssuer + " annotation to default it from"
 	messageIssuerErrorInit           = "Error initializing issuer: "
 	messageErrorCheckCertificate     = "Error checking existing TLS certificate: "
 	messageErrorGetCertificate       = "Error getting TLS certificate: "
 	messageErrorPreparingCertificate = "Error preparing issuer for certificate: "
+	messageErrorPolicyWebhook        = "Certificate denied by policy webhook: "
+	messageErrorMaxDuration          = "Error enforcing issuer maxDuration: "
+	messageErrorFIPSPolicy           = "Certificate spec violates strict FIPS policy: "
+	messageErrorKMSNotSupported      = "Cannot issue certificate: "
+	messageErrorJKSTrustStore        = "Error building JKS truststore: "
+	messageSecretNameConflict        = "spec.secretName %q is also targeted by Certificate(s) %s in this namespace; pausing issuance to avoid overwriting the Secret"
+	messageIssuancePaused            = "Issuance and renewal paused via the " + v1alpha1.AnnotationIssuePaused + " annotation"
+	messageIssuanceResumed           = "Issuance and renewal resumed"
+	messageErrorMisissuedCertificate = "Refusing to persist misissued certificate: "
+	messageErrorInvalidDomains       = "Certificate contains an invalid domain: "
 	messageErrorIssuingCertificate   = "Error issuing certificate: "
 	messageErrorRenewingCertificate  = "Error renewing certificate: "
 	messageErrorSavingCertificate    = "Error saving TLS certificate: "
@@ -61,8 +101,69 @@ const (
 )
 
 func (c *Controller) Sync(crt *v1alpha1.Certificate) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), syncTimeout)
+	defer cancel()
+
+	span := trace.StartSpan(ctx, "reconcile", trace.CertificateAttr(crt.Namespace, crt.Name))
+	defer span.End()
+
+	if crt.Annotations[v1alpha1.AnnotationIssuePaused] == "true" {
+		if !crt.HasCondition(v1alpha1.CertificateCondition{Type: v1alpha1.CertificateConditionPaused, Status: v1alpha1.ConditionTrue}) {
+			s := messageIssuancePaused
+			glog.Info(s)
+			c.recorder.Event(crt, api.EventTypeNormal, reasonIssuancePaused, s)
+			update := crt.DeepCopy()
+			update.UpdateStatusCondition(v1alpha1.CertificateConditionPaused, v1alpha1.ConditionTrue, reasonIssuancePaused, s)
+			return c.updateCertificateStatus(crt, update.Status)
+		}
+		return nil
+	}
+
+	if crt.HasCondition(v1alpha1.CertificateCondition{Type: v1alpha1.CertificateConditionPaused, Status: v1alpha1.ConditionTrue}) {
+		s := messageIssuanceResumed
+		glog.Info(s)
+		c.recorder.Event(crt, api.EventTypeNormal, reasonIssuanceResumed, s)
+		update := crt.DeepCopy()
+		update.UpdateStatusCondition(v1alpha1.CertificateConditionPaused, v1alpha1.ConditionFalse, reasonIssuanceResumed, s)
+		if err := c.updateCertificateStatus(crt, update.Status); err != nil {
+			return err
+		}
+	}
+
+	conflicting, err := c.certificatesSharingSecretName(crt)
+	if err != nil {
+		return err
+	}
+
+	if len(conflicting) > 0 {
+		s := fmt.Sprintf(messageSecretNameConflict, crt.Spec.SecretName, strings.Join(certificateNames(conflicting), ", "))
+		glog.Info(s)
+		c.recorder.Event(crt, api.EventTypeWarning, errorSecretNameConflict, s)
+		update := crt.DeepCopy()
+		update.UpdateStatusCondition(v1alpha1.CertificateConditionConflict, v1alpha1.ConditionTrue, errorSecretNameConflict, s)
+		return c.updateCertificateStatus(crt, update.Status)
+	}
+
+	if crt.HasCondition(v1alpha1.CertificateCondition{Type: v1alpha1.CertificateConditionConflict, Status: v1alpha1.ConditionTrue}) {
+		update := crt.DeepCopy()
+		update.UpdateStatusCondition(v1alpha1.CertificateConditionConflict, v1alpha1.ConditionFalse, "SecretNameAvailable", "spec.secretName is no longer targeted by any other Certificate in this namespace")
+		if err := c.updateCertificateStatus(crt, update.Status); err != nil {
+			return err
+		}
+	}
+
 	// step zero: check if the referenced issuer exists and is ready
-	issuerObj, err := c.issuerLister.Issuers(crt.Namespace).Get(crt.Spec.Issuer)
+	issuerName := crt.Spec.Issuer
+	if issuerName == "" {
+		issuerName, err = c.defaultIssuerName(crt.Namespace)
+		if err != nil {
+			glog.Info(err.Error())
+			c.recorder.Event(crt, api.EventTypeWarning, errorNoDefaultIssuer, err.Error())
+			return err
+		}
+	}
+
+	issuerObj, err := c.issuerLister.Issuers(crt.Namespace).Get(issuerName)
 
 	if err != nil {
 		s := fmt.Sprintf(messageIssuerNotFound, err.Error())
@@ -83,6 +184,10 @@ func (c *Controller) Sync(crt *v1alpha1.Certificate) (err error) {
 		return fmt.Errorf(s)
 	}
 
+	if err := c.syncSignerExpiringSoonCondition(crt, issuerObj); err != nil {
+		return err
+	}
+
 	i, err := c.issuerFactory.IssuerFor(issuerObj)
 
 	if err != nil {
@@ -92,8 +197,12 @@ func (c *Controller) Sync(crt *v1alpha1.Certificate) (err error) {
 		return err
 	}
 
+	if err := c.cleanupStalePendingChallenges(ctx, crt, i); err != nil {
+		glog.Errorf("error cleaning up stale pending challenges for %s/%s: %s", crt.Namespace, crt.Name, err.Error())
+	}
+
 	// grab existing certificate and validate private key
-	cert, err := kube.SecretTLSCert(c.secretLister, crt.Namespace, crt.Spec.SecretName)
+	cert, err := kube.SecretTLSCertRef(c.secretLister, crt.Namespace, crt.Spec.SecretName, crt.CertificateKey())
 
 	if err != nil {
 		s := messageErrorCheckCertificate + err.Error()
@@ -115,29 +224,79 @@ func (c *Controller) Sync(crt *v1alpha1.Certificate) (err error) {
 	// if the certificate was not found, or the certificate data is invalid, we
 	// should issue a new certificate
 	if k8sErrors.IsNotFound(err) || errors.IsInvalidData(err) {
-		return c.issue(i, crt)
+		return c.issue(ctx, issuerObj, i, crt)
+	}
+
+	// if the Secret records which Issuer produced the existing certificate,
+	// and spec.issuer has since been changed to point at a different
+	// Issuer, we should re-issue immediately rather than waiting for the
+	// existing certificate to approach expiry
+	if c.certificateIssuerHasChanged(crt, issuerName) {
+		return c.issue(ctx, issuerObj, i, crt)
 	}
 
 	// if the certificate is valid for a list of domains other than those
 	// listed in the certificate spec, we should re-issue the certificate
 	if !util.EqualUnsorted(crt.Spec.Domains, cert.DNSNames) {
-		return c.issue(i, crt)
+		return c.issue(ctx, issuerObj, i, crt)
 	}
 
+	forceRenew := crt.Annotations[v1alpha1.AnnotationRenewNow] == "true"
+
 	// calculate the amount of time until expiry
-	durationUntilExpiry := cert.NotAfter.Sub(time.Now())
+	durationUntilExpiry := cert.NotAfter.Sub(c.clock.Now())
 	// calculate how long until we should start attempting to renew the
 	// certificate
-	renewIn := durationUntilExpiry - renewBefore
+	renewIn := durationUntilExpiry - renewBefore - jitterFor(crt, c.renewBeforeJitter) - c.renewalClockSkew
 
 	// if we should being attempting to renew now, then trigger a renewal
-	if renewIn <= 0 {
-		return c.renew(i, crt)
+	if renewIn <= 0 || forceRenew {
+		if err := c.renew(ctx, issuerObj, i, crt); err != nil {
+			return err
+		}
+		if forceRenew {
+			return c.clearRenewNowAnnotation(crt)
+		}
+		return nil
 	}
 
 	return nil
 }
 
+// clearRenewNowAnnotation removes the AnnotationRenewNow annotation from crt,
+// once the forced renewal it requested has been triggered, so the same
+// renewal is not repeated on every subsequent sync.
+func (c *Controller) clearRenewNowAnnotation(crt *v1alpha1.Certificate) error {
+	update := crt.DeepCopy()
+	delete(update.Annotations, v1alpha1.AnnotationRenewNow)
+	_, err := c.cmClient.CertmanagerV1alpha1().Certificates(crt.Namespace).Update(update)
+	return err
+}
+
+// certificateNames returns the names of crts, for use in log/event messages.
+func certificateNames(crts []*v1alpha1.Certificate) []string {
+	names := make([]string, len(crts))
+	for i, crt := range crts {
+		names[i] = crt.Name
+	}
+	return names
+}
+
+// jitterFor returns a deterministic pseudo-random duration in [0, maxJitter)
+// for crt, derived from its namespace/name. The same Certificate therefore
+// always computes the same offset (so its renewal time stays stable across
+// resyncs), while different Certificates spread out across the jitter
+// window instead of all becoming due for renewal at the same instant. A
+// non-positive maxJitter disables jitter entirely.
+func jitterFor(crt *v1alpha1.Certificate, maxJitter time.Duration) time.Duration {
+	if maxJitter <= 0 {
+		return 0
+	}
+	sum := sha256.Sum256([]byte(crt.Namespace + "/" + crt.Name))
+	n := binary.BigEndian.Uint64(sum[:8])
+	return time.Duration(n % uint64(maxJitter))
+}
+
 func needsRenew(cert *x509.Certificate) bool {
 	durationUntilExpiry := cert.NotAfter.Sub(time.Now())
 	renewIn := durationUntilExpiry - renewBefore
@@ -156,15 +315,15 @@ func (c *Controller) scheduleRenewal(crt *v1alpha1.Certificate) {
 		return
 	}
 
-	cert, err := kube.SecretTLSCert(c.secretLister, crt.Namespace, crt.Spec.SecretName)
+	cert, err := kube.SecretTLSCertRef(c.secretLister, crt.Namespace, crt.Spec.SecretName, crt.CertificateKey())
 
 	if err != nil {
 		runtime.HandleError(fmt.Errorf("[%s/%s] Error getting certificate '%s': %s", crt.Namespace, crt.Name, crt.Spec.SecretName, err.Error()))
 		return
 	}
 
-	durationUntilExpiry := cert.NotAfter.Sub(time.Now())
-	renewIn := durationUntilExpiry - renewBefore
+	durationUntilExpiry := cert.NotAfter.Sub(c.clock.Now())
+	renewIn := durationUntilExpiry - renewBefore - jitterFor(crt, c.renewBeforeJitter) - c.renewalClockSkew
 
 	c.scheduledWorkQueue.Add(key, renewIn)
 
@@ -173,9 +332,174 @@ func (c *Controller) scheduleRenewal(crt *v1alpha1.Certificate) {
 	c.recorder.Event(crt, api.EventTypeNormal, successRenewalScheduled, s)
 }
 
-func (c *Controller) prepare(issuer issuer.Interface, crt *v1alpha1.Certificate) (err error) {
+// enforceMaxDuration checks crt's requested duration (if any) against
+// issuerObj's MaxDuration, if configured. If the requested duration does
+// not exceed MaxDuration, crt is returned unmodified. If it does, the
+// returned Certificate has its requested duration clamped to MaxDuration,
+// unless issuerObj.Spec.MaxDurationPolicy is "Reject", in which case an
+// error is returned instead.
+func enforceMaxDuration(issuerObj *v1alpha1.Issuer, crt *v1alpha1.Certificate) (*v1alpha1.Certificate, error) {
+	if issuerObj.Spec.MaxDuration == "" || crt.Spec.Duration == "" {
+		return crt, nil
+	}
+
+	maxDuration, err := time.ParseDuration(issuerObj.Spec.MaxDuration)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing issuer maxDuration %q: %s", issuerObj.Spec.MaxDuration, err.Error())
+	}
+
+	requestedDuration, err := time.ParseDuration(crt.Spec.Duration)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing certificate duration %q: %s", crt.Spec.Duration, err.Error())
+	}
+
+	if requestedDuration <= maxDuration {
+		return crt, nil
+	}
+
+	if issuerObj.Spec.MaxDurationPolicy == "Reject" {
+		return nil, fmt.Errorf("requested duration %s exceeds issuer maxDuration %s", requestedDuration, maxDuration)
+	}
+
+	update := crt.DeepCopy()
+	update.Spec.Duration = maxDuration.String()
+	return update, nil
+}
+
+// enforceFIPSPolicy rejects crt if it requests a key size that is not FIPS
+// 140-2 approved. A zero KeySize resolves to pki.DefaultRSAKeySize (2048),
+// which is itself approved, so Certificates that don't set KeySize are
+// never rejected.
+func enforceFIPSPolicy(crt *v1alpha1.Certificate) error {
+	keySize := crt.Spec.KeySize
+	if keySize == 0 {
+		keySize = pki.DefaultRSAKeySize
+	}
+	return pki.ValidateFIPSKeySize(keySize)
+}
+
+// checkKMSSupported returns an error if crt requests its private key be
+// generated and held by an external KMS/HSM (spec.kms), since no provider
+// is currently implemented. This is checked explicitly, rather than falling
+// back to a locally generated key, since doing so silently would write the
+// private key to the target Secret in plain contradiction of what the
+// Certificate requested.
+func checkKMSSupported(crt *v1alpha1.Certificate) error {
+	if crt.Spec.KMS == nil {
+		return nil
+	}
+	return fmt.Errorf("spec.kms.provider %q is not supported by this controller; no KMS/HSM provider is currently implemented", crt.Spec.KMS.Provider)
+}
+
+// validateAndNormalizeDomains validates crt's requested domains, rejecting
+// any that are a bare public suffix (e.g. "*.com") since no CA will ever
+// issue a certificate for one, or that normalize to an empty name, and
+// normalizes the rest (case, trailing dot, internationalized domain names
+// converted to their canonical ASCII/punycode form) so that they are issued
+// and compared consistently regardless of how they were entered. Duplicate
+// domains that only differ by this cosmetic normalization are collapsed
+// into a single entry, so they don't force spurious re-issuance or end up
+// duplicated in the resulting certificate's SANs. If no domain needed
+// normalizing or deduplicating, crt is returned unmodified.
+func validateAndNormalizeDomains(crt *v1alpha1.Certificate) (*v1alpha1.Certificate, error) {
+	seen := make(map[string]struct{}, len(crt.Spec.Domains))
+	domains := make([]string, 0, len(crt.Spec.Domains))
+
+	for _, domain := range crt.Spec.Domains {
+		normalized, err := pki.NormalizeDomain(domain)
+		if err != nil {
+			return nil, err
+		}
+
+		if normalized == "" {
+			return nil, fmt.Errorf("domain %q normalizes to an empty name", domain)
+		}
+
+		if err := pki.ValidateDomain(normalized); err != nil {
+			return nil, err
+		}
+
+		if _, ok := seen[normalized]; ok {
+			continue
+		}
+		seen[normalized] = struct{}{}
+		domains = append(domains, normalized)
+	}
+
+	if util.EqualUnsorted(domains, crt.Spec.Domains) {
+		return crt, nil
+	}
+
+	update := crt.DeepCopy()
+	update.Spec.Domains = domains
+	return update, nil
+}
+
+// validateIssuedCertificate checks a freshly issued or renewed certificate
+// against the Certificate resource that requested it, before it is
+// persisted to the target Secret: that its SANs match the requested
+// domains and email addresses, that it was issued for the private key it
+// is being paired with,
+// and, if a CA bundle was returned alongside it, that the CA actually
+// signed it. This guards against an issuer bug or a compromised/misbehaving
+// backend silently overwriting a working Secret with a non-conforming
+// certificate.
+func validateIssuedCertificate(crt *v1alpha1.Certificate, keyPEM, certPEM, caPEM []byte) error {
+	cert, err := pki.DecodeX509CertificateBytes(certPEM)
+	if err != nil {
+		return fmt.Errorf("error decoding issued certificate: %s", err.Error())
+	}
+
+	// CommonNameOnly certificates are issued with no SAN extension at all,
+	// so they never carry crt.Spec.Domains as DNSNames.
+	if !crt.Spec.CommonNameOnly && !util.EqualUnsorted(crt.Spec.Domains, cert.DNSNames) {
+		return fmt.Errorf("issued certificate does not contain the requested domains (wanted %v, got %v)", crt.Spec.Domains, cert.DNSNames)
+	}
+
+	if !util.EqualUnsorted(crt.Spec.EmailAddresses, cert.EmailAddresses) {
+		return fmt.Errorf("issued certificate does not contain the requested email addresses (wanted %v, got %v)", crt.Spec.EmailAddresses, cert.EmailAddresses)
+	}
+
+	if crt.Spec.CodeSigning && !hasExtKeyUsage(cert.ExtKeyUsage, x509.ExtKeyUsageCodeSigning) {
+		return fmt.Errorf("issued certificate does not have the requested codeSigning extended key usage")
+	}
+
+	key, err := pki.DecodePKCS1PrivateKeyBytes(keyPEM)
+	if err != nil {
+		return fmt.Errorf("error decoding issued private key: %s", err.Error())
+	}
+
+	if !pki.CertificateMatchesKey(cert, key) {
+		return fmt.Errorf("issued certificate does not match the private key it was issued with")
+	}
+
+	if len(caPEM) > 0 {
+		caCert, err := pki.DecodeX509CertificateBytes(caPEM)
+		if err != nil {
+			return fmt.Errorf("error decoding issued CA certificate: %s", err.Error())
+		}
+
+		if !pki.CertificateSignedBy(cert, caCert) {
+			return fmt.Errorf("issued certificate was not signed by the accompanying CA certificate")
+		}
+	}
+
+	return nil
+}
+
+// hasExtKeyUsage returns true if usages contains usage.
+func hasExtKeyUsage(usages []x509.ExtKeyUsage, usage x509.ExtKeyUsage) bool {
+	for _, u := range usages {
+		if u == usage {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Controller) prepare(ctx context.Context, issuer issuer.Interface, crt *v1alpha1.Certificate) (err error) {
 	var status v1alpha1.CertificateStatus
-	status, err = issuer.Prepare(crt)
+	status, err = issuer.Prepare(ctx, crt)
 
 	defer func() {
 		if saveErr := c.updateCertificateStatus(crt, status); saveErr != nil {
@@ -192,25 +516,73 @@ func (c *Controller) prepare(issuer issuer.Interface, crt *v1alpha1.Certificate)
 
 // return an error on failure. If retrieval is succesful, the certificate data
 // and private key will be stored in the named secret
-func (c *Controller) issue(issuer issuer.Interface, crt *v1alpha1.Certificate) (err error) {
+func (c *Controller) issue(ctx context.Context, issuerObj *v1alpha1.Issuer, issuer issuer.Interface, crt *v1alpha1.Certificate) (err error) {
+	start := c.clock.Now()
+
 	s := messagePreparingCertificate
 	glog.Info(s)
 	c.recorder.Event(crt, api.EventTypeNormal, reasonPreparingCertificate, s)
 
-	if err := c.prepare(issuer, crt); err != nil {
+	if err := c.prepare(ctx, issuer, crt); err != nil {
 		s := messageErrorPreparingCertificate + err.Error()
 		glog.Info(s)
 		c.recorder.Event(crt, api.EventTypeWarning, errorPreparingCertificate, s)
 		return err
 	}
 
+	if err := policy.Check(ctx, issuerObj.Spec.PolicyWebhook, policy.RequestForCertificate(issuerObj.Name, crt)); err != nil {
+		s := messageErrorPolicyWebhook + err.Error()
+		glog.Info(s)
+		c.recorder.Event(crt, api.EventTypeWarning, errorPolicyWebhook, s)
+		return err
+	}
+
+	crtToIssue, err := enforceMaxDuration(issuerObj, crt)
+	if err != nil {
+		s := messageErrorMaxDuration + err.Error()
+		glog.Info(s)
+		c.recorder.Event(crt, api.EventTypeWarning, errorMaxDuration, s)
+		return err
+	}
+
+	crtToIssue, err = validateAndNormalizeDomains(crtToIssue)
+	if err != nil {
+		s := messageErrorInvalidDomains + err.Error()
+		glog.Info(s)
+		c.recorder.Event(crt, api.EventTypeWarning, errorInvalidDomains, s)
+		return err
+	}
+
+	if err := checkKMSSupported(crtToIssue); err != nil {
+		s := messageErrorKMSNotSupported + err.Error()
+		glog.Info(s)
+		c.recorder.Event(crt, api.EventTypeWarning, errorKMSNotSupported, s)
+		return err
+	}
+
+	if c.strictFIPS {
+		if err := enforceFIPSPolicy(crtToIssue); err != nil {
+			s := messageErrorFIPSPolicy + err.Error()
+			glog.Info(s)
+			c.recorder.Event(crt, api.EventTypeWarning, errorFIPSPolicy, s)
+			return err
+		}
+	}
+
 	s = messageIssuingCertificate
 	glog.Info(s)
 	c.recorder.Event(crt, api.EventTypeNormal, reasonIssuingCertificate, s)
 
-	status, key, cert, err := issuer.Issue(crt)
+	signSpan := trace.StartSpan(ctx, "sign-order", trace.CertificateAttr(crt.Namespace, crt.Name))
+	status, key, cert, ca, err := issuer.Issue(ctx, crtToIssue)
+	signSpan.End()
 
 	defer func() {
+		status.LastIssuance = &v1alpha1.CertificateIssuanceStatus{
+			StartTime:       metav1.NewTime(start),
+			DurationSeconds: c.clock.Now().Sub(start).Seconds(),
+			Succeeded:       err == nil,
+		}
 		if saveErr := c.updateCertificateStatus(crt, status); saveErr != nil {
 			errs := []error{saveErr}
 			if err != nil {
@@ -218,26 +590,26 @@ func (c *Controller) issue(issuer issuer.Interface, crt *v1alpha1.Certificate) (
 			}
 			err = utilerrors.NewAggregate(errs)
 		}
+		if err != nil {
+			notify.Send(crt.Spec.Notify, notify.NotificationForCertificate(notify.EventFailed, crt, nil, err.Error()))
+		}
 	}()
 
 	if err != nil {
 		s := messageErrorIssuingCertificate + err.Error()
 		glog.Info(s)
-		c.recorder.Event(crt, api.EventTypeWarning, errorIssuingCertificate, s)
+		c.recorder.Event(crt, api.EventTypeWarning, failureReason(errorIssuingCertificate, err), s)
 		return err
 	}
 
-	_, err = kube.EnsureSecret(c.client, &api.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      crt.Spec.SecretName,
-			Namespace: crt.Namespace,
-		},
-		Data: map[string][]byte{
-			api.TLSCertKey:       cert,
-			api.TLSPrivateKeyKey: key,
-		},
-	})
+	if err = validateIssuedCertificate(crtToIssue, key, cert, ca); err != nil {
+		s := messageErrorMisissuedCertificate + err.Error()
+		glog.Info(s)
+		c.recorder.Event(crt, api.EventTypeWarning, errorMisissuedCertificate, s)
+		return err
+	}
 
+	secret, err := c.secretWithHistory(crt, issuerObj.Name, key, cert, ca)
 	if err != nil {
 		s := messageErrorSavingCertificate + err.Error()
 		glog.Info(s)
@@ -245,35 +617,112 @@ func (c *Controller) issue(issuer issuer.Interface, crt *v1alpha1.Certificate) (
 		return err
 	}
 
+	writeSpan := trace.StartSpan(ctx, "secret-write", trace.CertificateAttr(crt.Namespace, crt.Name))
+	if crt.Spec.ImmutableSecretRotation {
+		_, err = kube.EnsureImmutableSecret(ctx, c.client, secret)
+	} else {
+		_, err = kube.EnsureSecret(ctx, c.client, secret)
+	}
+	writeSpan.End()
+
+	if err != nil {
+		s := messageErrorSavingCertificate + err.Error()
+		glog.Info(s)
+		c.recorder.Event(crt, api.EventTypeWarning, errorSavingCertificate, s)
+		return err
+	}
+
+	if crt.Spec.ImmutableSecretRotation {
+		if err = c.updateCurrentSecretNameAnnotation(crt, secret.Name); err != nil {
+			s := messageErrorSavingCertificate + err.Error()
+			glog.Info(s)
+			c.recorder.Event(crt, api.EventTypeWarning, errorSavingCertificate, s)
+			return err
+		}
+	}
+
+	status.NotAfter = notAfterFromPEM(cert)
+
 	s = messageCertificateIssued
 	glog.Info(s)
 	c.recorder.Event(crt, api.EventTypeNormal, successCeritificateIssued, s)
 
+	c.recordAudit(auditlog.EventIssued, issuerObj, crt, cert)
+	notify.Send(crt.Spec.Notify, notify.NotificationForCertificate(notify.EventIssued, crt, cert, ""))
+
 	return nil
 }
 
 // renew will attempt to renew a certificate from the specified issuer, or
 // return an error on failure. If renewal is succesful, the certificate data
 // and private key will be stored in the named secret
-func (c *Controller) renew(issuer issuer.Interface, crt *v1alpha1.Certificate) error {
+func (c *Controller) renew(ctx context.Context, issuerObj *v1alpha1.Issuer, issuer issuer.Interface, crt *v1alpha1.Certificate) error {
+	start := c.clock.Now()
+
 	s := messagePreparingCertificate
 	glog.Info(s)
 	c.recorder.Event(crt, api.EventTypeNormal, reasonPreparingCertificate, s)
 
-	if err := c.prepare(issuer, crt); err != nil {
+	if err := c.prepare(ctx, issuer, crt); err != nil {
 		s := messageErrorPreparingCertificate + err.Error()
 		glog.Info(s)
 		c.recorder.Event(crt, api.EventTypeWarning, errorPreparingCertificate, s)
 		return err
 	}
 
+	if err := policy.Check(ctx, issuerObj.Spec.PolicyWebhook, policy.RequestForCertificate(issuerObj.Name, crt)); err != nil {
+		s := messageErrorPolicyWebhook + err.Error()
+		glog.Info(s)
+		c.recorder.Event(crt, api.EventTypeWarning, errorPolicyWebhook, s)
+		return err
+	}
+
+	crtToRenew, err := enforceMaxDuration(issuerObj, crt)
+	if err != nil {
+		s := messageErrorMaxDuration + err.Error()
+		glog.Info(s)
+		c.recorder.Event(crt, api.EventTypeWarning, errorMaxDuration, s)
+		return err
+	}
+
+	crtToRenew, err = validateAndNormalizeDomains(crtToRenew)
+	if err != nil {
+		s := messageErrorInvalidDomains + err.Error()
+		glog.Info(s)
+		c.recorder.Event(crt, api.EventTypeWarning, errorInvalidDomains, s)
+		return err
+	}
+
+	if err := checkKMSSupported(crtToRenew); err != nil {
+		s := messageErrorKMSNotSupported + err.Error()
+		glog.Info(s)
+		c.recorder.Event(crt, api.EventTypeWarning, errorKMSNotSupported, s)
+		return err
+	}
+
+	if c.strictFIPS {
+		if err := enforceFIPSPolicy(crtToRenew); err != nil {
+			s := messageErrorFIPSPolicy + err.Error()
+			glog.Info(s)
+			c.recorder.Event(crt, api.EventTypeWarning, errorFIPSPolicy, s)
+			return err
+		}
+	}
+
 	s = messageRenewingCertificate
 	glog.Info(s)
 	c.recorder.Event(crt, api.EventTypeNormal, reasonRenewingCertificate, s)
 
-	status, key, cert, err := issuer.Renew(crt)
+	signSpan := trace.StartSpan(ctx, "sign-order", trace.CertificateAttr(crt.Namespace, crt.Name))
+	status, key, cert, ca, err := issuer.Renew(ctx, crtToRenew)
+	signSpan.End()
 
 	defer func() {
+		status.LastIssuance = &v1alpha1.CertificateIssuanceStatus{
+			StartTime:       metav1.NewTime(start),
+			DurationSeconds: c.clock.Now().Sub(start).Seconds(),
+			Succeeded:       err == nil,
+		}
 		if saveErr := c.updateCertificateStatus(crt, status); saveErr != nil {
 			errs := []error{saveErr}
 			if err != nil {
@@ -281,25 +730,40 @@ func (c *Controller) renew(issuer issuer.Interface, crt *v1alpha1.Certificate) e
 			}
 			err = utilerrors.NewAggregate(errs)
 		}
+		if err != nil {
+			notify.Send(crt.Spec.Notify, notify.NotificationForCertificate(notify.EventFailed, crt, nil, err.Error()))
+		}
 	}()
 
 	if err != nil {
 		s := messageErrorRenewingCertificate + err.Error()
 		glog.Info(s)
-		c.recorder.Event(crt, api.EventTypeWarning, errorRenewingCertificate, s)
+		c.recorder.Event(crt, api.EventTypeWarning, failureReason(errorRenewingCertificate, err), s)
 		return err
 	}
 
-	_, err = kube.EnsureSecret(c.client, &api.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      crt.Spec.SecretName,
-			Namespace: crt.Namespace,
-		},
-		Data: map[string][]byte{
-			api.TLSCertKey:       cert,
-			api.TLSPrivateKeyKey: key,
-		},
-	})
+	if err = validateIssuedCertificate(crtToRenew, key, cert, ca); err != nil {
+		s := messageErrorMisissuedCertificate + err.Error()
+		glog.Info(s)
+		c.recorder.Event(crt, api.EventTypeWarning, errorMisissuedCertificate, s)
+		return err
+	}
+
+	secret, err := c.secretWithHistory(crt, issuerObj.Name, key, cert, ca)
+	if err != nil {
+		s := messageErrorSavingCertificate + err.Error()
+		glog.Info(s)
+		c.recorder.Event(crt, api.EventTypeWarning, errorSavingCertificate, s)
+		return err
+	}
+
+	writeSpan := trace.StartSpan(ctx, "secret-write", trace.CertificateAttr(crt.Namespace, crt.Name))
+	if crt.Spec.ImmutableSecretRotation {
+		_, err = kube.EnsureImmutableSecret(ctx, c.client, secret)
+	} else {
+		_, err = kube.EnsureSecret(ctx, c.client, secret)
+	}
+	writeSpan.End()
 
 	if err != nil {
 		s := messageErrorSavingCertificate + err.Error()
@@ -308,22 +772,320 @@ func (c *Controller) renew(issuer issuer.Interface, crt *v1alpha1.Certificate) e
 		return err
 	}
 
+	if crt.Spec.ImmutableSecretRotation {
+		if err = c.updateCurrentSecretNameAnnotation(crt, secret.Name); err != nil {
+			s := messageErrorSavingCertificate + err.Error()
+			glog.Info(s)
+			c.recorder.Event(crt, api.EventTypeWarning, errorSavingCertificate, s)
+			return err
+		}
+	}
+
+	status.NotAfter = notAfterFromPEM(cert)
+
 	s = messageCertificateRenewed
 	glog.Info(s)
 	c.recorder.Event(crt, api.EventTypeNormal, successCeritificateRenewed, s)
 
+	c.recordAudit(auditlog.EventRenewed, issuerObj, crt, cert)
+	notify.Send(crt.Spec.Notify, notify.NotificationForCertificate(notify.EventRenewed, crt, cert, ""))
+
+	c.triggerRollout(crt)
+
 	return nil
 }
 
+// triggerRollout restarts any Deployment/StatefulSet referencing crt's
+// target Secret, if a rollout trigger is configured. Errors are logged
+// rather than returned, since a failure to restart consumers of a
+// certificate shouldn't cause the renewal itself to be retried.
+func (c *Controller) triggerRollout(crt *v1alpha1.Certificate) {
+	if c.rolloutTrigger == nil {
+		return
+	}
+
+	if err := c.rolloutTrigger.Restart(crt.Namespace, crt.Spec.SecretName); err != nil {
+		glog.Errorf("error triggering rollout restart for Secret %s/%s: %s", crt.Namespace, crt.Spec.SecretName, err.Error())
+	}
+}
+
+// cleanupStalePendingChallenges asks issuer to clean up any challenge
+// resources it left behind from an issuance attempt that was abandoned or
+// interrupted before it could clean up after itself - for example, if the
+// controller was restarted mid-challenge. It runs on every resync of crt, so
+// that such resources are eventually cleaned up even though nothing else
+// about crt may have changed.
+func (c *Controller) cleanupStalePendingChallenges(ctx context.Context, crt *v1alpha1.Certificate, issuer issuer.Interface) error {
+	status, err := issuer.CleanupStalePendingChallenges(ctx, crt)
+	if saveErr := c.updateCertificateStatus(crt, status); saveErr != nil {
+		return saveErr
+	}
+	return err
+}
+
+// recordAudit appends a record of event to c.auditLog, if one is
+// configured. Errors are logged rather than returned, since a failure to
+// audit an issuance shouldn't cause the issuance itself to be retried.
+func (c *Controller) recordAudit(event auditlog.Event, issuerObj *v1alpha1.Issuer, crt *v1alpha1.Certificate, certPEM []byte) {
+	if c.auditLog == nil {
+		return
+	}
+
+	cert, err := pki.DecodeX509CertificateBytes(certPEM)
+	if err != nil {
+		glog.Errorf("error decoding issued certificate for audit log: %s", err.Error())
+		return
+	}
+
+	serial := fmt.Sprintf("%x", cert.SerialNumber)
+	if err := c.auditLog.Record(event, crt.Namespace, crt.Name, issuerObj.Name, serial, cert.DNSNames); err != nil {
+		glog.Errorf("error writing audit log record: %s", err.Error())
+	}
+}
+
+// certificateIssuerHasChanged returns true if the Secret holding crt's
+// current certificate records the name of the Issuer that produced it, and
+// that name no longer matches issuerName (the resolved name of the Issuer
+// crt.Spec.Issuer, or its namespace's default issuer, now refers to),
+// indicating the effective issuer has changed since the certificate was
+// issued.
+func (c *Controller) certificateIssuerHasChanged(crt *v1alpha1.Certificate, issuerName string) bool {
+	existingSecret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if err != nil {
+		return false
+	}
+
+	issuedBy := existingSecret.Annotations[v1alpha1.AnnotationIssuerName]
+	return issuedBy != "" && issuedBy != issuerName
+}
+
+// syncSignerExpiringSoonCondition mirrors issuerObj's
+// IssuerConditionSignerExpiringSoon condition onto crt, so the warning that
+// a CA issuer's signing certificate is approaching its own expiry surfaces
+// on every Certificate it issues, not only on the Issuer.
+func (c *Controller) syncSignerExpiringSoonCondition(crt *v1alpha1.Certificate, issuerObj *v1alpha1.Issuer) error {
+	signerExpiringSoon := issuerObj.HasCondition(v1alpha1.IssuerCondition{
+		Type:   v1alpha1.IssuerConditionSignerExpiringSoon,
+		Status: v1alpha1.ConditionTrue,
+	})
+
+	hasCondition := crt.HasCondition(v1alpha1.CertificateCondition{
+		Type:   v1alpha1.CertificateConditionSignerExpiringSoon,
+		Status: v1alpha1.ConditionTrue,
+	})
+
+	if signerExpiringSoon == hasCondition {
+		return nil
+	}
+
+	update := crt.DeepCopy()
+	if signerExpiringSoon {
+		s := fmt.Sprintf("Issuer %s's signing certificate is approaching expiry", issuerObj.Name)
+		c.recorder.Event(crt, api.EventTypeWarning, reasonSignerExpiringSoon, s)
+		update.UpdateStatusCondition(v1alpha1.CertificateConditionSignerExpiringSoon, v1alpha1.ConditionTrue, reasonSignerExpiringSoon, s)
+	} else {
+		s := fmt.Sprintf("Issuer %s's signing certificate is no longer approaching expiry", issuerObj.Name)
+		update.UpdateStatusCondition(v1alpha1.CertificateConditionSignerExpiringSoon, v1alpha1.ConditionFalse, reasonSignerExpiryOK, s)
+	}
+
+	return c.updateCertificateStatus(crt, update.Status)
+}
+
+// secretWithHistory builds the Secret that should be persisted for a newly
+// issued or renewed certificate. If crt.Spec.ImmutableSecretRotation is set,
+// this is a new, uniquely named Secret that should be created alongside any
+// Secret from a previous issuance (see immutableRotatedSecret); otherwise
+// it is crt.Spec.SecretName, with the Secret's previous cert/key data (if
+// any) recorded in its revision history annotation first. issuerName is
+// recorded in the AnnotationIssuerName annotation, so a later spec.issuer
+// change can be detected. The Secret is also stamped with
+// AnnotationCertificateChecksum, so consumers watching for Secret changes
+// can cheaply detect rotation.
+func (c *Controller) secretWithHistory(crt *v1alpha1.Certificate, issuerName string, key, cert, ca []byte) (*api.Secret, error) {
+	if crt.Spec.ImmutableSecretRotation {
+		return c.immutableRotatedSecret(crt, issuerName, key, cert, ca), nil
+	}
+
+	newSecret := &api.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      crt.Spec.SecretName,
+			Namespace: crt.Namespace,
+			Annotations: map[string]string{
+				v1alpha1.AnnotationIssuerName:          issuerName,
+				v1alpha1.AnnotationCertificateChecksum: certificateChecksum(cert),
+			},
+		},
+		Type: secretTypeForCertificate(crt),
+		Data: c.secretDataForCertificate(crt, key, cert, ca),
+	}
+
+	existingSecret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return newSecret, nil
+		}
+		return nil, err
+	}
+
+	return kube.RecordSecretHistory(existingSecret, newSecret)
+}
+
+// immutableRotatedSecret builds the Secret that should be persisted for a
+// newly issued or renewed certificate belonging to a Certificate with
+// spec.immutableSecretRotation set. Rather than reusing crt.Spec.SecretName
+// across issuances, it is named after a checksum of the certificate data, so
+// that each issuance writes a distinct Secret rather than mutating one in
+// place; the caller is responsible for recording the returned Secret's name
+// in AnnotationCurrentSecretName once it has been written.
+func (c *Controller) immutableRotatedSecret(crt *v1alpha1.Certificate, issuerName string, key, cert, ca []byte) *api.Secret {
+	checksum := certificateChecksum(cert)
+	return &api.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", crt.Spec.SecretName, checksum[:8]),
+			Namespace: crt.Namespace,
+			Annotations: map[string]string{
+				v1alpha1.AnnotationIssuerName:          issuerName,
+				v1alpha1.AnnotationCertificateChecksum: checksum,
+			},
+		},
+		Type: secretTypeForCertificate(crt),
+		Data: c.secretDataForCertificate(crt, key, cert, ca),
+	}
+}
+
+// secretTypeForCertificate returns the Kubernetes Secret type that should be
+// used for crt's target Secret, honouring crt.Spec.SecretType if set and
+// falling back to the standard TLS Secret type otherwise.
+func secretTypeForCertificate(crt *v1alpha1.Certificate) api.SecretType {
+	if crt.Spec.SecretType != "" {
+		return api.SecretType(crt.Spec.SecretType)
+	}
+	return api.SecretTypeTLS
+}
+
+// updateCurrentSecretNameAnnotation sets AnnotationCurrentSecretName to
+// secretName on crt, so that consumers of a Certificate with
+// spec.immutableSecretRotation set can discover which Secret currently
+// holds its active certificate.
+func (c *Controller) updateCurrentSecretNameAnnotation(crt *v1alpha1.Certificate, secretName string) error {
+	if crt.Annotations[v1alpha1.AnnotationCurrentSecretName] == secretName {
+		return nil
+	}
+
+	update := crt.DeepCopy()
+	if update.Annotations == nil {
+		update.Annotations = map[string]string{}
+	}
+	update.Annotations[v1alpha1.AnnotationCurrentSecretName] = secretName
+	_, err := c.cmClient.CertmanagerV1alpha1().Certificates(update.Namespace).Update(update)
+	return err
+}
+
 func (c *Controller) updateCertificateStatus(crt *v1alpha1.Certificate, status v1alpha1.CertificateStatus) error {
 	updateCertificate := crt.DeepCopy()
 	updateCertificate.Status = status
 	if reflect.DeepEqual(crt.Status, updateCertificate.Status) {
 		return nil
 	}
-	// TODO: replace Update call with UpdateStatus. This requires a custom API
-	// server with the /status subresource enabled and/or subresource support
-	// for CRDs (https://github.com/kubernetes/kubernetes/issues/38113)
-	_, err := c.cmClient.CertmanagerV1alpha1().Certificates(crt.Namespace).Update(updateCertificate)
+	_, err := c.cmClient.CertmanagerV1alpha1().Certificates(crt.Namespace).UpdateStatus(updateCertificate)
 	return err
 }
+
+// secretDataForCertificate builds the Secret.Data map that should be
+// persisted for a newly issued or renewed certificate, using crt's
+// SecretKeys (or the Kubernetes TLS Secret convention, if unset) to name
+// each entry. ca is only set by issuers (such as the CA issuer) that have a
+// signing certificate bundle to distribute alongside the leaf certificate,
+// and is omitted otherwise. If crt.Spec.JKS is set and ca is available, a
+// "truststore.jks" entry containing the CA bundle is also added; any error
+// building it is recorded as a warning Event on crt and otherwise ignored,
+// so a truststore misconfiguration does not block issuance of the PEM data.
+func (c *Controller) secretDataForCertificate(crt *v1alpha1.Certificate, key, cert, ca []byte) map[string][]byte {
+	data := map[string][]byte{
+		crt.CertificateKey(): cert,
+		crt.PrivateKeyKey():  key,
+	}
+	if len(ca) > 0 {
+		data[crt.CAKey()] = ca
+	}
+
+	if crt.Spec.JKS != nil && len(ca) > 0 {
+		trustStore, err := c.jksTrustStoreForCertificate(crt, ca)
+		if err != nil {
+			s := messageErrorJKSTrustStore + err.Error()
+			glog.Errorf("error building JKS truststore for certificate %s/%s: %s", crt.Namespace, crt.Name, err.Error())
+			c.recorder.Event(crt, api.EventTypeWarning, errorJKSTrustStore, s)
+		} else {
+			data[jksTrustStoreKey] = trustStore
+		}
+	}
+
+	return data
+}
+
+// jksTrustStoreKey is the Secret data key the JKS truststore is written
+// under, when crt.Spec.JKS is set.
+const jksTrustStoreKey = "truststore.jks"
+
+// defaultJKSPassword is the well known default password used by `keytool`
+// when none is given, used to protect the generated truststore.jks when
+// crt.Spec.JKS.PasswordSecretRef is unset.
+const defaultJKSPassword = "changeit"
+
+// jksTrustStoreForCertificate builds the JKS truststore for crt from the
+// certificates in ca, protected with the password referenced by
+// crt.Spec.JKS.PasswordSecretRef, or defaultJKSPassword if unset.
+func (c *Controller) jksTrustStoreForCertificate(crt *v1alpha1.Certificate, ca []byte) ([]byte, error) {
+	certs, err := pki.DecodeX509CertificateChainBytes(ca)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding CA bundle: %s", err.Error())
+	}
+
+	password := defaultJKSPassword
+	if ref := crt.Spec.JKS.PasswordSecretRef; ref != nil {
+		secret, err := c.secretLister.Secrets(crt.Namespace).Get(ref.Name)
+		if err != nil {
+			return nil, fmt.Errorf("error getting JKS password secret: %s", err.Error())
+		}
+		passwordBytes, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("no data for key '%s' in JKS password secret '%s'", ref.Key, ref.Name)
+		}
+		password = string(passwordBytes)
+	}
+
+	return jks.EncodeTrustStore(password, certs)
+}
+
+// certificateChecksum returns a hex-encoded SHA-256 checksum of the given
+// PEM encoded certificate, suitable for stamping onto a Secret so
+// consumers can detect when the certificate content has changed.
+func certificateChecksum(cert []byte) string {
+	sum := sha256.Sum256(cert)
+	return hex.EncodeToString(sum[:])
+}
+
+// failureReason returns a machine-readable reason code for err, preferring a
+// coarse-grained failure class (e.g. "Quota", "Auth", "DNS", "Validation")
+// when err can be classified, so that automation watching Certificate
+// Events can branch on failure class rather than string-matching the
+// human-readable message. It falls back to defaultReason when err's class
+// cannot be determined.
+func failureReason(defaultReason string, err error) string {
+	if class := errors.ClassifyFailure(err); class != errors.FailureClassUnknown {
+		return string(class)
+	}
+	return defaultReason
+}
+
+// notAfterFromPEM returns the expiry time of the given PEM encoded
+// certificate, to be recorded on CertificateStatus. If the certificate
+// cannot be parsed, nil is returned so status reporting doesn't fail an
+// otherwise successful issuance.
+func notAfterFromPEM(certPEM []byte) *metav1.Time {
+	cert, err := pki.DecodeX509CertificateBytes(certPEM)
+	if err != nil {
+		return nil
+	}
+	return &metav1.Time{Time: cert.NotAfter}
+}
@@ -0,0 +1,751 @@
+package certificates
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/client/clientset/fake"
+	cmlisters "github.com/jetstack-experimental/cert-manager/pkg/client/listers/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/util"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/pki"
+)
+
+// fakeScheduledWorkQueue records the durations it is asked to schedule work
+// for, so tests can assert on them without waiting for a real timer to fire.
+type fakeScheduledWorkQueue struct {
+	added map[interface{}]time.Duration
+}
+
+func (f *fakeScheduledWorkQueue) Add(obj interface{}, duration time.Duration) {
+	f.added[obj] = duration
+}
+
+func (f *fakeScheduledWorkQueue) Forget(obj interface{}) {
+	delete(f.added, obj)
+}
+
+func newTestSecretLister(t *testing.T, namespace, name string, notAfter time.Time) corelisters.SecretLister {
+	key, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatalf("error generating private key: %s", err.Error())
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    notAfter.Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("error creating test certificate: %s", err.Error())
+	}
+
+	secret := &api.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Data: map[string][]byte{
+			api.TLSPrivateKeyKey: pki.EncodePKCS1PrivateKey(key),
+			api.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBytes}),
+		},
+	}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if err := indexer.Add(secret); err != nil {
+		t.Fatalf("error adding secret to indexer: %s", err.Error())
+	}
+
+	return corelisters.NewSecretLister(indexer)
+}
+
+// TestScheduleRenewalUsesInjectedClock verifies that scheduleRenewal computes
+// the renewal delay relative to the Controller's injected clock, rather than
+// the real wall clock, so renewal timing can be tested deterministically.
+func TestScheduleRenewalUsesInjectedClock(t *testing.T) {
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := now.Add(60 * 24 * time.Hour)
+
+	crt := &v1alpha1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+		Spec:       v1alpha1.CertificateSpec{SecretName: "test-tls"},
+	}
+
+	work := &fakeScheduledWorkQueue{added: make(map[interface{}]time.Duration)}
+	c := &Controller{
+		clock:              clock.NewFakeClock(now),
+		secretLister:       newTestSecretLister(t, crt.Namespace, crt.Spec.SecretName, notAfter),
+		scheduledWorkQueue: work,
+		recorder:           record.NewFakeRecorder(10),
+	}
+
+	c.scheduleRenewal(crt)
+
+	key, err := keyFunc(crt)
+	if err != nil {
+		t.Fatalf("error computing key for certificate: %s", err.Error())
+	}
+
+	expected := notAfter.Sub(now) - renewBefore
+	got, ok := work.added[key]
+	if !ok {
+		t.Fatalf("expected a renewal to be scheduled for %q", key)
+	}
+	if got != expected {
+		t.Errorf("expected renewal in %s, got %s", expected, got)
+	}
+}
+
+// TestJitterFor verifies that jitterFor is deterministic for a given
+// Certificate, stays within [0, maxJitter), is disabled by a non-positive
+// maxJitter, and spreads different Certificates across the jitter window
+// rather than always returning the same offset.
+func TestJitterFor(t *testing.T) {
+	crtA := &v1alpha1.Certificate{ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "a"}}
+	crtB := &v1alpha1.Certificate{ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "b"}}
+
+	if got := jitterFor(crtA, 0); got != 0 {
+		t.Errorf("expected zero jitter when maxJitter is 0, got %s", got)
+	}
+
+	maxJitter := time.Hour
+	gotA1 := jitterFor(crtA, maxJitter)
+	gotA2 := jitterFor(crtA, maxJitter)
+	if gotA1 != gotA2 {
+		t.Errorf("expected jitterFor to be deterministic for the same certificate, got %s and %s", gotA1, gotA2)
+	}
+	if gotA1 < 0 || gotA1 >= maxJitter {
+		t.Errorf("expected jitter in [0, %s), got %s", maxJitter, gotA1)
+	}
+
+	gotB := jitterFor(crtB, maxJitter)
+	if gotA1 == gotB {
+		t.Errorf("expected different certificates to compute different jitter, both got %s", gotA1)
+	}
+}
+
+func newTestCertificateLister(t *testing.T, crts ...*v1alpha1.Certificate) cmlisters.CertificateLister {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, crt := range crts {
+		if err := indexer.Add(crt); err != nil {
+			t.Fatalf("error adding certificate to indexer: %s", err.Error())
+		}
+	}
+	return cmlisters.NewCertificateLister(indexer)
+}
+
+// TestCertificatesSharingSecretName verifies that certificatesSharingSecretName
+// only reports other Certificates in the same namespace targeting the same
+// spec.secretName, excluding both the Certificate itself and Certificates in
+// other namespaces or targeting a different Secret.
+func TestCertificatesSharingSecretName(t *testing.T) {
+	crt := &v1alpha1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+		Spec:       v1alpha1.CertificateSpec{SecretName: "shared-tls"},
+	}
+	conflicting := &v1alpha1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "other"},
+		Spec:       v1alpha1.CertificateSpec{SecretName: "shared-tls"},
+	}
+	otherNamespace := &v1alpha1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "othernamespace", Name: "other"},
+		Spec:       v1alpha1.CertificateSpec{SecretName: "shared-tls"},
+	}
+	unrelated := &v1alpha1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "unrelated"},
+		Spec:       v1alpha1.CertificateSpec{SecretName: "unrelated-tls"},
+	}
+
+	c := &Controller{certificateLister: newTestCertificateLister(t, crt, conflicting, otherNamespace, unrelated)}
+
+	got, err := c.certificatesSharingSecretName(crt)
+	if err != nil {
+		t.Fatalf("error checking for conflicting certificates: %s", err.Error())
+	}
+
+	if len(got) != 1 || got[0].Name != "other" {
+		t.Errorf("expected only %q to conflict with %q, got %v", "other", crt.Name, certificateNames(got))
+	}
+}
+
+// TestSecretDataForCertificate verifies that secretDataForCertificate uses
+// the Kubernetes TLS Secret convention by default, and honours
+// Spec.SecretKeys when a Certificate customizes them.
+func TestSecretDataForCertificate(t *testing.T) {
+	key, cert, ca := []byte("key"), []byte("cert"), []byte("ca")
+	c := &Controller{}
+
+	defaultCrt := &v1alpha1.Certificate{
+		Spec: v1alpha1.CertificateSpec{SecretName: "example-tls"},
+	}
+	data := c.secretDataForCertificate(defaultCrt, key, cert, ca)
+	if string(data["tls.crt"]) != "cert" || string(data["tls.key"]) != "key" || string(data["ca.crt"]) != "ca" {
+		t.Errorf("expected default TLS Secret keys to be used, got: %v", data)
+	}
+
+	customCrt := &v1alpha1.Certificate{
+		Spec: v1alpha1.CertificateSpec{
+			SecretName: "example-tls",
+			SecretKeys: &v1alpha1.CertificateSecretKeys{
+				CertificateKey: "cert.pem",
+				PrivateKeyKey:  "key.pem",
+				CAKey:          "ca-bundle.pem",
+			},
+		},
+	}
+	data = c.secretDataForCertificate(customCrt, key, cert, ca)
+	if string(data["cert.pem"]) != "cert" || string(data["key.pem"]) != "key" || string(data["ca-bundle.pem"]) != "ca" {
+		t.Errorf("expected custom Secret keys to be used, got: %v", data)
+	}
+	if _, ok := data["tls.crt"]; ok {
+		t.Errorf("did not expect default key %q to be present alongside custom keys", "tls.crt")
+	}
+}
+
+// TestSecretDataForCertificateJKS verifies that secretDataForCertificate
+// adds a truststore.jks entry when Spec.JKS is set and a CA bundle is
+// available, and that it honours Spec.JKS.PasswordSecretRef when set.
+func TestSecretDataForCertificateJKS(t *testing.T) {
+	key, cert := []byte("key"), []byte("cert")
+	_, ca := generateTestCA(t, "root-ca")
+
+	crt := &v1alpha1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+		Spec: v1alpha1.CertificateSpec{
+			SecretName: "example-tls",
+			JKS:        &v1alpha1.CertificateJKS{},
+		},
+	}
+
+	c := &Controller{}
+	data := c.secretDataForCertificate(crt, key, cert, ca)
+	trustStore, ok := data["truststore.jks"]
+	if !ok || len(trustStore) == 0 {
+		t.Fatalf("expected a non-empty truststore.jks entry, got: %v", data)
+	}
+
+	passwordSecret := &api.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "jks-password"},
+		Data:       map[string][]byte{"password": []byte("hunter2")},
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := indexer.Add(passwordSecret); err != nil {
+		t.Fatalf("error adding password secret to indexer: %s", err.Error())
+	}
+
+	crt.Spec.JKS.PasswordSecretRef = &v1alpha1.SecretKeySelector{
+		LocalObjectReference: v1alpha1.LocalObjectReference{Name: "jks-password"},
+		Key:                  "password",
+	}
+	c = &Controller{secretLister: corelisters.NewSecretLister(indexer)}
+	data = c.secretDataForCertificate(crt, key, cert, ca)
+	if len(data["truststore.jks"]) == 0 {
+		t.Fatalf("expected a non-empty truststore.jks entry when using a custom password, got: %v", data)
+	}
+
+	missingCrt := &v1alpha1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "example"},
+		Spec: v1alpha1.CertificateSpec{
+			SecretName: "example-tls",
+			JKS: &v1alpha1.CertificateJKS{
+				PasswordSecretRef: &v1alpha1.SecretKeySelector{
+					LocalObjectReference: v1alpha1.LocalObjectReference{Name: "missing-secret"},
+					Key:                  "password",
+				},
+			},
+		},
+	}
+	recorder := record.NewFakeRecorder(10)
+	c = &Controller{
+		secretLister: corelisters.NewSecretLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})),
+		recorder:     recorder,
+	}
+	data = c.secretDataForCertificate(missingCrt, key, cert, ca)
+	if _, ok := data["truststore.jks"]; ok {
+		t.Errorf("did not expect a truststore.jks entry when the password Secret is missing, got: %v", data)
+	}
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, errorJKSTrustStore) {
+			t.Errorf("expected a %s warning event, got: %s", errorJKSTrustStore, event)
+		}
+	default:
+		t.Errorf("expected a warning event to be recorded when the JKS truststore could not be built")
+	}
+}
+
+// TestSecretTypeForCertificate verifies that secretTypeForCertificate
+// defaults to the standard Kubernetes TLS Secret type, and honours
+// spec.secretType when set.
+func TestSecretTypeForCertificate(t *testing.T) {
+	defaultCrt := &v1alpha1.Certificate{
+		Spec: v1alpha1.CertificateSpec{SecretName: "example-tls"},
+	}
+	if got := secretTypeForCertificate(defaultCrt); got != api.SecretTypeTLS {
+		t.Errorf("expected default Secret type %q, got %q", api.SecretTypeTLS, got)
+	}
+
+	opaqueCrt := &v1alpha1.Certificate{
+		Spec: v1alpha1.CertificateSpec{
+			SecretName: "example-tls",
+			SecretType: string(api.SecretTypeOpaque),
+		},
+	}
+	if got := secretTypeForCertificate(opaqueCrt); got != api.SecretTypeOpaque {
+		t.Errorf("expected custom Secret type %q, got %q", api.SecretTypeOpaque, got)
+	}
+}
+
+// TestImmutableRotatedSecret verifies that immutableRotatedSecret names the
+// Secret after the certificate's checksum, rather than spec.secretName, and
+// produces a different name for different certificate content, so that
+// repeated issuances produce distinct Secret objects instead of mutating
+// one in place.
+func TestImmutableRotatedSecret(t *testing.T) {
+	crt := &v1alpha1.Certificate{
+		Spec: v1alpha1.CertificateSpec{
+			SecretName:              "example-tls",
+			ImmutableSecretRotation: true,
+		},
+	}
+	c := &Controller{}
+
+	secretOne := c.immutableRotatedSecret(crt, "issuer-1", []byte("key"), []byte("cert-one"), nil)
+	if secretOne.Name == crt.Spec.SecretName {
+		t.Errorf("expected a revisioned Secret name, got unchanged spec.secretName %q", secretOne.Name)
+	}
+
+	secretTwo := c.immutableRotatedSecret(crt, "issuer-1", []byte("key"), []byte("cert-two"), nil)
+	if secretOne.Name == secretTwo.Name {
+		t.Errorf("expected different certificate content to produce different Secret names, got %q for both", secretOne.Name)
+	}
+}
+
+// TestCertificateChecksum verifies that certificateChecksum returns a
+// stable value for identical input and a different value when the
+// certificate content changes, so it is useful as a Secret annotation for
+// detecting rotation.
+func TestCertificateChecksum(t *testing.T) {
+	a := certificateChecksum([]byte("cert-one"))
+	b := certificateChecksum([]byte("cert-one"))
+	c := certificateChecksum([]byte("cert-two"))
+
+	if a != b {
+		t.Errorf("expected checksum to be stable for identical input, got %q and %q", a, b)
+	}
+	if a == c {
+		t.Errorf("expected checksum to differ for different input, got %q for both", a)
+	}
+}
+
+// TestCheckKMSSupported verifies that checkKMSSupported allows Certificates
+// with no spec.kms set, and rejects those that request a KMS provider,
+// since none is currently implemented.
+func TestCheckKMSSupported(t *testing.T) {
+	if err := checkKMSSupported(&v1alpha1.Certificate{}); err != nil {
+		t.Errorf("expected no error for a Certificate with no spec.kms, got: %s", err)
+	}
+
+	crt := &v1alpha1.Certificate{
+		Spec: v1alpha1.CertificateSpec{
+			KMS: &v1alpha1.CertificateKMSConfig{Provider: "pkcs11"},
+		},
+	}
+	if err := checkKMSSupported(crt); err == nil {
+		t.Errorf("expected an error for a Certificate requesting an unsupported KMS provider, got none")
+	}
+}
+
+// TestValidateAndNormalizeDomains verifies that validateAndNormalizeDomains
+// lower-cases domains, strips a trailing root dot, and collapses duplicates
+// that only differ by this cosmetic normalization, while leaving an
+// already-normalized, duplicate-free domain list unmodified.
+func TestValidateAndNormalizeDomains(t *testing.T) {
+	crt := &v1alpha1.Certificate{
+		Spec: v1alpha1.CertificateSpec{
+			Domains: []string{"Example.com", "example.com.", "*.example.com", "example.com"},
+		},
+	}
+
+	updated, err := validateAndNormalizeDomains(crt)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := []string{"example.com", "*.example.com"}
+	if !util.EqualUnsorted(updated.Spec.Domains, expected) {
+		t.Errorf("expected domains %v, got %v", expected, updated.Spec.Domains)
+	}
+
+	alreadyNormalized := &v1alpha1.Certificate{
+		Spec: v1alpha1.CertificateSpec{Domains: []string{"example.com", "*.example.com"}},
+	}
+	unchanged, err := validateAndNormalizeDomains(alreadyNormalized)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if unchanged != alreadyNormalized {
+		t.Errorf("expected an already-normalized Certificate to be returned unmodified")
+	}
+}
+
+func TestEnforceFIPSPolicy(t *testing.T) {
+	for _, keySize := range []int{0, 2048, 3072, 4096} {
+		crt := &v1alpha1.Certificate{Spec: v1alpha1.CertificateSpec{KeySize: keySize}}
+		if err := enforceFIPSPolicy(crt); err != nil {
+			t.Errorf("key size %d: expected no error, got: %s", keySize, err.Error())
+		}
+	}
+
+	for _, keySize := range []int{512, 1024, 2047} {
+		crt := &v1alpha1.Certificate{Spec: v1alpha1.CertificateSpec{KeySize: keySize}}
+		if err := enforceFIPSPolicy(crt); err == nil {
+			t.Errorf("key size %d: expected an error, got none", keySize)
+		}
+	}
+}
+
+// TestSyncPausedAnnotation verifies that Sync short-circuits and records a
+// Paused condition for a Certificate carrying the AnnotationIssuePaused
+// annotation, without attempting to look up its Issuer.
+func TestSyncPausedAnnotation(t *testing.T) {
+	cmClient := fake.NewSimpleClientset()
+	crt := &v1alpha1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "testns",
+			Name:        "test",
+			Annotations: map[string]string{v1alpha1.AnnotationIssuePaused: "true"},
+		},
+		Spec: v1alpha1.CertificateSpec{SecretName: "test-tls"},
+	}
+
+	created, err := cmClient.CertmanagerV1alpha1().Certificates(crt.Namespace).Create(crt)
+	if err != nil {
+		t.Fatalf("error creating test certificate: %s", err.Error())
+	}
+
+	c := &Controller{
+		cmClient:          cmClient,
+		certificateLister: newTestCertificateLister(t, crt),
+		recorder:          record.NewFakeRecorder(10),
+	}
+
+	if err := c.Sync(created); err != nil {
+		t.Fatalf("unexpected error from Sync: %s", err.Error())
+	}
+
+	updated, err := cmClient.CertmanagerV1alpha1().Certificates(crt.Namespace).Get(crt.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error fetching updated certificate: %s", err.Error())
+	}
+
+	if !updated.HasCondition(v1alpha1.CertificateCondition{Type: v1alpha1.CertificateConditionPaused, Status: v1alpha1.ConditionTrue}) {
+		t.Errorf("expected certificate to have a Paused=True condition, got %v", updated.Status.Conditions)
+	}
+}
+
+// TestClearRenewNowAnnotation verifies that clearRenewNowAnnotation removes
+// the AnnotationRenewNow annotation from the stored Certificate, so a forced
+// renewal is not repeated on every subsequent sync.
+func TestClearRenewNowAnnotation(t *testing.T) {
+	cmClient := fake.NewSimpleClientset()
+	crt := &v1alpha1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "testns",
+			Name:        "test",
+			Annotations: map[string]string{v1alpha1.AnnotationRenewNow: "true"},
+		},
+		Spec: v1alpha1.CertificateSpec{SecretName: "test-tls"},
+	}
+
+	created, err := cmClient.CertmanagerV1alpha1().Certificates(crt.Namespace).Create(crt)
+	if err != nil {
+		t.Fatalf("error creating test certificate: %s", err.Error())
+	}
+
+	c := &Controller{cmClient: cmClient}
+
+	if err := c.clearRenewNowAnnotation(created); err != nil {
+		t.Fatalf("unexpected error clearing annotation: %s", err.Error())
+	}
+
+	updated, err := cmClient.CertmanagerV1alpha1().Certificates(crt.Namespace).Get(crt.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error fetching updated certificate: %s", err.Error())
+	}
+
+	if _, ok := updated.Annotations[v1alpha1.AnnotationRenewNow]; ok {
+		t.Errorf("expected %s annotation to be removed, got %v", v1alpha1.AnnotationRenewNow, updated.Annotations)
+	}
+}
+
+// TestCertificateIssuerHasChanged verifies that certificateIssuerHasChanged
+// detects a mismatch between the Issuer name recorded on the existing
+// Secret and crt.Spec.Issuer, but not when the two agree or when the
+// Secret has no recorded Issuer name (e.g. it predates this annotation
+// being introduced).
+func TestCertificateIssuerHasChanged(t *testing.T) {
+	tests := map[string]struct {
+		issuedBy string
+		expected bool
+	}{
+		"issuer unchanged":   {issuedBy: "ca-issuer", expected: false},
+		"issuer changed":     {issuedBy: "other-issuer", expected: true},
+		"no recorded issuer": {issuedBy: "", expected: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := &v1alpha1.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+				Spec:       v1alpha1.CertificateSpec{SecretName: "test-tls", Issuer: "ca-issuer"},
+			}
+
+			secret := &api.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Spec.SecretName},
+			}
+			if test.issuedBy != "" {
+				secret.Annotations = map[string]string{v1alpha1.AnnotationIssuerName: test.issuedBy}
+			}
+
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			if err := indexer.Add(secret); err != nil {
+				t.Fatalf("error adding secret to indexer: %s", err.Error())
+			}
+
+			c := &Controller{secretLister: corelisters.NewSecretLister(indexer)}
+
+			if got := c.certificateIssuerHasChanged(crt, crt.Spec.Issuer); got != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+// TestSyncSignerExpiringSoonCondition verifies that
+// syncSignerExpiringSoonCondition mirrors the Issuer's
+// IssuerConditionSignerExpiringSoon condition onto the Certificate, and
+// clears it again once the Issuer's condition clears.
+func TestSyncSignerExpiringSoonCondition(t *testing.T) {
+	tests := map[string]struct {
+		issuerExpiringSoon bool
+		crtAlreadyFlagged  bool
+		expectCondition    bool
+	}{
+		"issuer expiring soon flags the certificate":            {issuerExpiringSoon: true, crtAlreadyFlagged: false, expectCondition: true},
+		"issuer already flagged stays flagged":                  {issuerExpiringSoon: true, crtAlreadyFlagged: true, expectCondition: true},
+		"issuer not expiring soon leaves certificate clear":     {issuerExpiringSoon: false, crtAlreadyFlagged: false, expectCondition: false},
+		"issuer no longer expiring soon clears the certificate": {issuerExpiringSoon: false, crtAlreadyFlagged: true, expectCondition: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmClient := fake.NewSimpleClientset()
+			crt := &v1alpha1.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+				Spec:       v1alpha1.CertificateSpec{SecretName: "test-tls", Issuer: "ca-issuer"},
+			}
+			if test.crtAlreadyFlagged {
+				crt.UpdateStatusCondition(v1alpha1.CertificateConditionSignerExpiringSoon, v1alpha1.ConditionTrue, "SignerExpiringSoon", "previously flagged")
+			}
+
+			created, err := cmClient.CertmanagerV1alpha1().Certificates(crt.Namespace).Create(crt)
+			if err != nil {
+				t.Fatalf("error creating test certificate: %s", err.Error())
+			}
+
+			issuerObj := &v1alpha1.Issuer{ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "ca-issuer"}}
+			if test.issuerExpiringSoon {
+				issuerObj.UpdateStatusCondition(v1alpha1.IssuerConditionSignerExpiringSoon, v1alpha1.ConditionTrue, "SignerExpiringSoon", "expiring soon")
+			}
+
+			c := &Controller{cmClient: cmClient, recorder: record.NewFakeRecorder(10)}
+
+			if err := c.syncSignerExpiringSoonCondition(created, issuerObj); err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			updated, err := cmClient.CertmanagerV1alpha1().Certificates(crt.Namespace).Get(crt.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("error fetching updated certificate: %s", err.Error())
+			}
+
+			got := updated.HasCondition(v1alpha1.CertificateCondition{Type: v1alpha1.CertificateConditionSignerExpiringSoon, Status: v1alpha1.ConditionTrue})
+			if got != test.expectCondition {
+				t.Errorf("expected SignerExpiringSoon=%v, got conditions %v", test.expectCondition, updated.Status.Conditions)
+			}
+		})
+	}
+}
+
+// TestDefaultIssuerName verifies that defaultIssuerName reads the
+// AnnotationDefaultIssuer annotation off the Certificate's namespace, and
+// returns an error if the namespace doesn't exist or doesn't carry it.
+func TestDefaultIssuerName(t *testing.T) {
+	withAnnotation := &api.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "with-default",
+			Annotations: map[string]string{v1alpha1.AnnotationDefaultIssuer: "ca-issuer"},
+		},
+	}
+	withoutAnnotation := &api.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "without-default"},
+	}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if err := indexer.Add(withAnnotation); err != nil {
+		t.Fatalf("error adding namespace to indexer: %s", err.Error())
+	}
+	if err := indexer.Add(withoutAnnotation); err != nil {
+		t.Fatalf("error adding namespace to indexer: %s", err.Error())
+	}
+
+	c := &Controller{namespaceLister: corelisters.NewNamespaceLister(indexer)}
+
+	issuerName, err := c.defaultIssuerName("with-default")
+	if err != nil {
+		t.Errorf("expected no error for a namespace with the annotation, got: %s", err.Error())
+	}
+	if issuerName != "ca-issuer" {
+		t.Errorf("expected default issuer name %q, got %q", "ca-issuer", issuerName)
+	}
+
+	if _, err := c.defaultIssuerName("without-default"); err == nil {
+		t.Errorf("expected an error for a namespace without the annotation")
+	}
+
+	if _, err := c.defaultIssuerName("does-not-exist"); err == nil {
+		t.Errorf("expected an error for a namespace that does not exist")
+	}
+}
+
+func generateTestKeyAndCert(t *testing.T, domains []string, signer *rsa.PrivateKey, signerCert *x509.Certificate) (*rsa.PrivateKey, []byte) {
+	key, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatalf("error generating private key: %s", err.Error())
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: domains[0]},
+		DNSNames:     domains,
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, template, signerCert, &key.PublicKey, signer)
+	if err != nil {
+		t.Fatalf("error creating test certificate: %s", err.Error())
+	}
+
+	return key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
+}
+
+func generateTestCA(t *testing.T, commonName string) (*rsa.PrivateKey, []byte) {
+	key, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatalf("error generating private key: %s", err.Error())
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("error creating test CA certificate: %s", err.Error())
+	}
+
+	return key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
+}
+
+// TestValidateIssuedCertificate verifies that validateIssuedCertificate
+// catches a certificate missing a requested domain, email address or
+// codeSigning extended key usage, one paired with the wrong private key,
+// and one not actually signed by the accompanying CA certificate, while
+// accepting a conforming certificate.
+func TestValidateIssuedCertificate(t *testing.T) {
+	crt := &v1alpha1.Certificate{Spec: v1alpha1.CertificateSpec{Domains: []string{"example.com"}}}
+
+	caKey, caCertPEM := generateTestCA(t, "ca.example.com")
+	caCert, err := pki.DecodeX509CertificateBytes(caCertPEM)
+	if err != nil {
+		t.Fatalf("error decoding test CA certificate: %s", err.Error())
+	}
+
+	key, certPEM := generateTestKeyAndCert(t, []string{"example.com"}, caKey, caCert)
+	keyPEM := pki.EncodePKCS1PrivateKey(key)
+
+	if err := validateIssuedCertificate(crt, keyPEM, certPEM, caCertPEM); err != nil {
+		t.Errorf("expected a conforming certificate to validate, got: %s", err.Error())
+	}
+
+	_, wrongDomainCertPEM := generateTestKeyAndCert(t, []string{"other.example.com"}, caKey, caCert)
+	if err := validateIssuedCertificate(crt, keyPEM, wrongDomainCertPEM, caCertPEM); err == nil {
+		t.Errorf("expected a certificate missing the requested domain to fail validation")
+	}
+
+	otherKey, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatalf("error generating private key: %s", err.Error())
+	}
+	if err := validateIssuedCertificate(crt, pki.EncodePKCS1PrivateKey(otherKey), certPEM, caCertPEM); err == nil {
+		t.Errorf("expected a certificate paired with the wrong key to fail validation")
+	}
+
+	_, unrelatedCACertPEM := generateTestCA(t, "unrelated-ca.example.com")
+	if err := validateIssuedCertificate(crt, keyPEM, certPEM, unrelatedCACertPEM); err == nil {
+		t.Errorf("expected a certificate not signed by the accompanying CA to fail validation")
+	}
+
+	emailCrt := &v1alpha1.Certificate{Spec: v1alpha1.CertificateSpec{Domains: []string{"example.com"}, EmailAddresses: []string{"user@example.com"}}}
+	if err := validateIssuedCertificate(emailCrt, keyPEM, certPEM, caCertPEM); err == nil {
+		t.Errorf("expected a certificate missing the requested email address to fail validation")
+	}
+
+	codeSigningCrt := &v1alpha1.Certificate{Spec: v1alpha1.CertificateSpec{Domains: []string{"example.com"}, CodeSigning: true}}
+	if err := validateIssuedCertificate(codeSigningCrt, keyPEM, certPEM, caCertPEM); err == nil {
+		t.Errorf("expected a certificate missing the requested codeSigning extended key usage to fail validation")
+	}
+
+	commonNameOnlyCrt := &v1alpha1.Certificate{Spec: v1alpha1.CertificateSpec{Domains: []string{"example.com"}, CommonNameOnly: true}}
+	cnOnlyKey, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatalf("error generating private key: %s", err.Error())
+	}
+	cnOnlyTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	cnOnlyDERBytes, err := x509.CreateCertificate(rand.Reader, cnOnlyTemplate, caCert, &cnOnlyKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("error creating test certificate: %s", err.Error())
+	}
+	cnOnlyCertPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cnOnlyDERBytes})
+	cnOnlyKeyPEM := pki.EncodePKCS1PrivateKey(cnOnlyKey)
+	if err := validateIssuedCertificate(commonNameOnlyCrt, cnOnlyKeyPEM, cnOnlyCertPEM, caCertPEM); err != nil {
+		t.Errorf("expected a commonNameOnly certificate with no SANs to validate, got: %s", err.Error())
+	}
+}
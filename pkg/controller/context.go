@@ -1,11 +1,15 @@
 package controller
 
 import (
+	"time"
+
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
 
+	"github.com/jetstack-experimental/cert-manager/pkg/auditlog"
 	clientset "github.com/jetstack-experimental/cert-manager/pkg/client/clientset"
 	"github.com/jetstack-experimental/cert-manager/pkg/issuer"
+	"github.com/jetstack-experimental/cert-manager/pkg/rollout"
 	"github.com/jetstack-experimental/cert-manager/pkg/util/kube"
 )
 
@@ -28,7 +32,41 @@ type Context struct {
 	// instances
 	IssuerFactory issuer.Factory
 
+	// AuditLog, if non-nil, is written a tamper-evident record of every
+	// certificate issued or renewed.
+	AuditLog *auditlog.Logger
+
+	// RolloutTrigger, if non-nil, is used to trigger a rolling restart of
+	// Deployments/StatefulSets referencing a Certificate's target Secret
+	// whenever that certificate is renewed.
+	RolloutTrigger *rollout.Trigger
+
+	// RenewBeforeJitter is the upper bound of a random per-Certificate
+	// offset added to the renewal window, used by the certificates
+	// controller to spread out renewals that would otherwise all become
+	// due at the same instant.
+	RenewBeforeJitter time.Duration
+
+	// RenewalClockSkew is an additional fixed margin added to every
+	// Certificate's renewal window, on top of RenewBeforeJitter, to
+	// compensate for clock drift between this controller and whatever
+	// issued the certificates it manages.
+	RenewalClockSkew time.Duration
+
 	// Namespace is a namespace to operate within. This should be used when
 	// constructing SharedIndexInformers for the informer factory.
 	Namespace string
+
+	// StrictFIPS, if true, causes the certificates controller to reject
+	// Certificate specs that request a key size that is not FIPS 140-2
+	// approved, rather than issuing a non-compliant certificate.
+	StrictFIPS bool
+
+	// JanitorResourceTTL is how long a leftover ACME HTTP-01 solver
+	// Service/Ingress/Job (recognisable by their "certmanager.k8s.io/managed"
+	// label) is kept around after creation before the janitor controller
+	// considers it stale and deletes it. Solver resources are normally
+	// cleaned up as soon as their challenge completes; this only catches
+	// ones orphaned by a crash or a deleted Certificate.
+	JanitorResourceTTL time.Duration
 }
@@ -1,22 +1,46 @@
 package issuers
 
 import (
+	"context"
+	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/golang/glog"
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/errors"
 
 	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/issuer"
 )
 
 const (
 	errorInitIssuer = "ErrInitIssuer"
 
 	messageErrorInitIssuer = "Error initializing issuer: "
+
+	// defaultSyntheticProbeInterval is how often a synthetic probe
+	// issuance is performed if IssuerSpec.SyntheticProbe.IntervalSeconds is
+	// not set.
+	defaultSyntheticProbeInterval = time.Hour
+
+	// syntheticProbeSecretName is the name given to the throwaway Secret
+	// reference passed to the issuer for a synthetic probe issuance. The
+	// issued certificate is never persisted, so this never needs to
+	// resolve to a real Secret.
+	syntheticProbeSecretName = "cert-manager-synthetic-probe"
+
+	// syncTimeout bounds how long a single Sync call, and the issuer calls
+	// it makes, are allowed to run for, so that a hung ACME server or
+	// Kubernetes API call can't wedge a worker indefinitely.
+	syncTimeout = time.Minute * 10
 )
 
 func (c *Controller) Sync(iss *v1alpha1.Issuer) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), syncTimeout)
+	defer cancel()
+
 	i, err := c.issuerFactory.IssuerFor(iss)
 
 	if err != nil {
@@ -24,7 +48,7 @@ func (c *Controller) Sync(iss *v1alpha1.Issuer) (err error) {
 	}
 
 	var status v1alpha1.IssuerStatus
-	status, err = i.Setup()
+	status, err = i.Setup(ctx)
 
 	defer func() {
 		if saveErr := c.updateIssuerStatus(iss, status); saveErr != nil {
@@ -43,18 +67,68 @@ func (c *Controller) Sync(iss *v1alpha1.Issuer) (err error) {
 		return err
 	}
 
+	if iss.Spec.SyntheticProbe != nil && syntheticProbeDue(iss.Spec.SyntheticProbe, status.SyntheticProbe) {
+		status.SyntheticProbe = runSyntheticProbe(ctx, i, iss)
+	}
+
 	return nil
 }
 
+// syntheticProbeDue reports whether enough time has passed since the last
+// synthetic probe for cfg that another one should be attempted now.
+func syntheticProbeDue(cfg *v1alpha1.SyntheticProbeConfig, last *v1alpha1.SyntheticProbeStatus) bool {
+	if last == nil {
+		return true
+	}
+
+	interval := defaultSyntheticProbeInterval
+	if cfg.IntervalSeconds > 0 {
+		interval = time.Duration(cfg.IntervalSeconds) * time.Second
+	}
+
+	return time.Since(last.LastProbeTime.Time) >= interval
+}
+
+// runSyntheticProbe performs a single synthetic canary issuance against i,
+// exercising the same Issue path used for real Certificates, and returns
+// the resulting SyntheticProbeStatus. The synthetic Certificate used as
+// input is never persisted; only the outcome of the attempt is recorded.
+func runSyntheticProbe(ctx context.Context, i issuer.Interface, iss *v1alpha1.Issuer) *v1alpha1.SyntheticProbeStatus {
+	crt := &v1alpha1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-synthetic-probe", iss.Name),
+			Namespace: iss.Namespace,
+		},
+		Spec: v1alpha1.CertificateSpec{
+			Domains:    []string{iss.Spec.SyntheticProbe.DNSName},
+			SecretName: syntheticProbeSecretName,
+			Issuer:     iss.Name,
+		},
+	}
+
+	start := time.Now()
+	_, _, _, _, err := i.Issue(ctx, crt)
+	duration := time.Since(start)
+
+	status := &v1alpha1.SyntheticProbeStatus{
+		LastProbeTime:   metav1.Now(),
+		Succeeded:       err == nil,
+		DurationSeconds: duration.Seconds(),
+	}
+	if err != nil {
+		status.Error = err.Error()
+		glog.Errorf("synthetic probe issuance failed for issuer %s/%s: %s", iss.Namespace, iss.Name, err.Error())
+	}
+
+	return status
+}
+
 func (c *Controller) updateIssuerStatus(iss *v1alpha1.Issuer, status v1alpha1.IssuerStatus) error {
 	updateIssuer := iss.DeepCopy()
 	updateIssuer.Status = status
 	if reflect.DeepEqual(iss.Status, updateIssuer.Status) {
 		return nil
 	}
-	// TODO: replace Update call with UpdateStatus. This requires a custom API
-	// server with the /status subresource enabled and/or subresource support
-	// for CRDs (https://github.com/kubernetes/kubernetes/issues/38113)
-	_, err := c.cmClient.CertmanagerV1alpha1().Issuers(iss.Namespace).Update(updateIssuer)
+	_, err := c.cmClient.CertmanagerV1alpha1().Issuers(iss.Namespace).UpdateStatus(updateIssuer)
 	return err
 }
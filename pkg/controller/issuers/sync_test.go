@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"runtime/debug"
 	"testing"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -13,6 +14,48 @@ import (
 	"github.com/jetstack-experimental/cert-manager/pkg/client/clientset/fake"
 )
 
+func TestSyntheticProbeDue(t *testing.T) {
+	tests := map[string]struct {
+		cfg  *v1alpha1.SyntheticProbeConfig
+		last *v1alpha1.SyntheticProbeStatus
+		due  bool
+	}{
+		"never probed": {
+			cfg:  &v1alpha1.SyntheticProbeConfig{DNSName: "example.com"},
+			last: nil,
+			due:  true,
+		},
+		"probed recently, within default interval": {
+			cfg:  &v1alpha1.SyntheticProbeConfig{DNSName: "example.com"},
+			last: &v1alpha1.SyntheticProbeStatus{LastProbeTime: metav1.NewTime(time.Now().Add(-time.Minute))},
+			due:  false,
+		},
+		"probed over an hour ago, within default interval": {
+			cfg:  &v1alpha1.SyntheticProbeConfig{DNSName: "example.com"},
+			last: &v1alpha1.SyntheticProbeStatus{LastProbeTime: metav1.NewTime(time.Now().Add(-2 * time.Hour))},
+			due:  true,
+		},
+		"probed recently, within custom interval": {
+			cfg:  &v1alpha1.SyntheticProbeConfig{DNSName: "example.com", IntervalSeconds: 600},
+			last: &v1alpha1.SyntheticProbeStatus{LastProbeTime: metav1.NewTime(time.Now().Add(-time.Minute))},
+			due:  false,
+		},
+		"probed past custom interval": {
+			cfg:  &v1alpha1.SyntheticProbeConfig{DNSName: "example.com", IntervalSeconds: 60},
+			last: &v1alpha1.SyntheticProbeStatus{LastProbeTime: metav1.NewTime(time.Now().Add(-time.Minute))},
+			due:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := syntheticProbeDue(test.cfg, test.last); got != test.due {
+				t.Errorf("expected %v, got %v", test.due, got)
+			}
+		})
+	}
+}
+
 func newFakeIssuerWithStatus(name string, status v1alpha1.IssuerStatus) *v1alpha1.Issuer {
 	return &v1alpha1.Issuer{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,151 @@
+// Package janitor implements a control loop that prunes leftover ACME
+// HTTP-01 solver resources (Services, Ingresses, Jobs and
+// PodDisruptionBudgets, recognisable by the certmanager.k8s.io/managed
+// label). Those resources are normally
+// deleted as soon as their challenge completes (see
+// pkg/issuer/acme/http.Solver.CleanUp); this controller only catches ones
+// orphaned by a controller crash mid-challenge or by the deletion of the
+// Certificate they belonged to, which would otherwise accumulate forever.
+package janitor
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+
+	controllerpkg "github.com/jetstack-experimental/cert-manager/pkg/controller"
+	"github.com/jetstack-experimental/cert-manager/pkg/issuer/acme/http"
+)
+
+// sweepInterval is how often the janitor scans for stale solver resources.
+const sweepInterval = time.Minute * 5
+
+// Controller periodically deletes ACME HTTP-01 solver Services, Ingresses,
+// Jobs and PodDisruptionBudgets that have outlived resourceTTL.
+type Controller struct {
+	client kubernetes.Interface
+	// namespace restricts the sweep to a single namespace, matching the
+	// --namespace flag; if empty, all namespaces are swept.
+	namespace string
+	// resourceTTL is how old a managed resource must be before it is
+	// considered stale and deleted.
+	resourceTTL time.Duration
+}
+
+// New returns a new janitor Controller.
+func New(client kubernetes.Interface, namespace string, resourceTTL time.Duration) *Controller {
+	return &Controller{client: client, namespace: namespace, resourceTTL: resourceTTL}
+}
+
+func (c *Controller) Run(workers int, stopCh <-chan struct{}) error {
+	glog.V(4).Infof("Starting %s control loop", ControllerName)
+	wait.Until(c.sweep, sweepInterval, stopCh)
+	return nil
+}
+
+var managedListOptions = metav1.ListOptions{
+	LabelSelector: fmt.Sprintf("%s=%s", http.ManagedLabelKey, http.ManagedLabelValue),
+}
+
+func (c *Controller) sweep() {
+	if err := c.sweepServices(); err != nil {
+		glog.Errorf("error sweeping stale solver services: %s", err.Error())
+	}
+	if err := c.sweepIngresses(); err != nil {
+		glog.Errorf("error sweeping stale solver ingresses: %s", err.Error())
+	}
+	if err := c.sweepJobs(); err != nil {
+		glog.Errorf("error sweeping stale solver jobs: %s", err.Error())
+	}
+	if err := c.sweepPodDisruptionBudgets(); err != nil {
+		glog.Errorf("error sweeping stale solver pod disruption budgets: %s", err.Error())
+	}
+}
+
+func (c *Controller) sweepServices() error {
+	svcs, err := c.client.CoreV1().Services(c.namespace).List(managedListOptions)
+	if err != nil {
+		return fmt.Errorf("error listing services: %s", err.Error())
+	}
+	for _, svc := range svcs.Items {
+		if !c.isStale(svc.CreationTimestamp) {
+			continue
+		}
+		if err := c.client.CoreV1().Services(svc.Namespace).Delete(svc.Name, nil); err != nil {
+			glog.Errorf("error deleting stale solver service %s/%s: %s", svc.Namespace, svc.Name, err.Error())
+			continue
+		}
+		glog.Infof("deleted stale solver service %s/%s", svc.Namespace, svc.Name)
+	}
+	return nil
+}
+
+func (c *Controller) sweepIngresses() error {
+	ings, err := c.client.ExtensionsV1beta1().Ingresses(c.namespace).List(managedListOptions)
+	if err != nil {
+		return fmt.Errorf("error listing ingresses: %s", err.Error())
+	}
+	for _, ing := range ings.Items {
+		if !c.isStale(ing.CreationTimestamp) {
+			continue
+		}
+		if err := c.client.ExtensionsV1beta1().Ingresses(ing.Namespace).Delete(ing.Name, nil); err != nil {
+			glog.Errorf("error deleting stale solver ingress %s/%s: %s", ing.Namespace, ing.Name, err.Error())
+			continue
+		}
+		glog.Infof("deleted stale solver ingress %s/%s", ing.Namespace, ing.Name)
+	}
+	return nil
+}
+
+func (c *Controller) sweepJobs() error {
+	jobs, err := c.client.BatchV1().Jobs(c.namespace).List(managedListOptions)
+	if err != nil {
+		return fmt.Errorf("error listing jobs: %s", err.Error())
+	}
+	for _, job := range jobs.Items {
+		if !c.isStale(job.CreationTimestamp) {
+			continue
+		}
+		if err := c.client.BatchV1().Jobs(job.Namespace).Delete(job.Name, nil); err != nil {
+			glog.Errorf("error deleting stale solver job %s/%s: %s", job.Namespace, job.Name, err.Error())
+			continue
+		}
+		glog.Infof("deleted stale solver job %s/%s", job.Namespace, job.Name)
+	}
+	return nil
+}
+
+func (c *Controller) sweepPodDisruptionBudgets() error {
+	pdbs, err := c.client.PolicyV1beta1().PodDisruptionBudgets(c.namespace).List(managedListOptions)
+	if err != nil {
+		return fmt.Errorf("error listing pod disruption budgets: %s", err.Error())
+	}
+	for _, pdb := range pdbs.Items {
+		if !c.isStale(pdb.CreationTimestamp) {
+			continue
+		}
+		if err := c.client.PolicyV1beta1().PodDisruptionBudgets(pdb.Namespace).Delete(pdb.Name, nil); err != nil {
+			glog.Errorf("error deleting stale solver pod disruption budget %s/%s: %s", pdb.Namespace, pdb.Name, err.Error())
+			continue
+		}
+		glog.Infof("deleted stale solver pod disruption budget %s/%s", pdb.Namespace, pdb.Name)
+	}
+	return nil
+}
+
+func (c *Controller) isStale(created metav1.Time) bool {
+	return time.Since(created.Time) > c.resourceTTL
+}
+
+const ControllerName = "janitor"
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.Context) controllerpkg.Interface {
+		return New(ctx.Client, ctx.Namespace, ctx.JanitorResourceTTL).Run
+	})
+}
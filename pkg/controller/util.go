@@ -2,6 +2,7 @@ package controller
 
 import (
 	"reflect"
+	"sync"
 
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/cache"
@@ -12,6 +13,32 @@ var (
 	KeyFunc = cache.DeletionHandlingMetaNamespaceKeyFunc
 )
 
+var (
+	queuesMu sync.Mutex
+	queues   = make(map[string]workqueue.Interface)
+)
+
+// RegisterQueue records a controller's workqueue under name, so that its
+// current depth can be inspected via the debug/runtime endpoints. It is
+// expected to be called once per controller, when its queue is created.
+func RegisterQueue(name string, queue workqueue.Interface) {
+	queuesMu.Lock()
+	defer queuesMu.Unlock()
+	queues[name] = queue
+}
+
+// QueueDepths returns the current length of every registered workqueue,
+// keyed by the name it was registered under.
+func QueueDepths() map[string]int {
+	queuesMu.Lock()
+	defer queuesMu.Unlock()
+	depths := make(map[string]int, len(queues))
+	for name, q := range queues {
+		depths[name] = q.Len()
+	}
+	return depths
+}
+
 // QueuingEventHandler is an implementation of cache.ResourceEventHandler that
 // simply queues objects that are added/updated/deleted.
 type QueuingEventHandler struct {
@@ -0,0 +1,67 @@
+// Package crds holds the CustomResourceDefinitions cert-manager requires,
+// as Go values that can be installed or verified against a cluster in code
+// (see pkg/util/kube.EnsureCRD and VerifyCRD), rather than only as the
+// hand-applied manifest at docs/crd.yaml. The vendored apiextensions API
+// available here predates additionalPrinterColumns, categories and
+// structural schema validation, so those are only expressed in
+// docs/crd.yaml; the CRDs below are otherwise equivalent to it and freely
+// interchangeable with it on a cluster.
+package crds
+
+import (
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Group is the API group that every CustomResourceDefinition in this
+// package belongs to.
+const Group = "certmanager.k8s.io"
+
+// Version is the API version that every CustomResourceDefinition in this
+// package belongs to.
+const Version = "v1alpha1"
+
+// All returns the CustomResourceDefinitions cert-manager requires.
+func All() []*apiextensionsv1beta1.CustomResourceDefinition {
+	return []*apiextensionsv1beta1.CustomResourceDefinition{
+		Certificate(),
+		Issuer(),
+	}
+}
+
+// Certificate returns the CustomResourceDefinition for the Certificate
+// resource.
+func Certificate() *apiextensionsv1beta1.CustomResourceDefinition {
+	return &apiextensionsv1beta1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "certificates." + Group,
+		},
+		Spec: apiextensionsv1beta1.CustomResourceDefinitionSpec{
+			Group:   Group,
+			Version: Version,
+			Names: apiextensionsv1beta1.CustomResourceDefinitionNames{
+				Kind:   "Certificate",
+				Plural: "certificates",
+			},
+			Scope: apiextensionsv1beta1.NamespaceScoped,
+		},
+	}
+}
+
+// Issuer returns the CustomResourceDefinition for the Issuer resource.
+func Issuer() *apiextensionsv1beta1.CustomResourceDefinition {
+	return &apiextensionsv1beta1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "issuers." + Group,
+		},
+		Spec: apiextensionsv1beta1.CustomResourceDefinitionSpec{
+			Group:   Group,
+			Version: Version,
+			Names: apiextensionsv1beta1.CustomResourceDefinitionNames{
+				Kind:   "Issuer",
+				Plural: "issuers",
+			},
+			Scope: apiextensionsv1beta1.NamespaceScoped,
+		},
+	}
+}
@@ -0,0 +1,170 @@
+// Package ctlog implements the client side of the RFC 6962 Certificate
+// Transparency submission protocol, so a CA issuer can log the
+// precertificates it signs to a log (public or internal) and embed the
+// returned SCT in the final certificate.
+package ctlog
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// PoisonExtensionOID is the OID of the CT "poison" extension (RFC 6962
+// section 3.1), marked critical on a precertificate so that any client
+// that doesn't understand it refuses to treat the precertificate as
+// valid.
+var PoisonExtensionOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 3}
+
+// SCTListExtensionOID is the OID of the X.509v3 extension a final
+// certificate carries its embedded SCTs in (RFC 6962 section 3.3).
+var SCTListExtensionOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}
+
+// PoisonExtension returns the critical CT poison extension that must be
+// present on a precertificate submitted to a log, and absent from the
+// final certificate.
+func PoisonExtension() pkix.Extension {
+	return pkix.Extension{
+		Id:       PoisonExtensionOID,
+		Critical: true,
+		// ASN.1 NULL, the value RFC 6962 mandates for this extension.
+		Value: []byte{0x05, 0x00},
+	}
+}
+
+// Client submits precertificates to a single RFC 6962 CT log.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// New returns a Client that submits to the log at baseURL (e.g.
+// "https://ct.example.com/log"), using httpClient to make requests. If
+// httpClient is nil, http.DefaultClient is used.
+func New(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{baseURL: baseURL, httpClient: httpClient}
+}
+
+type addChainRequest struct {
+	Chain []string `json:"chain"`
+}
+
+type addChainResponse struct {
+	SCTVersion int    `json:"sct_version"`
+	ID         string `json:"id"`
+	Timestamp  uint64 `json:"timestamp"`
+	Extensions string `json:"extensions"`
+	Signature  string `json:"signature"`
+}
+
+// SubmitPreChain submits a precertificate and its issuing chain (both DER
+// encoded, precert first) to the log's add-pre-chain endpoint, returning
+// the raw, TLS-encoded SignedCertificateTimestamp the log returned.
+func (c *Client) SubmitPreChain(chain ...[]byte) ([]byte, error) {
+	return c.submit("add-pre-chain", chain)
+}
+
+// SubmitChain is like SubmitPreChain, but submits a final certificate via
+// the log's add-chain endpoint. Not all logs accept final certificates,
+// but some internal logs may prefer to avoid the precertificate dance.
+func (c *Client) SubmitChain(chain ...[]byte) ([]byte, error) {
+	return c.submit("add-chain", chain)
+}
+
+func (c *Client) submit(endpoint string, chain [][]byte) ([]byte, error) {
+	req := addChainRequest{}
+	for _, der := range chain {
+		req.Chain = append(req.Chain, base64.StdEncoding.EncodeToString(der))
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling ct log request: %s", err.Error())
+	}
+
+	url := c.baseURL + "/ct/v1/" + endpoint
+	resp, err := c.httpClient.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error submitting to ct log: %s", err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ct log %s returned status %s", url, resp.Status)
+	}
+
+	var addResp addChainResponse
+	if err := json.NewDecoder(resp.Body).Decode(&addResp); err != nil {
+		return nil, fmt.Errorf("error decoding ct log response: %s", err.Error())
+	}
+
+	return buildSCT(&addResp)
+}
+
+// buildSCT re-assembles the TLS-encoded SignedCertificateTimestamp (RFC
+// 6962 section 3.2) that the log's JSON response describes.
+func buildSCT(resp *addChainResponse) ([]byte, error) {
+	id, err := base64.StdEncoding.DecodeString(resp.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding log id: %s", err.Error())
+	}
+	if len(id) != 32 {
+		return nil, fmt.Errorf("log id must be 32 bytes, got %d", len(id))
+	}
+
+	extensions, err := base64.StdEncoding.DecodeString(resp.Extensions)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding extensions: %s", err.Error())
+	}
+
+	// The signature field is already the TLS-encoded digitally-signed
+	// struct (hash algorithm, signature algorithm, length, signature)
+	// that RFC 6962 embeds verbatim in the SCT.
+	signature, err := base64.StdEncoding.DecodeString(resp.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding signature: %s", err.Error())
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte(byte(resp.SCTVersion))
+	buf.Write(id)
+	binary.Write(&buf, binary.BigEndian, resp.Timestamp)
+	binary.Write(&buf, binary.BigEndian, uint16(len(extensions)))
+	buf.Write(extensions)
+	buf.Write(signature)
+
+	return buf.Bytes(), nil
+}
+
+// SCTListExtension builds the X.509v3 extension a final certificate
+// embeds its SCTs in: a SignedCertificateTimestampList (RFC 6962 section
+// 3.3), TLS-encoded and then wrapped in a DER OCTET STRING.
+func SCTListExtension(scts ...[]byte) (pkix.Extension, error) {
+	var list bytes.Buffer
+	for _, sct := range scts {
+		if len(sct) > 0xffff {
+			return pkix.Extension{}, fmt.Errorf("sct is too large to encode: %d bytes", len(sct))
+		}
+		binary.Write(&list, binary.BigEndian, uint16(len(sct)))
+		list.Write(sct)
+	}
+
+	var wrapped bytes.Buffer
+	binary.Write(&wrapped, binary.BigEndian, uint16(list.Len()))
+	wrapped.Write(list.Bytes())
+
+	value, err := asn1.Marshal(wrapped.Bytes())
+	if err != nil {
+		return pkix.Extension{}, fmt.Errorf("error encoding sct list extension: %s", err.Error())
+	}
+
+	return pkix.Extension{Id: SCTListExtensionOID, Value: value}, nil
+}
@@ -0,0 +1,111 @@
+package ctlog
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSubmitPreChain(t *testing.T) {
+	logID := make([]byte, 32)
+	rand.Read(logID)
+	signature := []byte{0x04, 0x03, 0x00, 0x02, 0xab, 0xcd}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ct/v1/add-pre-chain" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		var req addChainRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("error decoding request: %s", err)
+		}
+		if len(req.Chain) != 2 {
+			t.Fatalf("expected a 2 element chain, got %d", len(req.Chain))
+		}
+
+		resp := addChainResponse{
+			SCTVersion: 0,
+			ID:         base64.StdEncoding.EncodeToString(logID),
+			Timestamp:  1234567890,
+			Extensions: "",
+			Signature:  base64.StdEncoding.EncodeToString(signature),
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	client := New(srv.URL, nil)
+	sct, err := client.SubmitPreChain([]byte("precert"), []byte("issuer"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if sct[0] != 0 {
+		t.Errorf("expected sct version 0, got %d", sct[0])
+	}
+	if !bytes.Equal(sct[1:33], logID) {
+		t.Errorf("unexpected log id in sct")
+	}
+	gotTimestamp := binary.BigEndian.Uint64(sct[33:41])
+	if gotTimestamp != 1234567890 {
+		t.Errorf("expected timestamp 1234567890, got %d", gotTimestamp)
+	}
+	// extensions length (2 bytes) is zero, then the signature bytes follow directly.
+	if !bytes.Equal(sct[43:], signature) {
+		t.Errorf("expected trailing signature to be preserved verbatim")
+	}
+}
+
+func TestSubmitPreChainRejectsErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "log unavailable", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	client := New(srv.URL, nil)
+	if _, err := client.SubmitPreChain([]byte("precert")); err == nil {
+		t.Errorf("expected error when log returns a non-200 status")
+	}
+}
+
+func TestSCTListExtension(t *testing.T) {
+	sct1 := []byte("first-sct")
+	sct2 := []byte("second-sct")
+
+	ext, err := SCTListExtension(sct1, sct2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !ext.Id.Equal(SCTListExtensionOID) {
+		t.Errorf("unexpected extension OID: %v", ext.Id)
+	}
+
+	var octets []byte
+	if _, err := asn1.Unmarshal(ext.Value, &octets); err != nil {
+		t.Fatalf("error unmarshalling extension value as octet string: %s", err)
+	}
+
+	listLen := binary.BigEndian.Uint16(octets[0:2])
+	if int(listLen) != len(octets)-2 {
+		t.Fatalf("outer length prefix %d doesn't match remaining bytes %d", listLen, len(octets)-2)
+	}
+
+	rest := octets[2:]
+	var got [][]byte
+	for len(rest) > 0 {
+		l := binary.BigEndian.Uint16(rest[0:2])
+		got = append(got, rest[2:2+l])
+		rest = rest[2+l:]
+	}
+
+	if len(got) != 2 || !bytes.Equal(got[0], sct1) || !bytes.Equal(got[1], sct2) {
+		t.Errorf("unexpected round-tripped scts: %v", got)
+	}
+}
@@ -0,0 +1,119 @@
+package acme
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/crypto/acme"
+	"k8s.io/api/core/v1"
+
+	"github.com/golang/glog"
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
+
+const (
+	errorAccountRolloverFailed     = "ErrRolloverACMEAccount"
+	errorAccountDeactivationFailed = "ErrDeactivateACMEAccount"
+
+	successAccountRolledOver     = "ACMEAccountRolledOver"
+	warningAccountNotDeactivated = "ACMEAccountNotDeactivated"
+
+	messageAccountRolloverFailed = "Failed to roll over ACME account key: "
+	messageAccountRolledOver     = "The ACME account was rolled over onto a newly generated private key"
+	messageAccountNotDeactivated = "Could not deactivate the ACME account: the vendored ACME client does not implement the account deactivation resource. The account's private key Secret should be deleted by hand once it is no longer needed."
+)
+
+// handleAccountRolloverAnnotation checks for AnnotationACMERolloverAccountKey
+// on the Issuer and, if present, rolls the ACME account over onto a newly
+// generated private key by registering a fresh account with the ACME
+// server and replacing the PrivateKey Secret's contents, then clears the
+// annotation so the rollover is not repeated on every subsequent sync.
+//
+// This is not a true RFC 8555 key-change: the vendored ACME client predates
+// the key-change resource, so rather than rotating the key of the existing
+// account, a brand new account is registered with the same contact details
+// and the issuer is switched over to it. Any outstanding authorizations on
+// the old account are left behind, but this achieves the operationally
+// important part of a rollover: a compromised private key is no longer in
+// use.
+func (a *Acme) handleAccountRolloverAnnotation(ctx context.Context) (bool, error) {
+	if a.issuer.Annotations[v1alpha1.AnnotationACMERolloverAccountKey] != "true" {
+		return false, nil
+	}
+
+	if err := a.rolloverAccountKey(ctx); err != nil {
+		s := messageAccountRolloverFailed + err.Error()
+		glog.Info(s)
+		a.recorder.Event(a.issuer, v1.EventTypeWarning, errorAccountRolloverFailed, s)
+		return false, fmt.Errorf(s)
+	}
+
+	glog.Info(messageAccountRolledOver)
+	a.recorder.Event(a.issuer, v1.EventTypeNormal, successAccountRolledOver, messageAccountRolledOver)
+
+	return true, a.clearIssuerAnnotation(v1alpha1.AnnotationACMERolloverAccountKey)
+}
+
+// handleAccountDeactivationAnnotation checks for
+// AnnotationACMEDeactivateAccount on the Issuer and, if present, records
+// that deactivation was requested but cannot be performed, since the
+// vendored ACME client does not implement the account deactivation
+// resource. The annotation is cleared so the warning is not repeated on
+// every subsequent sync.
+func (a *Acme) handleAccountDeactivationAnnotation() error {
+	if a.issuer.Annotations[v1alpha1.AnnotationACMEDeactivateAccount] != "true" {
+		return nil
+	}
+
+	glog.Info(messageAccountNotDeactivated)
+	a.recorder.Event(a.issuer, v1.EventTypeWarning, warningAccountNotDeactivated, messageAccountNotDeactivated)
+
+	return a.clearIssuerAnnotation(v1alpha1.AnnotationACMEDeactivateAccount)
+}
+
+// rolloverAccountKey generates a new ACME account private key, registers a
+// new account with the ACME server using it, and overwrites the issuer's
+// PrivateKey Secret so that subsequent syncs use the new account.
+func (a *Acme) rolloverAccountKey(ctx context.Context) error {
+	newKey, err := a.createAccountPrivateKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	httpClient, err := a.httpClient()
+	if err != nil {
+		return err
+	}
+
+	cl := acme.Client{
+		Key:          newKey,
+		DirectoryURL: a.issuer.Spec.ACME.Server,
+		HTTPClient:   httpClient,
+	}
+
+	acc := &acme.Account{
+		Contact: []string{fmt.Sprintf("mailto:%s", a.issuer.Spec.ACME.Email)},
+	}
+
+	account, err := cl.Register(ctx, acc, acme.AcceptTOS)
+	if err != nil {
+		return fmt.Errorf("error registering new ACME account: %s", err.Error())
+	}
+
+	update := a.issuer.DeepCopy()
+	update.Status.ACMEStatus().URI = account.URI
+	if _, err := a.cmClient.CertmanagerV1alpha1().Issuers(update.Namespace).UpdateStatus(update); err != nil {
+		return fmt.Errorf("error updating issuer status with new account URI: %s", err.Error())
+	}
+
+	return nil
+}
+
+// clearIssuerAnnotation removes the given annotation from the Issuer
+// resource, once the action it requested has been handled.
+func (a *Acme) clearIssuerAnnotation(key string) error {
+	update := a.issuer.DeepCopy()
+	delete(update.Annotations, key)
+	_, err := a.cmClient.CertmanagerV1alpha1().Issuers(update.Namespace).Update(update)
+	return err
+}
@@ -0,0 +1,194 @@
+// Package akamai implements a DNS provider for solving the DNS-01
+// challenge using Akamai EdgeDNS.
+package akamai
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+// that uses Akamai's EdgeDNS API (authenticated via the EdgeGrid signing
+// scheme) to manage TXT records for a DNS-01 challenge.
+type DNSProvider struct {
+	host         string
+	clientToken  string
+	clientSecret string
+	accessToken  string
+}
+
+// NewDNSProviderCredentials uses the supplied EdgeGrid credentials to
+// return a DNSProvider instance configured for Akamai EdgeDNS. host is the
+// EdgeGrid API hostname, e.g. "akab-xxxx.luna.akamaiapis.net".
+func NewDNSProviderCredentials(host, clientToken, clientSecret, accessToken string) (*DNSProvider, error) {
+	if host == "" || clientToken == "" || clientSecret == "" || accessToken == "" {
+		return nil, fmt.Errorf("akamai credentials missing")
+	}
+
+	return &DNSProvider{
+		host:         host,
+		clientToken:  clientToken,
+		clientSecret: clientSecret,
+		accessToken:  accessToken,
+	}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS
+// propagation.
+func (c *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return 180 * time.Second, 5 * time.Second
+}
+
+// Present creates a TXT record to fulfil the dns-01 challenge
+func (c *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value, ttl := util.DNS01Record(domain, keyAuth)
+
+	zone, err := c.getHostedZone(fqdn)
+	if err != nil {
+		return err
+	}
+
+	recordName := util.UnFqdn(fqdn)
+
+	rrset := akamaiRRSet{
+		Name:  recordName,
+		Type:  "TXT",
+		TTL:   ttl,
+		RData: []string{strconv.Quote(value)},
+	}
+
+	uri := fmt.Sprintf("/config-dns/v2/zones/%s/names/%s/types/TXT", zone, recordName)
+	_, err = c.makeRequest("PUT", uri, rrset)
+	return err
+}
+
+// CleanUp removes the TXT record matching the specified parameters
+func (c *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _, _ := util.DNS01Record(domain, keyAuth)
+
+	zone, err := c.getHostedZone(fqdn)
+	if err != nil {
+		return err
+	}
+
+	recordName := util.UnFqdn(fqdn)
+	uri := fmt.Sprintf("/config-dns/v2/zones/%s/names/%s/types/TXT", zone, recordName)
+	_, err = c.makeRequest("DELETE", uri, nil)
+	return err
+}
+
+func (c *DNSProvider) getHostedZone(fqdn string) (string, error) {
+	authZone, err := util.FindZoneByFqdn(fqdn, util.RecursiveNameservers)
+	if err != nil {
+		return "", err
+	}
+	return util.UnFqdn(authZone), nil
+}
+
+func (c *DNSProvider) makeRequest(method, uri string, body interface{}) (json.RawMessage, error) {
+	var bodyBytes []byte
+	if body != nil {
+		var err error
+		bodyBytes, err = json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	url := "https://" + c.host + uri
+	req, err := http.NewRequest(method, url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	timestamp := time.Now().UTC().Format("20060102T15:04:05-0000")
+	nonce := edgeGridNonce()
+	req.Header.Set("Authorization", c.edgeGridAuthHeader(req, timestamp, nonce, bodyBytes))
+
+	client := http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error querying Akamai EdgeDNS API: %s", err.Error())
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("akamai EdgeDNS API error: %s", string(respBody))
+	}
+
+	return json.RawMessage(respBody), nil
+}
+
+// edgeGridAuthHeader builds the Akamai EdgeGrid authorization header, as
+// described at https://techdocs.akamai.com/developer/docs/authenticate-with-edgegrid.
+func (c *DNSProvider) edgeGridAuthHeader(req *http.Request, timestamp, nonce string, body []byte) string {
+	authData := fmt.Sprintf(
+		"EG1-HMAC-SHA256 client_token=%s;access_token=%s;timestamp=%s;nonce=%s;",
+		c.clientToken, c.accessToken, timestamp, nonce,
+	)
+
+	dataToSign := strings.Join([]string{
+		req.Method,
+		"https",
+		req.URL.Host,
+		req.URL.RequestURI(),
+		bodyHash(body),
+		authData,
+	}, "\t")
+
+	signingKeyB64 := hmacSHA256Base64(timestamp, []byte(c.clientSecret))
+	signingKey, err := base64.StdEncoding.DecodeString(signingKeyB64)
+	if err != nil {
+		// signingKeyB64 is our own base64 encoding, so this should never
+		// happen; fall back to signing with the encoded form.
+		signingKey = []byte(signingKeyB64)
+	}
+	signature := hmacSHA256Base64(dataToSign, signingKey)
+
+	return authData + "signature=" + signature
+}
+
+func bodyHash(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	h := sha256.Sum256(body)
+	return base64.StdEncoding.EncodeToString(h[:])
+}
+
+func hmacSHA256Base64(data string, key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func edgeGridNonce() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return fmt.Sprintf("%x", b)
+}
+
+type akamaiRRSet struct {
+	Name  string   `json:"name"`
+	Type  string   `json:"type"`
+	TTL   int      `json:"ttl"`
+	RData []string `json:"rdata"`
+}
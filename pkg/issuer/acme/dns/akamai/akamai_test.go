@@ -0,0 +1,17 @@
+package akamai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDNSProviderValid(t *testing.T) {
+	_, err := NewDNSProviderCredentials("akab-xxxx.luna.akamaiapis.net", "clienttoken", "clientsecret", "accesstoken")
+	assert.NoError(t, err)
+}
+
+func TestNewDNSProviderMissingCredErr(t *testing.T) {
+	_, err := NewDNSProviderCredentials("", "", "", "")
+	assert.EqualError(t, err, "akamai credentials missing")
+}
@@ -0,0 +1,188 @@
+// Package digitalocean implements a DNS provider for solving the DNS-01
+// challenge using DigitalOcean DNS.
+package digitalocean
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+// digitalOceanAPIURL represents the API endpoint to call.
+const digitalOceanAPIURL = "https://api.digitalocean.com/v2"
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+// that uses DigitalOcean's DNS API to manage TXT records for a DNS-01
+// challenge.
+type DNSProvider struct {
+	token string
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for
+// DigitalOcean. Credentials are read from the DO_AUTH_TOKEN environment
+// variable.
+func NewDNSProvider() (*DNSProvider, error) {
+	token := os.Getenv("DO_AUTH_TOKEN")
+	return NewDNSProviderCredentials(token)
+}
+
+// NewDNSProviderCredentials uses the supplied token to return a
+// DNSProvider instance configured for DigitalOcean.
+func NewDNSProviderCredentials(token string) (*DNSProvider, error) {
+	if token == "" {
+		return nil, fmt.Errorf("DigitalOcean credentials missing")
+	}
+
+	return &DNSProvider{token: token}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS
+// propagation.
+func (c *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return 120 * time.Second, 2 * time.Second
+}
+
+// Present creates a TXT record to fulfil the dns-01 challenge
+func (c *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value, ttl := util.DNS01Record(domain, keyAuth)
+
+	zoneName, err := c.getHostedZone(fqdn)
+	if err != nil {
+		return err
+	}
+
+	reqData := digitalOceanRecordRequest{
+		Type: "TXT",
+		Name: c.extractRecordName(fqdn, zoneName),
+		Data: value,
+		TTL:  ttl,
+	}
+
+	_, err = c.makeRequest("POST", fmt.Sprintf("/domains/%s/records", zoneName), reqData)
+	return err
+}
+
+// CleanUp removes the TXT record matching the specified parameters
+func (c *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _, _ := util.DNS01Record(domain, keyAuth)
+
+	zoneName, err := c.getHostedZone(fqdn)
+	if err != nil {
+		return err
+	}
+
+	records, err := c.findTxtRecords(zoneName, fqdn)
+	if err != nil {
+		return err
+	}
+
+	for _, rec := range records {
+		_, err := c.makeRequest("DELETE", fmt.Sprintf("/domains/%s/records/%d", zoneName, rec.ID), nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *DNSProvider) getHostedZone(fqdn string) (string, error) {
+	authZone, err := util.FindZoneByFqdn(fqdn, util.RecursiveNameservers)
+	if err != nil {
+		return "", err
+	}
+
+	return util.UnFqdn(authZone), nil
+}
+
+func (c *DNSProvider) extractRecordName(fqdn, zone string) string {
+	name := util.UnFqdn(fqdn)
+	if idx := len(name) - len(zone) - 1; idx > 0 {
+		return name[:idx]
+	}
+	return "@"
+}
+
+func (c *DNSProvider) findTxtRecords(zoneName, fqdn string) ([]digitalOceanRecord, error) {
+	result, err := c.makeRequest("GET", fmt.Sprintf("/domains/%s/records?type=TXT", zoneName), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp digitalOceanRecordsResponse
+	if err := json.Unmarshal(result, &resp); err != nil {
+		return nil, err
+	}
+
+	recordName := c.extractRecordName(fqdn, zoneName)
+	var found []digitalOceanRecord
+	for _, rec := range resp.DomainRecords {
+		if rec.Name == recordName {
+			found = append(found, rec)
+		}
+	}
+	return found, nil
+}
+
+func (c *DNSProvider) makeRequest(method, uri string, body interface{}) (json.RawMessage, error) {
+	var reqBody *bytes.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(b)
+	} else {
+		reqBody = bytes.NewReader([]byte{})
+	}
+
+	req, err := http.NewRequest(method, digitalOceanAPIURL+uri, reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	client := http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error querying DigitalOcean API: %s", err.Error())
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("DigitalOcean API error: %s", string(respBody))
+	}
+
+	return json.RawMessage(respBody), nil
+}
+
+type digitalOceanRecordRequest struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+	Data string `json:"data"`
+	TTL  int    `json:"ttl,omitempty"`
+}
+
+type digitalOceanRecord struct {
+	ID   int    `json:"id"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+	Data string `json:"data"`
+}
+
+type digitalOceanRecordsResponse struct {
+	DomainRecords []digitalOceanRecord `json:"domain_records"`
+}
@@ -0,0 +1,19 @@
+package digitalocean
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDNSProviderValid(t *testing.T) {
+	_, err := NewDNSProviderCredentials("123")
+	assert.NoError(t, err)
+}
+
+func TestNewDNSProviderMissingCredErr(t *testing.T) {
+	os.Setenv("DO_AUTH_TOKEN", "")
+	_, err := NewDNSProvider()
+	assert.EqualError(t, err, "DigitalOcean credentials missing")
+}
@@ -10,8 +10,10 @@ import (
 	corev1listers "k8s.io/client-go/listers/core/v1"
 
 	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/issuer/acme/dns/akamai"
 	"github.com/jetstack-experimental/cert-manager/pkg/issuer/acme/dns/clouddns"
 	"github.com/jetstack-experimental/cert-manager/pkg/issuer/acme/dns/cloudflare"
+	"github.com/jetstack-experimental/cert-manager/pkg/issuer/acme/dns/digitalocean"
 	"github.com/jetstack-experimental/cert-manager/pkg/issuer/acme/dns/route53"
 	"github.com/jetstack-experimental/cert-manager/pkg/issuer/acme/dns/util"
 )
@@ -93,6 +95,103 @@ func (s *Solver) CleanUp(ctx context.Context, crt *v1alpha1.Certificate, domain,
 	return slv.CleanUp(domain, token, key)
 }
 
+// dns01Provider is a single entry in the DNS-01 provider registry: it
+// knows how to recognise its own provider config on an
+// ACMEIssuerDNS01Provider and how to build a solver from it. Adding a new
+// DNS-01 provider only requires appending an entry here.
+type dns01Provider struct {
+	name       string
+	configured func(*v1alpha1.ACMEIssuerDNS01Provider) bool
+	build      func(providerConfig *v1alpha1.ACMEIssuerDNS01Provider) (solver, error)
+}
+
+func (s *Solver) registry() []dns01Provider {
+	return []dns01Provider{
+		{
+			name:       "clouddns",
+			configured: func(p *v1alpha1.ACMEIssuerDNS01Provider) bool { return p.CloudDNS != nil },
+			build: func(p *v1alpha1.ACMEIssuerDNS01Provider) (solver, error) {
+				saSecret, err := s.secretLister.Secrets(s.issuer.Namespace).Get(p.CloudDNS.ServiceAccount.Name)
+				if err != nil {
+					return nil, fmt.Errorf("error getting clouddns service account: %s", err.Error())
+				}
+				saBytes := saSecret.Data[p.CloudDNS.ServiceAccount.Key]
+				return clouddns.NewDNSProviderServiceAccountBytes(p.CloudDNS.Project, saBytes)
+			},
+		},
+		{
+			name:       "cloudflare",
+			configured: func(p *v1alpha1.ACMEIssuerDNS01Provider) bool { return p.Cloudflare != nil },
+			build: func(p *v1alpha1.ACMEIssuerDNS01Provider) (solver, error) {
+				apiKeySecret, err := s.secretLister.Secrets(s.issuer.Namespace).Get(p.Cloudflare.APIKey.Name)
+				if err != nil {
+					return nil, fmt.Errorf("error getting cloudflare service account: %s", err.Error())
+				}
+				apiKey := string(apiKeySecret.Data[p.Cloudflare.APIKey.Key])
+				return cloudflare.NewDNSProviderCredentials(p.Cloudflare.Email, apiKey)
+			},
+		},
+		{
+			name:       "route53",
+			configured: func(p *v1alpha1.ACMEIssuerDNS01Provider) bool { return p.Route53 != nil },
+			build: func(p *v1alpha1.ACMEIssuerDNS01Provider) (solver, error) {
+				secretAccessKeySecret, err := s.secretLister.Secrets(s.issuer.Namespace).Get(p.Route53.SecretAccessKey.Name)
+				if err != nil {
+					return nil, fmt.Errorf("error getting route53 secret access key: %s", err.Error())
+				}
+				secretAccessKeyBytes, ok := secretAccessKeySecret.Data[p.Route53.SecretAccessKey.Key]
+				if !ok {
+					return nil, fmt.Errorf("error getting route53 secret access key: key '%s' not found in secret", p.Route53.SecretAccessKey.Key)
+				}
+				return route53.NewDNSProviderAccessKeyRole(
+					p.Route53.AccessKeyID,
+					string(secretAccessKeyBytes),
+					p.Route53.HostedZoneID,
+					p.Route53.Region,
+					p.Route53.Role,
+					p.Route53.ExternalID,
+				)
+			},
+		},
+		{
+			name:       "digitalocean",
+			configured: func(p *v1alpha1.ACMEIssuerDNS01Provider) bool { return p.DigitalOcean != nil },
+			build: func(p *v1alpha1.ACMEIssuerDNS01Provider) (solver, error) {
+				tokenSecret, err := s.secretLister.Secrets(s.issuer.Namespace).Get(p.DigitalOcean.Token.Name)
+				if err != nil {
+					return nil, fmt.Errorf("error getting digitalocean token: %s", err.Error())
+				}
+				token := string(tokenSecret.Data[p.DigitalOcean.Token.Key])
+				return digitalocean.NewDNSProviderCredentials(token)
+			},
+		},
+		{
+			name:       "akamai",
+			configured: func(p *v1alpha1.ACMEIssuerDNS01Provider) bool { return p.Akamai != nil },
+			build: func(p *v1alpha1.ACMEIssuerDNS01Provider) (solver, error) {
+				clientTokenSecret, err := s.secretLister.Secrets(s.issuer.Namespace).Get(p.Akamai.ClientToken.Name)
+				if err != nil {
+					return nil, fmt.Errorf("error getting akamai client token: %s", err.Error())
+				}
+				clientSecretSecret, err := s.secretLister.Secrets(s.issuer.Namespace).Get(p.Akamai.ClientSecret.Name)
+				if err != nil {
+					return nil, fmt.Errorf("error getting akamai client secret: %s", err.Error())
+				}
+				accessTokenSecret, err := s.secretLister.Secrets(s.issuer.Namespace).Get(p.Akamai.AccessToken.Name)
+				if err != nil {
+					return nil, fmt.Errorf("error getting akamai access token: %s", err.Error())
+				}
+				return akamai.NewDNSProviderCredentials(
+					p.Akamai.Host,
+					string(clientTokenSecret.Data[p.Akamai.ClientToken.Key]),
+					string(clientSecretSecret.Data[p.Akamai.ClientSecret.Key]),
+					string(accessTokenSecret.Data[p.Akamai.AccessToken.Key]),
+				)
+			},
+		},
+	}
+}
+
 func (s *Solver) solverFor(crt *v1alpha1.Certificate, domain string) (solver, error) {
 	var cfg *v1alpha1.ACMECertificateDNS01Config
 	if cfg = crt.Spec.ACME.ConfigForDomain(domain).DNS01; cfg == nil ||
@@ -107,57 +206,64 @@ func (s *Solver) solverFor(crt *v1alpha1.Certificate, domain string) (solver, er
 		return nil, fmt.Errorf("invalid provider config specified for domain '%s': %s", domain, err.Error())
 	}
 
-	var impl solver
-	switch {
-	case providerConfig.CloudDNS != nil:
-		saSecret, err := s.secretLister.Secrets(s.issuer.Namespace).Get(providerConfig.CloudDNS.ServiceAccount.Name)
-		if err != nil {
-			return nil, fmt.Errorf("error getting clouddns service account: %s", err.Error())
+	for _, p := range s.registry() {
+		if !p.configured(providerConfig) {
+			continue
 		}
-		saBytes := saSecret.Data[providerConfig.CloudDNS.ServiceAccount.Key]
-
-		impl, err = clouddns.NewDNSProviderServiceAccountBytes(providerConfig.CloudDNS.Project, saBytes)
+		impl, err := p.build(providerConfig)
 		if err != nil {
-			return nil, fmt.Errorf("error instantiating google clouddns challenge solver: %s", err.Error())
-		}
-	case providerConfig.Cloudflare != nil:
-		apiKeySecret, err := s.secretLister.Secrets(s.issuer.Namespace).Get(providerConfig.Cloudflare.APIKey.Name)
-		if err != nil {
-			return nil, fmt.Errorf("error getting cloudflare service account: %s", err.Error())
+			return nil, fmt.Errorf("error instantiating %s challenge solver: %s", p.name, err.Error())
 		}
+		return withTimeoutOverrides(impl, providerConfig)
+	}
 
-		email := providerConfig.Cloudflare.Email
-		apiKey := string(apiKeySecret.Data[providerConfig.Cloudflare.APIKey.Key])
+	return nil, fmt.Errorf("no dns provider config specified for domain '%s'", domain)
+}
 
-		impl, err = cloudflare.NewDNSProviderCredentials(email, apiKey)
-		if err != nil {
-			return nil, fmt.Errorf("error instantiating cloudflare challenge solver: %s", err.Error())
-		}
-	case providerConfig.Route53 != nil:
-		secretAccessKeySecret, err := s.secretLister.Secrets(s.issuer.Namespace).Get(providerConfig.Route53.SecretAccessKey.Name)
-		if err != nil {
-			return nil, fmt.Errorf("error getting route53 secret access key: %s", err.Error())
-		}
+// timeoutOverrideSolver wraps a solver, overriding the timeout and/or
+// interval it returns from Timeout() with the values configured on the
+// issuer's ACMEIssuerDNS01Provider, if any.
+type timeoutOverrideSolver struct {
+	solver
+	timeout  time.Duration
+	interval time.Duration
+}
 
-		secretAccessKeyBytes, ok := secretAccessKeySecret.Data[providerConfig.Route53.SecretAccessKey.Key]
-		if !ok {
-			return nil, fmt.Errorf("error getting route53 secret access key: key '%s' not found in secret", providerConfig.Route53.SecretAccessKey.Key)
-		}
+func (s *timeoutOverrideSolver) Timeout() (timeout, interval time.Duration) {
+	timeout, interval = s.solver.Timeout()
+	if s.timeout > 0 {
+		timeout = s.timeout
+	}
+	if s.interval > 0 {
+		interval = s.interval
+	}
+	return timeout, interval
+}
+
+// withTimeoutOverrides wraps impl so that its Timeout() honours
+// providerConfig's PropagationTimeout and PollingInterval, if set.
+func withTimeoutOverrides(impl solver, providerConfig *v1alpha1.ACMEIssuerDNS01Provider) (solver, error) {
+	if providerConfig.PropagationTimeout == "" && providerConfig.PollingInterval == "" {
+		return impl, nil
+	}
 
-		impl, err = route53.NewDNSProviderAccessKey(
-			providerConfig.Route53.AccessKeyID,
-			string(secretAccessKeyBytes),
-			providerConfig.Route53.HostedZoneID,
-			providerConfig.Route53.Region,
-		)
+	var timeout, interval time.Duration
+	if providerConfig.PropagationTimeout != "" {
+		t, err := time.ParseDuration(providerConfig.PropagationTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing propagationTimeout %q: %s", providerConfig.PropagationTimeout, err.Error())
+		}
+		timeout = t
+	}
+	if providerConfig.PollingInterval != "" {
+		i, err := time.ParseDuration(providerConfig.PollingInterval)
 		if err != nil {
-			return nil, fmt.Errorf("error instantiating route53 challenge solver: %s", err.Error())
+			return nil, fmt.Errorf("error parsing pollingInterval %q: %s", providerConfig.PollingInterval, err.Error())
 		}
-	default:
-		return nil, fmt.Errorf("no dns provider config specified for domain '%s'", domain)
+		interval = i
 	}
 
-	return impl, nil
+	return &timeoutOverrideSolver{solver: impl, timeout: timeout, interval: interval}, nil
 }
 
 func NewSolver(issuer *v1alpha1.Issuer, client kubernetes.Interface, secretLister corev1listers.SecretLister) *Solver {
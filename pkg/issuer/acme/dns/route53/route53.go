@@ -12,6 +12,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/client"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/route53"
@@ -57,10 +58,10 @@ func (d customRetryer) RetryRules(r *request.Request) time.Duration {
 //
 // AWS Credentials are automatically detected in the following locations
 // and prioritized in the following order:
-// 1. Environment variables: AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY,
-//    AWS_REGION, [AWS_SESSION_TOKEN]
-// 2. Shared credentials file (defaults to ~/.aws/credentials)
-// 3. Amazon EC2 IAM role
+//  1. Environment variables: AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY,
+//     AWS_REGION, [AWS_SESSION_TOKEN]
+//  2. Shared credentials file (defaults to ~/.aws/credentials)
+//  3. Amazon EC2 IAM role
 //
 // If AWS_HOSTED_ZONE_ID is not set, Lego tries to determine the correct
 // public hosted zone via the FQDN.
@@ -83,6 +84,17 @@ func NewDNSProvider() (*DNSProvider, error) {
 // NewDNSProviderAccessKey returns a DNSProvider instance configured for the AWS
 // Route 53 service using static credentials from its parameters
 func NewDNSProviderAccessKey(accessKeyID, secretAccessKey, hostedZoneID, region string) (*DNSProvider, error) {
+	return NewDNSProviderAccessKeyRole(accessKeyID, secretAccessKey, hostedZoneID, region, "", "")
+}
+
+// NewDNSProviderAccessKeyRole returns a DNSProvider instance configured for
+// the AWS Route 53 service using static credentials from its parameters. If
+// role is set, those credentials are used to assume the given IAM role via
+// STS AssumeRole before talking to Route 53, so that the hosted zone can
+// live in an AWS account other than the one accessKeyID belongs to.
+// externalID is passed along with the AssumeRole call if set, as required by
+// some cross-account role trust policies.
+func NewDNSProviderAccessKeyRole(accessKeyID, secretAccessKey, hostedZoneID, region, role, externalID string) (*DNSProvider, error) {
 
 	creds := credentials.NewStaticCredentials(accessKeyID, secretAccessKey, "")
 
@@ -94,6 +106,16 @@ func NewDNSProviderAccessKey(accessKeyID, secretAccessKey, hostedZoneID, region
 	if region != "" {
 		config.WithRegion(region)
 	}
+
+	if role != "" {
+		stsSession := session.New(config)
+		config = config.WithCredentials(stscreds.NewCredentials(stsSession, role, func(p *stscreds.AssumeRoleProvider) {
+			if externalID != "" {
+				p.ExternalID = aws.String(externalID)
+			}
+		}))
+	}
+
 	client := route53.New(session.New(config))
 
 	return &DNSProvider{
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	extv1beta1 "k8s.io/api/extensions/v1beta1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -52,6 +54,10 @@ func jobNameFunc(crtName, domain string) string {
 	return dns1035(fmt.Sprintf("cm-%s-%s", crtName, util.RandStringRunes(5)))
 }
 
+func pdbNameFunc(crtName, domain string) string {
+	return dns1035(fmt.Sprintf("cm-%s-%s", crtName, util.RandStringRunes(5)))
+}
+
 // Solver is an implementation of the acme http-01 challenge solver protocol
 type Solver struct {
 	issuer       *v1alpha1.Issuer
@@ -64,13 +70,23 @@ func NewSolver(issuer *v1alpha1.Issuer, client kubernetes.Interface, secretListe
 	return &Solver{issuer, client, secretLister}
 }
 
+const (
+	// ManagedLabelKey and ManagedLabelValue together mark a Service,
+	// Ingress or Job as created and owned by the ACME HTTP-01 solver, so
+	// that other control loops (e.g. the janitor controller in
+	// pkg/controller/janitor) can find them without watching every
+	// resource of that type.
+	ManagedLabelKey   = "certmanager.k8s.io/managed"
+	ManagedLabelValue = "true"
+)
+
 // labelsForCert returns some labels to add to resources related to the given
 // Certificate.
 // TODO: move this somewhere 'general', so that other control loops can filter
 // their watches based on these labels and save watching *all* resource types.
 func labelsForCert(crt *v1alpha1.Certificate, domain string) map[string]string {
 	return map[string]string{
-		"certmanager.k8s.io/managed":     "true",
+		ManagedLabelKey:                  ManagedLabelValue,
 		"certmanager.k8s.io/domain":      domain,
 		"certmanager.k8s.io/certificate": crt.Name,
 		"certmanager.k8s.io/id":          util.RandStringRunes(5),
@@ -84,7 +100,7 @@ func dns1035(s string) string {
 // ensureService will ensure the service required to solve this challenge
 // exists in the target API server, either by updating the existing Service
 // or by creating a new one.
-func (s *Solver) ensureService(crt *v1alpha1.Certificate, domain string, labels map[string]string) (svc *corev1.Service, err error) {
+func (s *Solver) ensureService(ctx context.Context, crt *v1alpha1.Certificate, domain string, labels map[string]string) (svc *corev1.Service, err error) {
 	svcName := svcNameFunc(crt.Name, domain)
 	svc, err = s.client.CoreV1().Services(crt.Namespace).Get(svcName, metav1.GetOptions{})
 	if err != nil && !k8sErrors.IsNotFound(err) {
@@ -123,7 +139,7 @@ func (s *Solver) ensureService(crt *v1alpha1.Certificate, domain string, labels
 	svc.Spec.Type = corev1.ServiceTypeNodePort
 	svc.Spec.Selector = labels
 
-	return kube.EnsureService(s.client, svc)
+	return kube.EnsureService(ctx, s.client, svc)
 }
 
 // cleanupService will ensure the service created for this challenge request
@@ -139,7 +155,7 @@ func (s *Solver) cleanupService(crt *v1alpha1.Certificate, domain string) error
 
 // ensureIngress will ensure the ingress required to solve this challenge
 // exists.
-func (s *Solver) ensureIngress(crt *v1alpha1.Certificate, svcName, domain, token string, labels map[string]string) (ing *extv1beta1.Ingress, err error) {
+func (s *Solver) ensureIngress(ctx context.Context, crt *v1alpha1.Certificate, svcName, domain, token string, labels map[string]string) (ing *extv1beta1.Ingress, err error) {
 	domainCfg := crt.Spec.ACME.ConfigForDomain(domain)
 	if existingIngressName := domainCfg.HTTP01.Ingress; existingIngressName != "" {
 		ing, err = s.ensureIngressHasRule(existingIngressName, crt, svcName, domain, token, nil)
@@ -152,7 +168,7 @@ func (s *Solver) ensureIngress(crt *v1alpha1.Certificate, svcName, domain, token
 		return nil, err
 	}
 
-	return kube.EnsureIngress(s.client, ing)
+	return kube.EnsureIngress(ctx, s.client, ing)
 }
 
 // cleanupIngress will remove the rules added by cert-manager to an existing
@@ -269,11 +285,21 @@ func ingressPath(token, serviceName string) extv1beta1.HTTPIngressPath {
 	}
 }
 
+// solverPodConfig returns the configuration for the solver Pod set on this
+// Solver's issuer, or an empty configuration if none was set.
+func (s *Solver) solverPodConfig() *v1alpha1.ACMEIssuerHTTP01SolverPodConfig {
+	if s.issuer.Spec.ACME == nil || s.issuer.Spec.ACME.HTTP01 == nil || s.issuer.Spec.ACME.HTTP01.SolverPod == nil {
+		return &v1alpha1.ACMEIssuerHTTP01SolverPodConfig{}
+	}
+	return s.issuer.Spec.ACME.HTTP01.SolverPod
+}
+
 // ensureJob will ensure the job required to solve this challenge exists in the
 // Kubernetes API server.
 func (s *Solver) ensureJob(crt *v1alpha1.Certificate, domain, token, key string, labels map[string]string) (*batchv1.Job, error) {
 	activeDeadlineSeconds := int64(HTTP01Timeout / time.Second)
 	jobName := jobNameFunc(crt.Name, domain)
+	podCfg := s.solverPodConfig()
 
 	err := s.client.BatchV1().Jobs(crt.Namespace).Delete(jobName, nil)
 	if err != nil && !k8sErrors.IsNotFound(err) {
@@ -293,7 +319,8 @@ func (s *Solver) ensureJob(crt *v1alpha1.Certificate, domain, token, key string,
 					Labels: labels,
 				},
 				Spec: corev1.PodSpec{
-					RestartPolicy: corev1.RestartPolicyOnFailure,
+					RestartPolicy:   corev1.RestartPolicyOnFailure,
+					SecurityContext: podCfg.SecurityContext,
 					Containers: []corev1.Container{
 						{
 							Name: "acmesolver",
@@ -319,6 +346,7 @@ func (s *Solver) ensureJob(crt *v1alpha1.Certificate, domain, token, key string,
 									ContainerPort: acmeSolverListenPort,
 								},
 							},
+							SecurityContext: podCfg.ContainerSecurityContext,
 						},
 					},
 				},
@@ -327,6 +355,46 @@ func (s *Solver) ensureJob(crt *v1alpha1.Certificate, domain, token, key string,
 	})
 }
 
+// ensurePodDisruptionBudget will ensure a PodDisruptionBudget protecting the
+// solver Pod from voluntary eviction exists, if requested on the issuer's
+// HTTP01 solver Pod config. If it is not requested, any PodDisruptionBudget
+// previously created for this challenge is removed instead.
+func (s *Solver) ensurePodDisruptionBudget(ctx context.Context, crt *v1alpha1.Certificate, domain string, labels map[string]string) error {
+	if !s.solverPodConfig().PodDisruptionBudget {
+		return s.cleanupPodDisruptionBudget(crt, domain)
+	}
+
+	maxUnavailable := intstr.FromInt(0)
+	_, err := kube.EnsurePodDisruptionBudget(ctx, s.client, &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pdbNameFunc(crt.Name, domain),
+			Namespace: crt.Namespace,
+			Labels:    labels,
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error ensuring http01 challenge pod disruption budget: %s", err.Error())
+	}
+	return nil
+}
+
+// cleanupPodDisruptionBudget will ensure the PodDisruptionBudget created for
+// this challenge request does not exist.
+func (s *Solver) cleanupPodDisruptionBudget(crt *v1alpha1.Certificate, domain string) error {
+	pdbName := pdbNameFunc(crt.Name, domain)
+	err := s.client.PolicyV1beta1().PodDisruptionBudgets(crt.Namespace).Delete(pdbName, nil)
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return fmt.Errorf("error cleaning up pod disruption budget: %s", err.Error())
+	}
+	return nil
+}
+
 func (s *Solver) cleanupJob(crt *v1alpha1.Certificate, domain string) error {
 	jobName := jobNameFunc(crt.Name, domain)
 
@@ -346,13 +414,13 @@ func (s *Solver) cleanupJob(crt *v1alpha1.Certificate, domain string) error {
 func (s *Solver) Present(ctx context.Context, crt *v1alpha1.Certificate, domain, token, key string) error {
 	labels := labelsForCert(crt, domain)
 
-	svc, err := s.ensureService(crt, domain, labels)
+	svc, err := s.ensureService(ctx, crt, domain, labels)
 
 	if err != nil {
 		return fmt.Errorf("error ensuring http01 challenge service: %s", err.Error())
 	}
 
-	_, err = s.ensureIngress(crt, svc.Name, domain, token, labels)
+	_, err = s.ensureIngress(ctx, crt, svc.Name, domain, token, labels)
 
 	if err != nil {
 		return fmt.Errorf("error ensuring http01 challenge ingress: %s", err.Error())
@@ -364,6 +432,10 @@ func (s *Solver) Present(ctx context.Context, crt *v1alpha1.Certificate, domain,
 		return fmt.Errorf("error ensuring http01 challenge job: %s", err.Error())
 	}
 
+	if err := s.ensurePodDisruptionBudget(ctx, crt, domain, labels); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -379,7 +451,7 @@ func (s *Solver) Wait(ctx context.Context, crt *v1alpha1.Certificate, domain, to
 			out := make(chan error, 1)
 			go func() {
 				defer close(out)
-				out <- testReachability(ctx, domain, fmt.Sprintf("%s/%s", solver.HTTPChallengePath, token), key)
+				out <- testReachability(ctx, s.issuer.Spec.ACME.HTTP01SelfCheckAddressFamily, domain, fmt.Sprintf("%s/%s", solver.HTTPChallengePath, token), key)
 			}()
 			return out
 		}():
@@ -398,14 +470,38 @@ func (s *Solver) Wait(ctx context.Context, crt *v1alpha1.Certificate, domain, to
 }
 
 // testReachability will attempt to connect to the 'domain' with 'path' and
-// check if the returned body equals 'key'
-func testReachability(ctx context.Context, domain, path, key string) error {
+// check if the returned body equals 'key'. addressFamily restricts which IP
+// address family is dialed - one of "" (both, Go's default happy-eyeballs
+// behaviour), "ipv4" or "ipv6" - so that the self check can be made to
+// succeed on single-stack IPv6 clusters where dialing an IPv4 address is
+// never going to work.
+func testReachability(ctx context.Context, addressFamily, domain, path, key string) error {
 	url := &url.URL{}
 	url.Scheme = "http"
 	url.Host = domain
 	url.Path = path
 
-	response, err := http.Get(url.String())
+	network, err := dialNetworkForAddressFamily(addressFamily)
+	if err != nil {
+		return err
+	}
+
+	dialer := &net.Dialer{}
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, addr string) (net.Conn, error) {
+				return dialer.DialContext(ctx, network, addr)
+			},
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url.String(), nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+
+	response, err := client.Do(req)
 	if err != nil {
 		return err
 	}
@@ -429,9 +525,27 @@ func testReachability(ctx context.Context, domain, path, key string) error {
 	return nil
 }
 
+// dialNetworkForAddressFamily maps an ACMEIssuer.HTTP01SelfCheckAddressFamily
+// value to the network name accepted by net.Dialer.DialContext.
+func dialNetworkForAddressFamily(addressFamily string) (string, error) {
+	switch addressFamily {
+	case "":
+		return "tcp", nil
+	case "ipv4":
+		return "tcp4", nil
+	case "ipv6":
+		return "tcp6", nil
+	default:
+		return "", fmt.Errorf("invalid http01SelfCheckAddressFamily %q: must be one of \"\", \"ipv4\" or \"ipv6\"", addressFamily)
+	}
+}
+
 // CleanUp will ensure the created service and ingress are clean/deleted of any
 // cert-manager created data.
 func (s *Solver) CleanUp(ctx context.Context, crt *v1alpha1.Certificate, domain, token, key string) error {
+	if err := s.cleanupPodDisruptionBudget(crt, domain); err != nil {
+		return fmt.Errorf("[%s] Error cleaning up pod disruption budget: %s", domain, err.Error())
+	}
 	if err := s.cleanupJob(crt, domain); err != nil {
 		return fmt.Errorf("[%s] Error cleaning up job: %s", domain, err.Error())
 	}
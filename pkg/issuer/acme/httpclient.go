@@ -0,0 +1,86 @@
+package acme
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
+
+// httpClient returns the http.Client that should be used for all requests
+// made to this issuer's ACME server. If the issuer has an HTTPProxy
+// configured, it is used in preference to the HTTPS_PROXY/HTTP_PROXY/
+// NO_PROXY environment variables that net/http's default transport already
+// honours. If the issuer has a CABundle or CABundleSecretRef configured, it
+// is trusted in addition to the system trust store when validating the
+// ACME server's TLS certificate. If SkipTLSVerify is set, the server's TLS
+// certificate is not validated at all - this is insecure and is intended
+// only for air-gapped lab environments.
+func (a *Acme) httpClient() (*http.Client, error) {
+	acmeIssuer := a.issuer.Spec.ACME
+	caBundle, err := a.caBundle(acmeIssuer)
+	if err != nil {
+		return nil, err
+	}
+
+	if acmeIssuer.HTTPProxy == "" && len(caBundle) == 0 && !acmeIssuer.SkipTLSVerify {
+		return http.DefaultClient, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if acmeIssuer.HTTPProxy != "" {
+		proxyURL, err := url.Parse(acmeIssuer.HTTPProxy)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing httpProxy %q: %s", acmeIssuer.HTTPProxy, err.Error())
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if len(caBundle) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("error parsing CA bundle: no valid PEM certificates found")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	if acmeIssuer.SkipTLSVerify {
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.InsecureSkipVerify = true
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// caBundle resolves the configured CABundle or CABundleSecretRef, if any,
+// into PEM encoded bytes. CABundle takes precedence if both are set.
+func (a *Acme) caBundle(acmeIssuer *v1alpha1.ACMEIssuer) ([]byte, error) {
+	if len(acmeIssuer.CABundle) > 0 {
+		return acmeIssuer.CABundle, nil
+	}
+
+	if acmeIssuer.CABundleSecretRef == nil {
+		return nil, nil
+	}
+
+	secret, err := a.secretsLister.Secrets(a.issuer.Namespace).Get(acmeIssuer.CABundleSecretRef.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error getting caBundleSecretRef: %s", err.Error())
+	}
+
+	bundle, ok := secret.Data[acmeIssuer.CABundleSecretRef.Key]
+	if !ok {
+		return nil, fmt.Errorf("error getting caBundleSecretRef: key '%s' not found in secret", acmeIssuer.CABundleSecretRef.Key)
+	}
+
+	return bundle, nil
+}
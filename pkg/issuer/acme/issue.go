@@ -1,13 +1,12 @@
 package acme
 
 import (
-	"bytes"
 	"context"
 	"crypto/rand"
 	"crypto/x509"
-	"encoding/pem"
 	"fmt"
 	"log"
+	"time"
 
 	"golang.org/x/crypto/acme"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
@@ -15,11 +14,14 @@ import (
 	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
 	"github.com/jetstack-experimental/cert-manager/pkg/util/kube"
 	"github.com/jetstack-experimental/cert-manager/pkg/util/pki"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/trace"
 )
 
 const (
 	errorIssueCert = "ErrIssueCert"
 
+	errorRateLimited = "ErrRateLimited"
+
 	successCertIssued = "CertIssueSuccess"
 
 	messageErrorIssueCert = "Error issuing TLS certificate: "
@@ -27,14 +29,21 @@ const (
 	messageCertIssued = "Certificate issued successfully"
 )
 
-func (a *Acme) obtainCertificate(crt *v1alpha1.Certificate) ([]byte, []byte, error) {
+func (a *Acme) obtainCertificate(ctx context.Context, crt *v1alpha1.Certificate) ([]byte, []byte, error) {
 	if crt.Spec.ACME == nil {
 		return nil, nil, fmt.Errorf("acme config must be specified")
 	}
+	if crt.Spec.CommonNameOnly {
+		return nil, nil, fmt.Errorf("commonNameOnly is not supported by the ACME issuer: a SAN extension is required to prove domain control")
+	}
 	domains := crt.Spec.Domains
 
-	if len(domains) == 0 {
-		return nil, nil, fmt.Errorf("no domains specified")
+	if len(domains) == 0 && len(crt.Spec.IPAddresses) == 0 {
+		return nil, nil, fmt.Errorf("no domains or IP addresses specified")
+	}
+
+	if err := a.checkRateLimit(); err != nil {
+		return nil, nil, err
 	}
 
 	acmePrivKey, err := kube.SecretTLSKey(a.secretsLister, a.issuer.Namespace, a.issuer.Spec.ACME.PrivateKey)
@@ -43,60 +52,94 @@ func (a *Acme) obtainCertificate(crt *v1alpha1.Certificate) ([]byte, []byte, err
 		return nil, nil, fmt.Errorf("error getting acme account private key: %s", err.Error())
 	}
 
+	httpClient, err := a.httpClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	cl := &acme.Client{
 		Key:          acmePrivKey,
 		DirectoryURL: a.issuer.Spec.ACME.Server,
+		HTTPClient:   httpClient,
 	}
 
-	key, err := kube.SecretTLSKey(a.secretsLister, crt.Namespace, crt.Spec.SecretName)
+	keySize := crt.Spec.KeySize
+	if keySize == 0 {
+		keySize = pki.DefaultRSAKeySize
+	}
+
+	key, err := kube.SecretTLSKeyRef(a.secretsLister, crt.Namespace, crt.Spec.SecretName, crt.PrivateKeyKey())
+	regenerate := k8sErrors.IsNotFound(err)
+
+	if err == nil && !pki.PrivateKeyMatchesSize(key, keySize) {
+		regenerate = true
+		err = nil
+	}
 
-	if k8sErrors.IsNotFound(err) {
-		key, err = pki.GenerateRSAPrivateKey(2048)
+	if regenerate {
+		keySpan := trace.StartSpan(ctx, "keygen", trace.CertificateAttr(crt.Namespace, crt.Name))
+		key, err = pki.GenerateRSAPrivateKey(keySize)
+		keySpan.End()
 		if err != nil {
 			return nil, nil, fmt.Errorf("error generating private key: %s", err.Error())
 		}
-	}
-
-	if err != nil {
+	} else if err != nil {
 		return nil, nil, fmt.Errorf("error getting certificate private key: %s", err.Error())
 	}
 
-	template := pki.GenerateCSR(domains)
+	template := pki.GenerateCSR(domains, crt.Spec.IPAddresses)
 	csr, err := x509.CreateCertificateRequest(rand.Reader, template, key)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating certificate request: %s", err)
 	}
 
+	var requestedDuration time.Duration
+	if crt.Spec.Duration != "" {
+		requestedDuration, err = time.ParseDuration(crt.Spec.Duration)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error parsing requested certificate duration %q: %s", crt.Spec.Duration, err.Error())
+		}
+	}
+
+	orderSpan := trace.StartSpan(ctx, "acme-order", trace.CertificateAttr(crt.Namespace, crt.Name))
 	certSlice, certURL, err := cl.CreateCert(
-		context.Background(),
+		ctx,
 		csr,
-		0,
+		requestedDuration,
 		true,
 	)
+	orderSpan.End()
 	if err != nil {
+		a.observeOrderError(err)
 		return nil, nil, fmt.Errorf("error getting certificate for acme server: %s", err)
 	}
 
-	certBuffer := bytes.NewBuffer([]byte{})
-	for _, cert := range certSlice {
-		pem.Encode(certBuffer, &pem.Block{Type: "CERTIFICATE", Bytes: cert})
+	chainPem, err := pki.ChainFromCertificates(certSlice, a.issuer.Spec.ACME.ExcludeRootCAFromChain)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building certificate chain: %s", err.Error())
 	}
 
 	log.Printf("successfully got certificate: domains=%+v url=%s", domains, certURL)
 
-	return pki.EncodePKCS1PrivateKey(key), certBuffer.Bytes(), nil
+	return pki.EncodePKCS1PrivateKey(key), chainPem, nil
 }
 
-func (a *Acme) Issue(crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, []byte, []byte, error) {
+func (a *Acme) Issue(ctx context.Context, crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, []byte, []byte, []byte, error) {
 	update := crt.DeepCopy()
-	key, cert, err := a.obtainCertificate(crt)
+	key, cert, err := a.obtainCertificate(ctx, crt)
 	if err != nil {
+		if _, ok := err.(*rateLimitedError); ok {
+			update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionFalse, errorRateLimited, err.Error())
+			return update.Status, nil, nil, nil, err
+		}
 		s := messageErrorIssueCert + err.Error()
 		update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionFalse, errorIssueCert, s)
-		return update.Status, nil, nil, err
+		return update.Status, nil, nil, nil, err
 	}
 
 	update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionTrue, successCertIssued, messageCertIssued)
 
-	return update.Status, key, cert, err
+	// ACME certificates are signed by a public CA that clients already
+	// trust, so we don't have a ca.crt bundle to return here.
+	return update.Status, key, cert, nil, err
 }
@@ -6,9 +6,12 @@ import (
 	"fmt"
 	"log"
 	"sync"
+	"time"
 
 	"golang.org/x/crypto/acme"
 	"k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	"github.com/golang/glog"
@@ -21,6 +24,7 @@ const (
 	successObtainedAuthorization = "ObtainAuthorization"
 	reasonPresentChallenge       = "PresentChallenge"
 	reasonSelfCheck              = "SelfCheck"
+	reasonAuthorizationTimeout   = "AuthorizationTimeout"
 	errorGetACMEAccount          = "ErrGetACMEAccount"
 	errorCheckAuthorization      = "ErrCheckAuthorization"
 	errorObtainAuthorization     = "ErrObtainAuthorization"
@@ -28,9 +32,34 @@ const (
 	messageObtainedAuthorization    = "Obtained authorization for domain %s"
 	messagePresentChallenge         = "Presenting %s challenge for domain %s"
 	messageSelfCheck                = "Performing self-check for domain %s"
+	messageAuthorizationTimeout     = "Authorization for domain %s did not complete within %s, abandoning it; a fresh challenge will be requested on the next attempt"
 	messageErrorGetACMEAccount      = "Error getting ACME account: "
 	messageErrorCheckAuthorization  = "Error checking ACME domain validation: "
 	messageErrorObtainAuthorization = "Error obtaining ACME domain authorization: "
+
+	// staleChallengeThreshold is how long a pending challenge may remain
+	// outstanding before CleanupStalePendingChallenges considers it
+	// abandoned and cleans it up. It is longer than HTTP01Timeout so that
+	// challenges legitimately still in progress aren't touched.
+	staleChallengeThreshold = time.Hour
+
+	// authorizationTimeout bounds how long a single domain's authorization
+	// attempt (presenting its challenge and waiting for the ACME server to
+	// validate it) is allowed to run. Without this, a single domain whose
+	// authorization has stalled server-side could consume the whole sync
+	// timeout and starve the other domains on the same Certificate of their
+	// share of it. On timeout, any authorization previously saved for the
+	// domain is discarded, so the next reconcile starts over with a fresh
+	// challenge rather than getting wedged retrying one that will never
+	// complete; this old draft-ACME client predates RFC 8555 orders, so a
+	// domain authorization (rather than an order) is the unit that gets
+	// abandoned and retried here.
+	authorizationTimeout = time.Minute * 3
+	// maxACMEStatusUpdateRetries bounds how many times recordPendingChallenge
+	// and clearPendingChallenge retry a status update after a conflicting
+	// concurrent write, since several domains on the same Certificate may be
+	// authorized concurrently.
+	maxACMEStatusUpdateRetries = 5
 )
 
 // Prepare will ensure the issuer has been initialised and is ready to issue
@@ -38,7 +67,7 @@ const (
 //
 // It will send the appropriate Letsencrypt authorizations, and complete
 // challenge requests if neccessary.
-func (a *Acme) Prepare(crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, error) {
+func (a *Acme) Prepare(ctx context.Context, crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, error) {
 	update := crt.DeepCopy()
 
 	log.Printf("getting private key for acme issuer %s/%s", a.issuer.Namespace, a.issuer.Name)
@@ -50,13 +79,21 @@ func (a *Acme) Prepare(crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, e
 		return update.Status, errors.New(s)
 	}
 
+	httpClient, err := a.httpClient()
+	if err != nil {
+		s := messageErrorGetACMEAccount + err.Error()
+		update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionFalse, errorGetACMEAccount, s)
+		return update.Status, errors.New(s)
+	}
+
 	cl := &acme.Client{
 		Key:          accountPrivKey,
 		DirectoryURL: a.issuer.Spec.ACME.Server,
+		HTTPClient:   httpClient,
 	}
 
 	// step one: check issuer to see if we already have authorizations
-	toAuthorize, err := authorizationsToObtain(cl, *crt)
+	toAuthorize, err := authorizationsToObtain(ctx, cl, *crt)
 
 	if err != nil {
 		s := messageErrorCheckAuthorization + err.Error()
@@ -72,7 +109,7 @@ func (a *Acme) Prepare(crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, e
 		return update.Status, nil
 	}
 
-	auths, err := getAuthorizations(cl, toAuthorize...)
+	auths, err := getAuthorizations(ctx, cl, toAuthorize...)
 
 	if err != nil {
 		s := messageErrorCheckAuthorization + err.Error()
@@ -92,12 +129,18 @@ func (a *Acme) Prepare(crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, e
 		wg.Add(1)
 		go func(auth authResponse) {
 			defer wg.Done()
-			a, err := a.authorize(cl, crt, auth)
+			authCtx, cancel := context.WithTimeout(ctx, authorizationTimeout)
+			defer cancel()
+			authorization, err := a.authorize(authCtx, cl, crt, auth)
+			if err != nil && authCtx.Err() == context.DeadlineExceeded {
+				a.abandonAuthorization(crt, auth.domain)
+				err = fmt.Errorf("authorization for domain '%s' timed out after %s", auth.domain, authorizationTimeout)
+			}
 			resultChan <- struct {
 				authResponse
 				*acme.Authorization
 				error
-			}{authResponse: auth, Authorization: a, error: err}
+			}{authResponse: auth, Authorization: authorization, error: err}
 		}(auth)
 	}
 
@@ -113,8 +156,9 @@ func (a *Acme) Prepare(crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, e
 			errs = append(errs, fmt.Errorf("authorization in %s state is not ready", res.Authorization.Status))
 		}
 		crt.Status.ACMEStatus().SaveAuthorization(v1alpha1.ACMEDomainAuthorization{
-			Domain: res.authResponse.domain,
-			URI:    res.Authorization.URI,
+			Domain:    res.authResponse.domain,
+			URI:       res.Authorization.URI,
+			StartTime: metav1.Now(),
 		})
 	}
 
@@ -141,7 +185,7 @@ func keyForChallenge(cl *acme.Client, challenge *acme.Challenge) (string, error)
 	return "", err
 }
 
-func (a *Acme) authorize(cl *acme.Client, crt *v1alpha1.Certificate, auth authResponse) (*acme.Authorization, error) {
+func (a *Acme) authorize(ctx context.Context, cl *acme.Client, crt *v1alpha1.Certificate, auth authResponse) (*acme.Authorization, error) {
 	glog.V(4).Infof("picking challenge type for domain '%s'", auth.domain)
 	challengeType, err := pickChallengeType(auth.domain, auth.auth, crt.Spec.ACME.Config)
 	if err != nil {
@@ -162,32 +206,50 @@ func (a *Acme) authorize(cl *acme.Client, crt *v1alpha1.Certificate, auth authRe
 		return nil, err
 	}
 
-	defer solver.CleanUp(context.Background(), crt, auth.domain, token, key)
+	if err := a.recordPendingChallenge(crt.Namespace, crt.Name, v1alpha1.ACMEPendingChallenge{
+		Domain:    auth.domain,
+		Type:      challengeType,
+		Token:     token,
+		Key:       key,
+		StartTime: metav1.Now(),
+	}); err != nil {
+		glog.Errorf("error recording pending %s challenge for domain '%s': %s", challengeType, auth.domain, err.Error())
+	}
+
+	defer func() {
+		solver.CleanUp(ctx, crt, auth.domain, token, key)
+		if err := a.clearPendingChallenge(crt.Namespace, crt.Name, auth.domain, token); err != nil {
+			glog.Errorf("error clearing pending %s challenge for domain '%s': %s", challengeType, auth.domain, err.Error())
+		}
+	}()
 
 	a.recorder.Eventf(crt, v1.EventTypeNormal, reasonPresentChallenge, messagePresentChallenge, challengeType, auth.domain)
-	err = solver.Present(context.Background(), crt, auth.domain, token, key)
+	err = solver.Present(ctx, crt, auth.domain, token, key)
 	if err != nil {
 		return nil, fmt.Errorf("error presenting acme authorization for domain '%s': %s", auth.domain, err.Error())
 	}
 
 	a.recorder.Eventf(crt, v1.EventTypeNormal, reasonSelfCheck, messageSelfCheck, auth.domain)
-	err = solver.Wait(context.Background(), crt, auth.domain, token, key)
+	err = solver.Wait(ctx, crt, auth.domain, token, key)
 	if err != nil {
 		return nil, fmt.Errorf("error waiting for key to be available for domain '%s': %s", auth.domain, err.Error())
 	}
 
-	challenge, err = cl.Accept(context.Background(), challenge)
+	challenge, err = cl.Accept(ctx, challenge)
 	if err != nil {
+		a.recordAuthorizationError(crt.Namespace, crt.Name, auth.domain, err)
 		return nil, fmt.Errorf("error accepting acme challenge for domain '%s': %s", auth.domain, err.Error())
 	}
 
 	glog.V(4).Infof("waiting for authorization for domain %s (%s)...", auth.domain, challenge.URI)
-	authorization, err := cl.WaitAuthorization(context.Background(), challenge.URI)
+	authorization, err := cl.WaitAuthorization(ctx, challenge.URI)
 	if err != nil {
+		a.recordAuthorizationError(crt.Namespace, crt.Name, auth.domain, err)
 		return nil, fmt.Errorf("error waiting for authorization for domain '%s': %s", auth.domain, err.Error())
 	}
 
 	if authorization.Status != acme.StatusValid {
+		a.recordAuthorizationError(crt.Namespace, crt.Name, auth.domain, challengeError(authorization))
 		return nil, fmt.Errorf("expected acme domain authorization status for '%s' to be valid, but it is %s", auth.domain, authorization.Status)
 	}
 
@@ -196,8 +258,75 @@ func (a *Acme) authorize(cl *acme.Client, crt *v1alpha1.Certificate, auth authRe
 	return authorization, nil
 }
 
-func checkAuthorization(cl *acme.Client, uri string) (bool, error) {
-	a, err := cl.GetAuthorization(context.Background(), uri)
+// abandonAuthorization discards any authorization previously saved for
+// domain and emits an event recording that it was abandoned after timing
+// out, so the next reconcile requests a fresh one via getAuthorizations
+// rather than endlessly retrying one that has stalled.
+func (a *Acme) abandonAuthorization(crt *v1alpha1.Certificate, domain string) {
+	status := crt.Status.ACMEStatus()
+	remaining := make([]v1alpha1.ACMEDomainAuthorization, 0, len(status.Authorizations))
+	for _, auth := range status.Authorizations {
+		if auth.Domain == domain {
+			continue
+		}
+		remaining = append(remaining, auth)
+	}
+	status.Authorizations = remaining
+
+	a.recorder.Eventf(crt, v1.EventTypeWarning, reasonAuthorizationTimeout, messageAuthorizationTimeout, domain, authorizationTimeout)
+}
+
+// challengeError returns the error recorded against the first challenge of
+// authorization that has one, for authorizations that did not reach the
+// valid state without a top-level error being returned by the ACME client
+// (e.g. the authorization simply expired).
+func challengeError(authorization *acme.Authorization) error {
+	for _, challenge := range authorization.Challenges {
+		if challenge.Error != nil {
+			return challenge.Error
+		}
+	}
+	return fmt.Errorf("authorization is in %s state", authorization.Status)
+}
+
+// recordAuthorizationError persists the upstream ACME problem document
+// carried by err (if any) onto the named Certificate's ACME status, so it
+// is visible without correlating a truncated Event string with controller
+// logs. Errors updating the status are logged rather than returned, since a
+// failure to record the error detail shouldn't prevent the authorization
+// failure itself from being returned and retried.
+func (a *Acme) recordAuthorizationError(namespace, name, domain string, err error) {
+	authErr := &v1alpha1.ACMEAuthorizationError{
+		Domain: domain,
+		Detail: err.Error(),
+	}
+
+	switch e := err.(type) {
+	case *acme.Error:
+		authErr.Type = e.ProblemType
+		authErr.Detail = e.Detail
+		authErr.StatusCode = e.StatusCode
+	case *acme.AuthorizationError:
+		for _, sub := range e.Errors {
+			if ae, ok := sub.(*acme.Error); ok {
+				authErr.Type = ae.ProblemType
+				authErr.Detail = ae.Detail
+				authErr.StatusCode = ae.StatusCode
+				break
+			}
+		}
+	}
+
+	updateErr := a.updateACMEStatus(namespace, name, func(status *v1alpha1.CertificateACMEStatus) {
+		status.LastAuthorizationError = authErr
+	})
+	if updateErr != nil {
+		glog.Errorf("error recording ACME authorization error detail for domain '%s': %s", domain, updateErr.Error())
+	}
+}
+
+func checkAuthorization(ctx context.Context, cl *acme.Client, uri string) (bool, error) {
+	a, err := cl.GetAuthorization(ctx, uri)
 
 	if err != nil {
 		return false, err
@@ -218,15 +347,19 @@ func authorizationsMap(list []v1alpha1.ACMEDomainAuthorization) map[string]v1alp
 	return out
 }
 
-func authorizationsToObtain(cl *acme.Client, crt v1alpha1.Certificate) ([]string, error) {
+func authorizationsToObtain(ctx context.Context, cl *acme.Client, crt v1alpha1.Certificate) ([]string, error) {
 	authMap := authorizationsMap(crt.Status.ACMEStatus().Authorizations)
+	// identifiers to authorize cover both DNS names and IP addresses (RFC
+	// 8738) requested on the Certificate - Authorize picks the appropriate
+	// identifier type for each value.
+	identifiers := append(append([]string{}, crt.Spec.Domains...), crt.Spec.IPAddresses...)
 	toAuthorize := util.StringFilter(func(domain string) (bool, error) {
 		auth, ok := authMap[domain]
 		if !ok {
 			return false, nil
 		}
-		return checkAuthorization(cl, auth.URI)
-	}, crt.Spec.Domains...)
+		return checkAuthorization(ctx, cl, auth.URI)
+	}, identifiers...)
 
 	domains := make([]string, len(toAuthorize))
 	for i, v := range toAuthorize {
@@ -260,12 +393,12 @@ func (a authResponses) Error() error {
 	return nil
 }
 
-func getAuthorizations(cl *acme.Client, domains ...string) ([]authResponse, error) {
+func getAuthorizations(ctx context.Context, cl *acme.Client, domains ...string) ([]authResponse, error) {
 	respCh := make(chan authResponse)
 	defer close(respCh)
 	for _, d := range domains {
 		go func(domain string) {
-			auth, err := cl.Authorize(context.Background(), domain)
+			auth, err := cl.Authorize(ctx, domain)
 
 			if err != nil {
 				respCh <- authResponse{"", nil, fmt.Errorf("getting acme authorization failed: %s", err.Error())}
@@ -311,3 +444,91 @@ func challengeForAuthorization(cl *acme.Client, auth *acme.Authorization, challe
 	}
 	return nil, fmt.Errorf("challenge mechanism '%s' not allowed for domain", challengeType)
 }
+
+// recordPendingChallenge persists pc onto the named Certificate's ACME
+// status, so that it can be found and cleaned up by
+// CleanupStalePendingChallenges even if the controller is restarted before
+// authorize's own deferred cleanup can run.
+func (a *Acme) recordPendingChallenge(namespace, name string, pc v1alpha1.ACMEPendingChallenge) error {
+	return a.updateACMEStatus(namespace, name, func(status *v1alpha1.CertificateACMEStatus) {
+		status.PendingChallenges = append(status.PendingChallenges, pc)
+	})
+}
+
+// clearPendingChallenge removes the pending challenge for domain/token
+// recorded by recordPendingChallenge, once it has been cleaned up.
+func (a *Acme) clearPendingChallenge(namespace, name, domain, token string) error {
+	return a.updateACMEStatus(namespace, name, func(status *v1alpha1.CertificateACMEStatus) {
+		remaining := make([]v1alpha1.ACMEPendingChallenge, 0, len(status.PendingChallenges))
+		for _, pc := range status.PendingChallenges {
+			if pc.Domain == domain && pc.Token == token {
+				continue
+			}
+			remaining = append(remaining, pc)
+		}
+		status.PendingChallenges = remaining
+	})
+}
+
+// updateACMEStatus fetches the latest version of the named Certificate,
+// applies mutate to its ACME status and persists the result, retrying on a
+// conflicting concurrent update - several domains on the same Certificate
+// may have their challenges presented and cleaned up concurrently.
+func (a *Acme) updateACMEStatus(namespace, name string, mutate func(*v1alpha1.CertificateACMEStatus)) error {
+	for i := 0; i < maxACMEStatusUpdateRetries; i++ {
+		crt, err := a.cmClient.CertmanagerV1alpha1().Certificates(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		mutate(crt.Status.ACMEStatus())
+
+		_, err = a.cmClient.CertmanagerV1alpha1().Certificates(namespace).UpdateStatus(crt)
+		if err == nil {
+			return nil
+		}
+		if !k8sErrors.IsConflict(err) {
+			return err
+		}
+	}
+
+	return fmt.Errorf("failed to update ACME status for Certificate %s/%s after %d retries due to repeated conflicts", namespace, name, maxACMEStatusUpdateRetries)
+}
+
+// CleanupStalePendingChallenges removes any challenge resources recorded on
+// crt's ACME status that have been outstanding for longer than
+// staleChallengeThreshold, indicating the issuance attempt that created them
+// was abandoned or the controller was restarted before it could clean up
+// after itself.
+func (a *Acme) CleanupStalePendingChallenges(ctx context.Context, crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, error) {
+	update := crt.DeepCopy()
+	status := update.Status.ACMEStatus()
+
+	remaining := make([]v1alpha1.ACMEPendingChallenge, 0, len(status.PendingChallenges))
+	var errs []error
+	for _, pc := range status.PendingChallenges {
+		if time.Since(pc.StartTime.Time) < staleChallengeThreshold {
+			remaining = append(remaining, pc)
+			continue
+		}
+
+		solver, err := a.solverFor(pc.Type)
+		if err != nil {
+			errs = append(errs, err)
+			remaining = append(remaining, pc)
+			continue
+		}
+
+		if err := solver.CleanUp(ctx, update, pc.Domain, pc.Token, pc.Key); err != nil {
+			errs = append(errs, fmt.Errorf("error cleaning up stale %s challenge for domain '%s': %s", pc.Type, pc.Domain, err.Error()))
+			remaining = append(remaining, pc)
+			continue
+		}
+
+		glog.Infof("cleaned up stale %s challenge for domain '%s'", pc.Type, pc.Domain)
+	}
+
+	status.PendingChallenges = remaining
+
+	return update.Status, utilerrors.NewAggregate(errs)
+}
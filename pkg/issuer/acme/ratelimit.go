@@ -0,0 +1,83 @@
+package acme
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/acme"
+)
+
+// rateLimits tracks, per ACME account, the time until which new orders
+// should be deferred because the CA has told us we're being rate limited.
+// This is process-global as rate limits are enforced by the CA per account
+// regardless of which Certificate resource triggered the order.
+var rateLimits = &rateLimitTracker{limits: make(map[string]time.Time)}
+
+type rateLimitTracker struct {
+	mu     sync.Mutex
+	limits map[string]time.Time
+}
+
+// blockedUntil returns the time at which it is safe to resume making
+// requests for the given account key, and whether the account is currently
+// blocked.
+func (t *rateLimitTracker) blockedUntil(accountKey string) (time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	until, ok := t.limits[accountKey]
+	if !ok || time.Now().After(until) {
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+// observe records a rate limit error observed for the given account key,
+// deferring further orders until the returned Retry-After has elapsed.
+func (t *rateLimitTracker) observe(accountKey string, retryAfter time.Duration) {
+	if retryAfter <= 0 {
+		// the CA didn't tell us how long to wait - apply a conservative
+		// default backoff rather than retrying immediately
+		retryAfter = time.Hour
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.limits[accountKey] = time.Now().Add(retryAfter)
+}
+
+// accountKey returns the identifier used to track rate limits for this
+// issuer's registered ACME account.
+func (a *Acme) accountKey() string {
+	return fmt.Sprintf("%s/%s", a.issuer.Spec.ACME.Server, a.issuer.Spec.ACME.PrivateKey)
+}
+
+// rateLimitedError is returned by checkRateLimit when an order is being
+// deferred due to a previous rate limit response, so that callers can
+// report it distinctly from a genuine issuance failure.
+type rateLimitedError struct {
+	until time.Time
+}
+
+func (e *rateLimitedError) Error() string {
+	return fmt.Sprintf("deferring new ACME order until %s due to a previous rate limit response from the server", e.until.Format(time.RFC3339))
+}
+
+// checkRateLimit returns an error if this issuer's ACME account is
+// currently being rate limited, without making a request to the ACME
+// server.
+func (a *Acme) checkRateLimit() error {
+	if until, blocked := rateLimits.blockedUntil(a.accountKey()); blocked {
+		return &rateLimitedError{until: until}
+	}
+	return nil
+}
+
+// observeOrderError records any rate limit information present in err so
+// that subsequent orders for this account can be paced or deferred.
+func (a *Acme) observeOrderError(err error) {
+	retryAfter, ok := acme.RateLimit(err)
+	if !ok {
+		return
+	}
+	rateLimits.observe(a.accountKey(), retryAfter)
+}
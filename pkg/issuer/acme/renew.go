@@ -1,6 +1,8 @@
 package acme
 
 import (
+	"context"
+
 	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
 )
 
@@ -12,16 +14,20 @@ const (
 	messageCertRenewed = "Certificate renewed successfully"
 )
 
-func (a *Acme) Renew(crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, []byte, []byte, error) {
+func (a *Acme) Renew(ctx context.Context, crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, []byte, []byte, []byte, error) {
 	update := crt.DeepCopy()
-	key, cert, err := a.obtainCertificate(crt)
+	key, cert, err := a.obtainCertificate(ctx, crt)
 	if err != nil {
+		if _, ok := err.(*rateLimitedError); ok {
+			update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionFalse, errorRateLimited, err.Error())
+			return update.Status, nil, nil, nil, err
+		}
 		s := messageErrorIssueCert + err.Error()
 		update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionFalse, errorRenewCert, s)
-		return update.Status, nil, nil, err
+		return update.Status, nil, nil, nil, err
 	}
 
 	update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionTrue, successCertRenewed, messageCertRenewed)
 
-	return update.Status, key, cert, err
+	return update.Status, key, cert, nil, err
 }
@@ -24,21 +24,45 @@ const (
 	successAccountRegistered = "ACMEAccountRegistered"
 	successAccountVerified   = "ACMEAccountVerified"
 
+	warningInsecureSkipVerify = "InsecureSkipVerify"
+
 	messageAccountRegistrationFailed = "Failed to register ACME account: "
 	messageAccountVerificationFailed = "Failed to verify ACME account: "
 	messageAccountRegistered         = "The ACME account was registered with the ACME server"
 	messageAccountVerified           = "The ACME account was verified with the ACME server"
+	messageInsecureSkipVerify        = "This issuer is configured with skipTLSVerify - TLS certificate verification of the ACME server is disabled, which is insecure and should only be used in air-gapped lab environments"
 )
 
-func (a *Acme) Setup() (v1alpha1.IssuerStatus, error) {
+func (a *Acme) Setup(ctx context.Context) (v1alpha1.IssuerStatus, error) {
+	if err := a.handleAccountDeactivationAnnotation(); err != nil {
+		return a.issuer.Status, err
+	}
+
+	if rolledOver, err := a.handleAccountRolloverAnnotation(ctx); err != nil {
+		return a.issuer.Status, err
+	} else if rolledOver {
+		return a.issuer.Status, nil
+	}
+
 	update := a.issuer.DeepCopy()
 
+	if a.issuer.Spec.ACME.SkipTLSVerify {
+		a.recorder.Event(a.issuer, v1.EventTypeWarning, warningInsecureSkipVerify, messageInsecureSkipVerify)
+	}
+
 	accountPrivKey, err := kube.SecretTLSKey(a.secretsLister, a.issuer.Namespace, a.issuer.Spec.ACME.PrivateKey)
 
 	if k8sErrors.IsNotFound(err) {
-		accountPrivKey, err = a.createAccountPrivateKey()
+		accountPrivKey, err = a.createAccountPrivateKey(ctx)
+	}
+
+	if err != nil {
+		s := messageAccountRegistrationFailed + err.Error()
+		update.UpdateStatusCondition(v1alpha1.IssuerConditionReady, v1alpha1.ConditionFalse, errorAccountRegistrationFailed, s)
+		return update.Status, fmt.Errorf(s)
 	}
 
+	httpClient, err := a.httpClient()
 	if err != nil {
 		s := messageAccountRegistrationFailed + err.Error()
 		update.UpdateStatusCondition(v1alpha1.IssuerConditionReady, v1alpha1.ConditionFalse, errorAccountRegistrationFailed, s)
@@ -48,9 +72,10 @@ func (a *Acme) Setup() (v1alpha1.IssuerStatus, error) {
 	cl := acme.Client{
 		Key:          accountPrivKey,
 		DirectoryURL: a.issuer.Spec.ACME.Server,
+		HTTPClient:   httpClient,
 	}
 
-	_, err = cl.GetReg(context.Background(), a.issuer.Status.ACMEStatus().URI)
+	_, err = cl.GetReg(ctx, a.issuer.Status.ACMEStatus().URI)
 
 	if err == nil {
 		update.UpdateStatusCondition(v1alpha1.IssuerConditionReady, v1alpha1.ConditionTrue, successAccountVerified, messageAccountVerified)
@@ -65,8 +90,7 @@ func (a *Acme) Setup() (v1alpha1.IssuerStatus, error) {
 		Contact: []string{fmt.Sprintf("mailto:%s", strings.ToLower(a.issuer.Spec.ACME.Email))},
 	}
 
-	// todo (@munnerz): don't use ctx.Background() here
-	account, err := cl.Register(context.Background(), acc, acme.AcceptTOS)
+	account, err := cl.Register(ctx, acc, acme.AcceptTOS)
 
 	if err != nil {
 		s := messageAccountRegistrationFailed + err.Error()
@@ -80,14 +104,14 @@ func (a *Acme) Setup() (v1alpha1.IssuerStatus, error) {
 	return update.Status, nil
 }
 
-func (a *Acme) createAccountPrivateKey() (*rsa.PrivateKey, error) {
+func (a *Acme) createAccountPrivateKey(ctx context.Context) (*rsa.PrivateKey, error) {
 	accountPrivKey, err := pki.GenerateRSAPrivateKey(2048)
 
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = kube.EnsureSecret(a.client, &v1.Secret{
+	_, err = kube.EnsureSecret(ctx, a.client, &v1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      a.issuer.Spec.ACME.PrivateKey,
 			Namespace: a.issuer.Namespace,
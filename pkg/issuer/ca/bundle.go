@@ -0,0 +1,43 @@
+package ca
+
+import (
+	"bytes"
+	"time"
+
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
+
+// buildCABundle returns the PEM encoded ca.crt bundle that should be stored
+// alongside a certificate issued or renewed by the given CA issuer.
+//
+// If the target secret already has a ca.crt entry for a different signing
+// CA certificate, and the issuer has a CATrustBundleOverlap configured, the
+// previous entry is retained alongside the current one so that clients who
+// have not yet refreshed their trust store don't break mid-rotation. With no
+// overlap configured, ca.crt always contains only the current signing CA
+// certificate.
+func buildCABundle(secretsLister corelisters.SecretLister, namespace, secretName, caKey string, issuerCA *v1alpha1.CAIssuer, signerCertPEM []byte) []byte {
+	if issuerCA.CATrustBundleOverlap == "" {
+		return signerCertPEM
+	}
+
+	if _, err := time.ParseDuration(issuerCA.CATrustBundleOverlap); err != nil {
+		return signerCertPEM
+	}
+
+	existing, err := secretsLister.Secrets(namespace).Get(secretName)
+	if err != nil || existing == nil {
+		return signerCertPEM
+	}
+
+	existingCA := existing.Data[caKey]
+	if len(existingCA) == 0 || bytes.Contains(existingCA, signerCertPEM) {
+		// nothing to merge - either there's no previous bundle, or it
+		// already contains the current signing certificate
+		return signerCertPEM
+	}
+
+	return append(append([]byte{}, signerCertPEM...), existingCA...)
+}
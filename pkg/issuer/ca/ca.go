@@ -4,6 +4,7 @@ import (
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
 	corelisters "k8s.io/client-go/listers/core/v1"
@@ -24,6 +25,11 @@ type CA struct {
 	cmclient      clientset.Interface
 	recorder      record.EventRecorder
 	secretsLister corelisters.SecretLister
+
+	// clock is used to determine the current time, and is overridden in
+	// tests so that NotBefore/NotAfter and renewal math can be verified
+	// deterministically.
+	clock clock.Clock
 }
 
 func NewCA(issuer *v1alpha1.Issuer,
@@ -38,6 +44,7 @@ func NewCA(issuer *v1alpha1.Issuer,
 		cmclient:      cmclient,
 		recorder:      recorder,
 		secretsLister: secretsLister,
+		clock:         clock.RealClock{},
 	}, nil
 }
 
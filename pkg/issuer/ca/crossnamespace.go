@@ -0,0 +1,56 @@
+package ca
+
+import (
+	"fmt"
+	"strings"
+
+	api "k8s.io/api/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
+
+// signerNamespace returns the namespace the issuer's signing Secret
+// (Spec.CA.SecretRef) should be read from: Spec.CA.SecretNamespace if set,
+// otherwise the Issuer's own namespace.
+func (c *CA) signerNamespace() string {
+	if ns := c.issuer.Spec.CA.SecretNamespace; ns != "" {
+		return ns
+	}
+	return c.issuer.Namespace
+}
+
+// checkCrossNamespaceReference verifies that the issuer is allowed to read
+// its signing Secret from namespace, when that differs from the Issuer's
+// own namespace, by checking the Secret's
+// AnnotationAllowedReferenceNamespaces annotation. It is a no-op when
+// Spec.CA.SecretNamespace is unset, since an Issuer always has permission
+// to read Secrets in its own namespace.
+func checkCrossNamespaceReference(secretsLister corelisters.SecretLister, issuer *v1alpha1.Issuer, namespace string) error {
+	if issuer.Spec.CA.SecretNamespace == "" || issuer.Spec.CA.SecretNamespace == issuer.Namespace {
+		return nil
+	}
+
+	secret, err := secretsLister.Secrets(namespace).Get(issuer.Spec.CA.SecretRef.Name)
+	if err != nil {
+		return err
+	}
+
+	if !referenceGranted(secret, issuer.Namespace) {
+		return fmt.Errorf("secret %s/%s does not grant namespace %q permission to reference it via the %s annotation", namespace, secret.Name, issuer.Namespace, v1alpha1.AnnotationAllowedReferenceNamespaces)
+	}
+
+	return nil
+}
+
+// referenceGranted reports whether secret's AnnotationAllowedReferenceNamespaces
+// annotation grants namespace permission to reference it.
+func referenceGranted(secret *api.Secret, namespace string) bool {
+	for _, allowed := range strings.Split(secret.Annotations[v1alpha1.AnnotationAllowedReferenceNamespaces], ",") {
+		allowed = strings.TrimSpace(allowed)
+		if allowed == "*" || allowed == namespace {
+			return true
+		}
+	}
+	return false
+}
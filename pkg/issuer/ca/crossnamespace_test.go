@@ -0,0 +1,121 @@
+package ca
+
+import (
+	"testing"
+
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
+
+// TestReferenceGranted verifies that referenceGranted honours a wildcard
+// or exact, comma-separated, whitespace-tolerant namespace list.
+func TestReferenceGranted(t *testing.T) {
+	tests := []struct {
+		name      string
+		allowed   string
+		namespace string
+		want      bool
+	}{
+		{"unset annotation denies", "", "tenant", false},
+		{"wildcard allows any namespace", "*", "tenant", true},
+		{"exact match allows", "tenant", "tenant", true},
+		{"one of several allows", "other, tenant , another", "tenant", true},
+		{"no match denies", "other,another", "tenant", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &api.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{v1alpha1.AnnotationAllowedReferenceNamespaces: tt.allowed},
+				},
+			}
+			if got := referenceGranted(secret, tt.namespace); got != tt.want {
+				t.Errorf("referenceGranted(%q, %q) = %v, want %v", tt.allowed, tt.namespace, got, tt.want)
+			}
+		})
+	}
+}
+
+func newTestCASecretLister(t *testing.T, secrets ...*api.Secret) corelisters.SecretLister {
+	t.Helper()
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, secret := range secrets {
+		if err := indexer.Add(secret); err != nil {
+			t.Fatalf("error adding secret to indexer: %s", err.Error())
+		}
+	}
+	return corelisters.NewSecretLister(indexer)
+}
+
+// TestCheckCrossNamespaceReference verifies that checkCrossNamespaceReference
+// is a no-op when SecretNamespace is unset or equal to the Issuer's own
+// namespace, and otherwise enforces the referenced Secret's
+// AnnotationAllowedReferenceNamespaces grant.
+func TestCheckCrossNamespaceReference(t *testing.T) {
+	t.Run("no-op when SecretNamespace is unset", func(t *testing.T) {
+		issuer := &v1alpha1.Issuer{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tenant"},
+			Spec:       v1alpha1.IssuerSpec{CA: &v1alpha1.CAIssuer{SecretRef: v1alpha1.LocalObjectReference{Name: "ca-keypair"}}},
+		}
+		if err := checkCrossNamespaceReference(newTestCASecretLister(t), issuer, "tenant"); err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+	})
+
+	t.Run("denies a reference the Secret doesn't grant", func(t *testing.T) {
+		issuer := &v1alpha1.Issuer{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tenant"},
+			Spec: v1alpha1.IssuerSpec{CA: &v1alpha1.CAIssuer{
+				SecretRef:       v1alpha1.LocalObjectReference{Name: "ca-keypair"},
+				SecretNamespace: "shared",
+			}},
+		}
+		secret := &api.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "shared", Name: "ca-keypair"}}
+		if err := checkCrossNamespaceReference(newTestCASecretLister(t, secret), issuer, "shared"); err == nil {
+			t.Fatalf("expected an error for a Secret that doesn't grant this namespace access")
+		}
+	})
+
+	t.Run("allows a reference the Secret grants", func(t *testing.T) {
+		issuer := &v1alpha1.Issuer{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tenant"},
+			Spec: v1alpha1.IssuerSpec{CA: &v1alpha1.CAIssuer{
+				SecretRef:       v1alpha1.LocalObjectReference{Name: "ca-keypair"},
+				SecretNamespace: "shared",
+			}},
+		}
+		secret := &api.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "shared",
+				Name:        "ca-keypair",
+				Annotations: map[string]string{v1alpha1.AnnotationAllowedReferenceNamespaces: "tenant"},
+			},
+		}
+		if err := checkCrossNamespaceReference(newTestCASecretLister(t, secret), issuer, "shared"); err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+	})
+}
+
+// TestSignerNamespace verifies that signerNamespace defaults to the
+// Issuer's own namespace, and honours SecretNamespace when set.
+func TestSignerNamespace(t *testing.T) {
+	c := &CA{issuer: &v1alpha1.Issuer{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tenant"},
+		Spec:       v1alpha1.IssuerSpec{CA: &v1alpha1.CAIssuer{}},
+	}}
+	if got := c.signerNamespace(); got != "tenant" {
+		t.Errorf("expected default namespace %q, got %q", "tenant", got)
+	}
+
+	c.issuer.Spec.CA.SecretNamespace = "shared"
+	if got := c.signerNamespace(); got != "shared" {
+		t.Errorf("expected SecretNamespace %q, got %q", "shared", got)
+	}
+}
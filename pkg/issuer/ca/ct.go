@@ -0,0 +1,64 @@
+package ca
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/ctlog"
+)
+
+// signCertificateWithCT signs crt the same way signCertificate does, but
+// first signs and submits a precertificate to the issuer's configured CT
+// log, embedding the resulting SCT in the final certificate (RFC 6962
+// section 3.1). The precertificate and final certificate share the same
+// serial number, validity period and public key, as the protocol
+// requires - only the poison extension is swapped for the SCT list
+// extension between the two signing passes. ctx is checked before the log
+// submission is made; the ctlog.Client it's submitted through doesn't yet
+// accept a context itself.
+func (c *CA) signCertificateWithCT(ctx context.Context, crt *v1alpha1.Certificate, issuerCert *x509.Certificate, publicKey, signerKey interface{}) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	precertTemplate, err := createCertificateTemplate(crt, publicKey, c.issuer.Spec.CA.SerialNumberBits, c.clock)
+	if err != nil {
+		return nil, fmt.Errorf("error creating x509 certificate template: %s", err.Error())
+	}
+	precertTemplate.ExtraExtensions = append(precertTemplate.ExtraExtensions, ctlog.PoisonExtension())
+
+	precertDER, err := x509.CreateCertificate(rand.Reader, precertTemplate, issuerCert, publicKey, signerKey)
+	if err != nil {
+		return nil, fmt.Errorf("error creating precertificate: %s", err.Error())
+	}
+
+	logClient := ctlog.New(c.issuer.Spec.CA.CTLogURL, nil)
+	sct, err := logClient.SubmitPreChain(precertDER, issuerCert.Raw)
+	if err != nil {
+		return nil, fmt.Errorf("error submitting precertificate to ct log %q: %s", c.issuer.Spec.CA.CTLogURL, err.Error())
+	}
+
+	sctExt, err := ctlog.SCTListExtension(sct)
+	if err != nil {
+		return nil, fmt.Errorf("error building sct list extension: %s", err.Error())
+	}
+
+	finalTemplate := *precertTemplate
+	finalTemplate.ExtraExtensions = []pkix.Extension{sctExt}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, &finalTemplate, issuerCert, publicKey, signerKey)
+	if err != nil {
+		return nil, fmt.Errorf("error creating x509 certificate: %s", err.Error())
+	}
+
+	pemBytes := bytes.NewBuffer([]byte{})
+	if err := pem.Encode(pemBytes, &pem.Block{Type: "CERTIFICATE", Bytes: derBytes}); err != nil {
+		return nil, fmt.Errorf("error encoding certificate PEM: %s", err.Error())
+	}
+	return pemBytes.Bytes(), nil
+}
@@ -0,0 +1,169 @@
+package ca
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/golang/glog"
+	api "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/kube"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/pki"
+)
+
+const (
+	errorDelegatedCA = "ErrDelegatedCA"
+
+	successDelegatedCA = "DelegatedCAProvisioned"
+
+	messageErrorDelegatedCA = "Error provisioning delegated CA for namespace %s: %s"
+
+	messageDelegatedCAProvisioned = "Provisioned delegated intermediate CA secret %s/%s and Issuer %s/%s"
+
+	// delegatedCADuration is long relative to a leaf certificate's default
+	// 1 year (see certificateDuration) since rotating an intermediate
+	// requires every tenant Issuer using it to be re-provisioned.
+	delegatedCADuration = time.Hour * 24 * 365 * 5
+)
+
+// provisionDelegatedNamespaces mints a Name Constraints-limited intermediate
+// signing certificate for each entry in spec.CA.DelegatedNamespaces, and
+// writes it to a Secret plus a matching Issuer in the target namespace. It
+// is run every time Setup succeeds, so a change to Domains, or a missing
+// Secret/Issuer, is corrected on the next resync rather than only at
+// creation time.
+func (c *CA) provisionDelegatedNamespaces(ctx context.Context, signerCert *x509.Certificate, signerKey interface{}) error {
+	for _, delegated := range c.issuer.Spec.CA.DelegatedNamespaces {
+		if err := c.provisionDelegatedNamespace(ctx, signerCert, signerKey, delegated); err != nil {
+			s := fmt.Sprintf(messageErrorDelegatedCA, delegated.Namespace, err.Error())
+			glog.Error(s)
+			c.recorder.Event(c.issuer, api.EventTypeWarning, errorDelegatedCA, s)
+			return fmt.Errorf(s)
+		}
+	}
+	return nil
+}
+
+func (c *CA) provisionDelegatedNamespace(ctx context.Context, signerCert *x509.Certificate, signerKey interface{}, delegated v1alpha1.CADelegatedNamespace) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	secretName := delegated.SecretName
+	if secretName == "" {
+		secretName = c.issuer.Name + "-delegated-ca"
+	}
+	issuerName := delegated.IssuerName
+	if issuerName == "" {
+		issuerName = secretName
+	}
+
+	certPem, keyPem, err := signDelegatedCA(signerCert, signerKey, delegated, c.clock)
+	if err != nil {
+		return err
+	}
+
+	secret, err := kube.EnsureSecret(ctx, c.cl, &api.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: delegated.Namespace,
+		},
+		Type: api.SecretTypeTLS,
+		Data: map[string][]byte{
+			api.TLSCertKey:       certPem,
+			api.TLSPrivateKeyKey: keyPem,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error writing delegated CA secret: %s", err.Error())
+	}
+
+	delegatedIssuer := &v1alpha1.Issuer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      issuerName,
+			Namespace: delegated.Namespace,
+		},
+		Spec: v1alpha1.IssuerSpec{
+			CA: &v1alpha1.CAIssuer{
+				SecretRef: v1alpha1.LocalObjectReference{
+					Name: secret.Name,
+				},
+			},
+		},
+	}
+
+	_, err = c.cmclient.CertmanagerV1alpha1().Issuers(delegated.Namespace).Create(delegatedIssuer)
+	if k8sErrors.IsAlreadyExists(err) {
+		existing, getErr := c.cmclient.CertmanagerV1alpha1().Issuers(delegated.Namespace).Get(issuerName, metav1.GetOptions{})
+		if getErr != nil {
+			return fmt.Errorf("error getting existing delegated Issuer: %s", getErr.Error())
+		}
+		existing.Spec.CA = delegatedIssuer.Spec.CA
+		_, err = c.cmclient.CertmanagerV1alpha1().Issuers(delegated.Namespace).Update(existing)
+	}
+	if err != nil {
+		return fmt.Errorf("error creating delegated Issuer: %s", err.Error())
+	}
+
+	s := fmt.Sprintf(messageDelegatedCAProvisioned, delegated.Namespace, secretName, delegated.Namespace, issuerName)
+	glog.Info(s)
+	c.recorder.Event(c.issuer, api.EventTypeNormal, successDelegatedCA, s)
+	return nil
+}
+
+// signDelegatedCA mints an intermediate CA keypair signed by signerCert/
+// signerKey, restricted by an x509 Name Constraints extension to only the
+// DNS domains listed in delegated.Domains. clk is used to compute
+// NotBefore/NotAfter, so callers can inject a clock.FakeClock in tests.
+func signDelegatedCA(signerCert *x509.Certificate, signerKey interface{}, delegated v1alpha1.CADelegatedNamespace, clk clock.Clock) (certPem, keyPem []byte, err error) {
+	key, err := pki.GenerateRSAPrivateKey(pki.DefaultRSAKeySize)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating delegated CA private key: %s", err.Error())
+	}
+
+	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), defaultSerialNumberBits)
+	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating serial number: %s", err.Error())
+	}
+
+	now := clk.Now()
+	template := &x509.Certificate{
+		Version:      3,
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			Organization: []string{defaultOrganization},
+			CommonName:   fmt.Sprintf("%s intermediate CA", delegated.Namespace),
+		},
+		NotBefore:                   now,
+		NotAfter:                    now.Add(delegatedCADuration),
+		KeyUsage:                    x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid:       true,
+		IsCA:                        true,
+		PermittedDNSDomainsCritical: true,
+		PermittedDNSDomains:         delegated.Domains,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, &key.PublicKey, signerKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error signing delegated intermediate CA certificate: %s", err.Error())
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := pem.Encode(buf, &pem.Block{Type: "CERTIFICATE", Bytes: der}); err != nil {
+		return nil, nil, fmt.Errorf("error encoding delegated CA certificate: %s", err.Error())
+	}
+
+	return buf.Bytes(), pki.EncodePKCS1PrivateKey(key), nil
+}
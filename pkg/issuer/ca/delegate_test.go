@@ -0,0 +1,92 @@
+package ca
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/pki"
+)
+
+func generateTestSigner(t *testing.T, commonName string) (*x509.Certificate, interface{}) {
+	t.Helper()
+
+	key, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatalf("error generating signer key: %s", err.Error())
+	}
+
+	template, err := createCertificateTemplate(&v1alpha1.Certificate{
+		Spec: v1alpha1.CertificateSpec{Domains: []string{commonName}},
+	}, &key.PublicKey, 0, clock.RealClock{})
+	if err != nil {
+		t.Fatalf("error building signer template: %s", err.Error())
+	}
+	template.IsCA = true
+	template.KeyUsage |= x509.KeyUsageCertSign
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("error self-signing test signer certificate: %s", err.Error())
+	}
+
+	cert, err := pki.DecodeDERCertificateBytes(der)
+	if err != nil {
+		t.Fatalf("error decoding self-signed signer certificate: %s", err.Error())
+	}
+
+	return cert, key
+}
+
+// TestSignDelegatedCA verifies that signDelegatedCA mints an intermediate
+// CA restricted to delegated.Domains via Name Constraints, with
+// NotBefore/NotAfter computed from the injected clock rather than the real
+// wall clock.
+func TestSignDelegatedCA(t *testing.T) {
+	signerCert, signerKey := generateTestSigner(t, "root-ca")
+
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(now)
+
+	delegated := v1alpha1.CADelegatedNamespace{Namespace: "tenant", Domains: []string{"tenant.example.com"}}
+
+	certPem, keyPem, err := signDelegatedCA(signerCert, signerKey, delegated, fakeClock)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	certBlock, _ := pem.Decode(certPem)
+	if certBlock == nil {
+		t.Fatalf("expected a PEM encoded certificate")
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		t.Fatalf("error parsing delegated CA certificate: %s", err.Error())
+	}
+
+	if !cert.NotBefore.Equal(now) {
+		t.Errorf("expected NotBefore to come from the injected clock, got %s, want %s", cert.NotBefore, now)
+	}
+	if want := now.Add(delegatedCADuration); !cert.NotAfter.Equal(want) {
+		t.Errorf("expected NotAfter %s, got %s", want, cert.NotAfter)
+	}
+	if !cert.IsCA {
+		t.Errorf("expected a CA certificate")
+	}
+	if len(cert.PermittedDNSDomains) != 1 || cert.PermittedDNSDomains[0] != "tenant.example.com" {
+		t.Errorf("expected PermittedDNSDomains %v, got %v", delegated.Domains, cert.PermittedDNSDomains)
+	}
+	if !cert.PermittedDNSDomainsCritical {
+		t.Errorf("expected the Name Constraints extension to be marked critical")
+	}
+
+	keyBlock, _ := pem.Decode(keyPem)
+	if keyBlock == nil || keyBlock.Type != "RSA PRIVATE KEY" {
+		t.Errorf("expected a PEM encoded RSA private key, got: %s", keyPem)
+	}
+}
@@ -2,6 +2,7 @@ package ca
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
@@ -10,20 +11,25 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/golang/glog"
 	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
 	"github.com/jetstack-experimental/cert-manager/pkg/util/kube"
 	"github.com/jetstack-experimental/cert-manager/pkg/util/pki"
+	api "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/clock"
 )
 
 const (
 	errorGetCertKeyPair = "ErrGetCertKeyPair"
 	errorIssueCert      = "ErrIssueCert"
+	errorDeniedDomain   = "ErrDeniedDomain"
 
 	successCertIssued = "CertIssueSuccess"
 
 	messageErrorGetCertKeyPair = "Error getting keypair for certificate: "
 	messageErrorIssueCert      = "Error issuing TLS certificate: "
+	messageErrorDeniedDomain   = "Certificate denied by issuer policy: "
 
 	messageCertIssued = "Certificate issued successfully"
 )
@@ -32,50 +38,90 @@ const (
 	// certificateDuration of 1 year
 	certificateDuration = time.Hour * 24 * 365
 	defaultOrganization = "cert-manager"
+
+	// defaultSerialNumberBits is used when an issuer does not specify
+	// SerialNumberBits, matching the previous fixed 128-bit behaviour.
+	defaultSerialNumberBits = 128
 )
 
-func (c *CA) Issue(crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, []byte, []byte, error) {
+func (c *CA) Issue(ctx context.Context, crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, []byte, []byte, []byte, error) {
 	update := crt.DeepCopy()
 
-	signeeKey, err := kube.SecretTLSKey(c.secretsLister, c.issuer.Namespace, crt.Spec.SecretName)
+	if err := checkAllowedDNSZones(c.issuer, crt); err != nil {
+		s := messageErrorDeniedDomain + err.Error()
+		update.UpdateStatusCondition(v1alpha1.CertificateConditionDenied, v1alpha1.ConditionTrue, errorDeniedDomain, s)
+		return update.Status, nil, nil, nil, err
+	}
+
+	keySize := crt.Spec.KeySize
+	if keySize == 0 {
+		keySize = pki.DefaultRSAKeySize
+	}
+
+	signeeKey, err := kube.SecretTLSKeyRef(c.secretsLister, c.issuer.Namespace, crt.Spec.SecretName, crt.PrivateKeyKey())
+	regenerate := k8sErrors.IsNotFound(err)
+
+	if err == nil && !pki.PrivateKeyMatchesSize(signeeKey, keySize) {
+		regenerate = true
+		err = nil
+	}
 
-	if k8sErrors.IsNotFound(err) {
-		signeeKey, err = pki.GenerateRSAPrivateKey(2048)
+	if regenerate {
+		signeeKey, err = pki.GenerateRSAPrivateKey(keySize)
 	}
 
 	if err != nil {
 		s := messageErrorGetCertKeyPair + err.Error()
 		update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionFalse, errorGetCertKeyPair, s)
-		return update.Status, nil, nil, err
+		return update.Status, nil, nil, nil, err
 	}
 
-	certPem, err := c.obtainCertificate(crt, &signeeKey.PublicKey)
+	certPem, err := c.obtainCertificate(ctx, crt, &signeeKey.PublicKey)
 
 	if err != nil {
 		s := messageErrorIssueCert + err.Error()
 		update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionFalse, errorIssueCert, s)
-		return update.Status, nil, nil, err
+		return update.Status, nil, nil, nil, err
 	}
 
 	update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionTrue, successCertIssued, messageCertIssued)
+	update.UpdateStatusCondition(v1alpha1.CertificateConditionDenied, v1alpha1.ConditionFalse, successCertIssued, messageCertIssued)
 
-	return update.Status, pki.EncodePKCS1PrivateKey(signeeKey), certPem, nil
+	var caPem []byte
+	if signerSecret, err := c.secretsLister.Secrets(c.signerNamespace()).Get(c.issuer.Spec.CA.SecretRef.Name); err == nil {
+		caPem = buildCABundle(c.secretsLister, crt.Namespace, crt.Spec.SecretName, crt.CAKey(), c.issuer.Spec.CA, signerSecret.Data[api.TLSCertKey])
+	}
+
+	return update.Status, pki.EncodePKCS1PrivateKey(signeeKey), certPem, caPem, nil
 }
 
-func (c *CA) obtainCertificate(crt *v1alpha1.Certificate, signeeKey interface{}) ([]byte, error) {
-	signerCert, err := kube.SecretTLSCert(c.secretsLister, c.issuer.Namespace, c.issuer.Spec.CA.SecretRef.Name)
+func (c *CA) obtainCertificate(ctx context.Context, crt *v1alpha1.Certificate, signeeKey interface{}) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	secretNamespace := c.signerNamespace()
+	if err := checkCrossNamespaceReference(c.secretsLister, c.issuer, secretNamespace); err != nil {
+		return nil, fmt.Errorf("error checking issuer secret reference: %s", err.Error())
+	}
+
+	signerCert, err := kube.SecretTLSCert(c.secretsLister, secretNamespace, c.issuer.Spec.CA.SecretRef.Name)
 
 	if err != nil {
 		return nil, fmt.Errorf("error getting issuer certificate: %s", err.Error())
 	}
 
-	signerKey, err := kube.SecretTLSKey(c.secretsLister, c.issuer.Namespace, c.issuer.Spec.CA.SecretRef.Name)
+	signerKey, err := kube.SecretTLSKey(c.secretsLister, secretNamespace, c.issuer.Spec.CA.SecretRef.Name)
 
 	if err != nil {
 		return nil, fmt.Errorf("error getting issuer private key: %s", err.Error())
 	}
 
-	crtPem, _, err := signCertificate(crt, signerCert, signeeKey, signerKey)
+	if c.issuer.Spec.CA.CTLogURL != "" {
+		return c.signCertificateWithCT(ctx, crt, signerCert, signeeKey, signerKey)
+	}
+
+	crtPem, _, err := signCertificate(crt, signerCert, signeeKey, signerKey, c.issuer.Spec.CA.SerialNumberBits, c.clock)
 
 	if err != nil {
 		return nil, err
@@ -84,13 +130,58 @@ func (c *CA) obtainCertificate(crt *v1alpha1.Certificate, signeeKey interface{})
 	return crtPem, nil
 }
 
-func createCertificateTemplate(crt *v1alpha1.Certificate, publicKey interface{}) (*x509.Certificate, error) {
-	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
+func createCertificateTemplate(crt *v1alpha1.Certificate, publicKey interface{}, serialNumberBits int, clk clock.Clock) (*x509.Certificate, error) {
+	if serialNumberBits <= 0 {
+		serialNumberBits = defaultSerialNumberBits
+	}
+	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), uint(serialNumberBits))
 	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate serial number: %s", err.Error())
 	}
 
+	notBefore := clk.Now()
+	if crt.Spec.NotBeforeSkew != "" {
+		skew, err := time.ParseDuration(crt.Spec.NotBeforeSkew)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing notBeforeSkew %q: %s", crt.Spec.NotBeforeSkew, err.Error())
+		}
+		notBefore = notBefore.Add(-skew)
+	}
+
+	duration := certificateDuration
+	if crt.Spec.Duration != "" {
+		d, err := time.ParseDuration(crt.Spec.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing requested certificate duration %q: %s", crt.Spec.Duration, err.Error())
+		}
+		duration = d
+	}
+
+	keyUsage := x509.KeyUsageDigitalSignature
+	var extKeyUsage []x509.ExtKeyUsage
+
+	// EmailAddresses requests an S/MIME-style certificate: it needs to be
+	// able to both sign and encrypt for a recipient's mail client to accept
+	// it, and needs the emailProtection EKU so that use is unambiguous.
+	if len(crt.Spec.EmailAddresses) > 0 {
+		keyUsage |= x509.KeyUsageKeyEncipherment
+		extKeyUsage = append(extKeyUsage, x509.ExtKeyUsageEmailProtection)
+	}
+
+	// CodeSigning requests a code-signing certificate: signing artifacts
+	// only needs digital signature, but the codeSigning EKU is required for
+	// verifiers to accept the certificate for that purpose.
+	if crt.Spec.CodeSigning {
+		extKeyUsage = append(extKeyUsage, x509.ExtKeyUsageCodeSigning)
+	}
+
+	dnsNames := crt.Spec.Domains
+	if crt.Spec.CommonNameOnly {
+		glog.Warningf("issuing certificate %s/%s with no SAN extension at all, identified only by its CommonName, as requested by spec.commonNameOnly - most modern TLS clients will refuse to validate it", crt.Namespace, crt.Name)
+		dnsNames = nil
+	}
+
 	cert := &x509.Certificate{
 		Version:               3,
 		BasicConstraintsValid: true,
@@ -101,21 +192,35 @@ func createCertificateTemplate(crt *v1alpha1.Certificate, publicKey interface{})
 			Organization: []string{defaultOrganization},
 			CommonName:   crt.Spec.Domains[0],
 		},
-		NotBefore: time.Now(),
-		NotAfter:  time.Now().Add(certificateDuration),
+		NotBefore: notBefore,
+		NotAfter:  notBefore.Add(duration),
 		// see http://golang.org/pkg/crypto/x509/#KeyUsage
-		KeyUsage: x509.KeyUsageDigitalSignature,
-		DNSNames: crt.Spec.Domains,
+		KeyUsage:       keyUsage,
+		ExtKeyUsage:    extKeyUsage,
+		DNSNames:       dnsNames,
+		EmailAddresses: crt.Spec.EmailAddresses,
 	}
 	return cert, nil
 }
 
+// SignCertificate creates and signs an x509 certificate for crt using the
+// given CA keypair, honouring the same NotBeforeSkew, Duration and
+// SerialNumberBits fields that the CA issuer controller does. Unlike the CA
+// issuer, it requires no Kubernetes API access or running controller, and is
+// the building block used by pkg/certmanager's standalone Client. clk is
+// used to compute NotBefore/NotAfter; pass clock.RealClock{} in production
+// code, or a clock.FakeClock to test expiry/renewal behaviour
+// deterministically.
+func SignCertificate(crt *v1alpha1.Certificate, issuerCert *x509.Certificate, publicKey interface{}, signerKey interface{}, serialNumberBits int, clk clock.Clock) ([]byte, *x509.Certificate, error) {
+	return signCertificate(crt, issuerCert, publicKey, signerKey, serialNumberBits, clk)
+}
+
 // signCertificate returns a signed x509.Certificate object for the given
 // *v1alpha1.Certificate crt.
 // publicKey is the public key of the signee, and signerKey is the private
 // key of the signer.
-func signCertificate(crt *v1alpha1.Certificate, issuerCert *x509.Certificate, publicKey interface{}, signerKey interface{}) ([]byte, *x509.Certificate, error) {
-	template, err := createCertificateTemplate(crt, publicKey)
+func signCertificate(crt *v1alpha1.Certificate, issuerCert *x509.Certificate, publicKey interface{}, signerKey interface{}, serialNumberBits int, clk clock.Clock) ([]byte, *x509.Certificate, error) {
+	template, err := createCertificateTemplate(crt, publicKey, serialNumberBits, clk)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating x509 certificate template: %s", err.Error())
 	}
@@ -0,0 +1,182 @@
+package ca
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/pki"
+)
+
+// TestCreateCertificateTemplateUsesInjectedClock verifies that
+// createCertificateTemplate computes NotBefore/NotAfter relative to the
+// provided clock, rather than the real wall clock, honouring
+// Spec.NotBeforeSkew and Spec.Duration when set.
+func TestCreateCertificateTemplateUsesInjectedClock(t *testing.T) {
+	now := time.Date(2020, 6, 15, 12, 0, 0, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(now)
+
+	key, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatalf("error generating test key: %s", err.Error())
+	}
+
+	crt := &v1alpha1.Certificate{
+		Spec: v1alpha1.CertificateSpec{
+			Domains:       []string{"example.com"},
+			NotBeforeSkew: "5m",
+			Duration:      "48h",
+		},
+	}
+
+	template, err := createCertificateTemplate(crt, &key.PublicKey, 0, fakeClock)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	wantNotBefore := now.Add(-5 * time.Minute)
+	if !template.NotBefore.Equal(wantNotBefore) {
+		t.Errorf("expected NotBefore %s, got %s", wantNotBefore, template.NotBefore)
+	}
+	wantNotAfter := wantNotBefore.Add(48 * time.Hour)
+	if !template.NotAfter.Equal(wantNotAfter) {
+		t.Errorf("expected NotAfter %s, got %s", wantNotAfter, template.NotAfter)
+	}
+}
+
+// TestCreateCertificateTemplateDefaults verifies that
+// createCertificateTemplate falls back to certificateDuration and
+// defaultSerialNumberBits when Spec.Duration/serialNumberBits are unset.
+func TestCreateCertificateTemplateDefaults(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(now)
+
+	key, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatalf("error generating test key: %s", err.Error())
+	}
+
+	crt := &v1alpha1.Certificate{Spec: v1alpha1.CertificateSpec{Domains: []string{"example.com"}}}
+
+	template, err := createCertificateTemplate(crt, &key.PublicKey, 0, fakeClock)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if !template.NotBefore.Equal(now) {
+		t.Errorf("expected NotBefore %s, got %s", now, template.NotBefore)
+	}
+	if want := now.Add(certificateDuration); !template.NotAfter.Equal(want) {
+		t.Errorf("expected NotAfter %s, got %s", want, template.NotAfter)
+	}
+	if template.SerialNumber.BitLen() > defaultSerialNumberBits {
+		t.Errorf("expected a serial number of at most %d bits, got %d", defaultSerialNumberBits, template.SerialNumber.BitLen())
+	}
+}
+
+// TestCreateCertificateTemplateCommonNameOnly verifies that
+// Spec.CommonNameOnly strips the SAN extension entirely, rather than just
+// omitting it when empty.
+func TestCreateCertificateTemplateCommonNameOnly(t *testing.T) {
+	key, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatalf("error generating test key: %s", err.Error())
+	}
+
+	crt := &v1alpha1.Certificate{
+		Spec: v1alpha1.CertificateSpec{Domains: []string{"example.com"}, CommonNameOnly: true},
+	}
+
+	template, err := createCertificateTemplate(crt, &key.PublicKey, 0, clock.RealClock{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if len(template.DNSNames) != 0 {
+		t.Errorf("expected no DNS names when commonNameOnly is set, got %v", template.DNSNames)
+	}
+	if template.Subject.CommonName != "example.com" {
+		t.Errorf("expected CommonName %q, got %q", "example.com", template.Subject.CommonName)
+	}
+}
+
+// TestCreateCertificateTemplateKeyUsage verifies that EmailAddresses and
+// CodeSigning each add their specific EKU/KeyUsage bits, on top of the
+// baseline digital signature usage.
+func TestCreateCertificateTemplateKeyUsage(t *testing.T) {
+	key, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatalf("error generating test key: %s", err.Error())
+	}
+
+	t.Run("S/MIME", func(t *testing.T) {
+		crt := &v1alpha1.Certificate{
+			Spec: v1alpha1.CertificateSpec{Domains: []string{"example.com"}, EmailAddresses: []string{"user@example.com"}},
+		}
+		template, err := createCertificateTemplate(crt, &key.PublicKey, 0, clock.RealClock{})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		if template.KeyUsage&x509.KeyUsageKeyEncipherment == 0 {
+			t.Errorf("expected KeyUsageKeyEncipherment for an S/MIME certificate")
+		}
+		if !containsEKU(template.ExtKeyUsage, x509.ExtKeyUsageEmailProtection) {
+			t.Errorf("expected ExtKeyUsageEmailProtection for an S/MIME certificate")
+		}
+	})
+
+	t.Run("code signing", func(t *testing.T) {
+		crt := &v1alpha1.Certificate{
+			Spec: v1alpha1.CertificateSpec{Domains: []string{"example.com"}, CodeSigning: true},
+		}
+		template, err := createCertificateTemplate(crt, &key.PublicKey, 0, clock.RealClock{})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		if !containsEKU(template.ExtKeyUsage, x509.ExtKeyUsageCodeSigning) {
+			t.Errorf("expected ExtKeyUsageCodeSigning for a code-signing certificate")
+		}
+	})
+}
+
+func containsEKU(ekus []x509.ExtKeyUsage, want x509.ExtKeyUsage) bool {
+	for _, eku := range ekus {
+		if eku == want {
+			return true
+		}
+	}
+	return false
+}
+
+// TestSignCertificate verifies that signCertificate produces a PEM encoded
+// certificate issued by issuerCert, with NotBefore taken from the injected
+// clock.
+func TestSignCertificate(t *testing.T) {
+	signerCert, signerKey := generateTestSigner(t, "root-ca")
+
+	now := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(now)
+
+	key, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatalf("error generating leaf key: %s", err.Error())
+	}
+
+	crt := &v1alpha1.Certificate{Spec: v1alpha1.CertificateSpec{Domains: []string{"leaf.example.com"}}}
+
+	pemBytes, cert, err := signCertificate(crt, signerCert, &key.PublicKey, signerKey, 0, fakeClock)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if len(pemBytes) == 0 {
+		t.Fatalf("expected non-empty PEM output")
+	}
+	if !cert.NotBefore.Equal(now) {
+		t.Errorf("expected NotBefore %s, got %s", now, cert.NotBefore)
+	}
+	if err := cert.CheckSignatureFrom(signerCert); err != nil {
+		t.Errorf("expected certificate to be signed by signerCert: %s", err.Error())
+	}
+}
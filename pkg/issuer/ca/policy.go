@@ -0,0 +1,41 @@
+package ca
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
+
+// checkAllowedDNSZones returns an error naming the first of crt's requested
+// domains that falls outside issuer's Spec.CA.AllowedDNSZones, or nil if
+// every domain is allowed. An unset AllowedDNSZones permits any domain.
+func checkAllowedDNSZones(issuer *v1alpha1.Issuer, crt *v1alpha1.Certificate) error {
+	zones := issuer.Spec.CA.AllowedDNSZones
+	if len(zones) == 0 {
+		return nil
+	}
+
+	for _, domain := range crt.Spec.Domains {
+		if !domainInDNSZones(domain, zones) {
+			return fmt.Errorf("domain %q is not within this issuer's allowedDNSZones %v", domain, zones)
+		}
+	}
+
+	return nil
+}
+
+// domainInDNSZones reports whether domain is equal to, or a subdomain of,
+// one of zones.
+func domainInDNSZones(domain string, zones []string) bool {
+	domain = strings.ToLower(strings.TrimPrefix(domain, "*."))
+
+	for _, zone := range zones {
+		zone = strings.ToLower(zone)
+		if domain == zone || strings.HasSuffix(domain, "."+zone) {
+			return true
+		}
+	}
+
+	return false
+}
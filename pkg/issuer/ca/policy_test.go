@@ -0,0 +1,66 @@
+package ca
+
+import (
+	"testing"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
+
+// TestDomainInDNSZones verifies that domainInDNSZones matches a zone
+// exactly or any of its subdomains, case-insensitively, and honours a
+// leading wildcard label on domain.
+func TestDomainInDNSZones(t *testing.T) {
+	zones := []string{"Example.com", "internal.example.org"}
+
+	tests := map[string]bool{
+		"example.com":              true,
+		"EXAMPLE.COM":              true,
+		"www.example.com":          true,
+		"a.b.example.com":          true,
+		"*.example.com":            true,
+		"internal.example.org":     true,
+		"svc.internal.example.org": true,
+		"example.org":              false,
+		"notexample.com":           false,
+		"other.com":                false,
+	}
+
+	for domain, want := range tests {
+		if got := domainInDNSZones(domain, zones); got != want {
+			t.Errorf("domainInDNSZones(%q, %v) = %v, want %v", domain, zones, got, want)
+		}
+	}
+}
+
+// TestCheckAllowedDNSZones verifies that checkAllowedDNSZones allows any
+// domain when AllowedDNSZones is unset, and otherwise rejects the first
+// requested domain that falls outside it.
+func TestCheckAllowedDNSZones(t *testing.T) {
+	issuerWithZones := func(zones ...string) *v1alpha1.Issuer {
+		return &v1alpha1.Issuer{Spec: v1alpha1.IssuerSpec{CA: &v1alpha1.CAIssuer{AllowedDNSZones: zones}}}
+	}
+
+	t.Run("unset allows any domain", func(t *testing.T) {
+		issuer := issuerWithZones()
+		crt := &v1alpha1.Certificate{Spec: v1alpha1.CertificateSpec{Domains: []string{"anything.example.com"}}}
+		if err := checkAllowedDNSZones(issuer, crt); err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+	})
+
+	t.Run("allows domains within a configured zone", func(t *testing.T) {
+		issuer := issuerWithZones("example.com")
+		crt := &v1alpha1.Certificate{Spec: v1alpha1.CertificateSpec{Domains: []string{"www.example.com"}}}
+		if err := checkAllowedDNSZones(issuer, crt); err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+	})
+
+	t.Run("rejects a domain outside every configured zone", func(t *testing.T) {
+		issuer := issuerWithZones("example.com")
+		crt := &v1alpha1.Certificate{Spec: v1alpha1.CertificateSpec{Domains: []string{"www.example.com", "evil.org"}}}
+		if err := checkAllowedDNSZones(issuer, crt); err == nil {
+			t.Fatalf("expected an error for a domain outside the allowed zones")
+		}
+	})
+}
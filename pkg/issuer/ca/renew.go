@@ -1,9 +1,12 @@
 package ca
 
 import (
+	"context"
+
 	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
 	"github.com/jetstack-experimental/cert-manager/pkg/util/kube"
 	"github.com/jetstack-experimental/cert-manager/pkg/util/pki"
+	api "k8s.io/api/core/v1"
 )
 
 const (
@@ -16,26 +19,38 @@ const (
 	messageCertRenewed = "Certificate issued successfully"
 )
 
-func (c *CA) Renew(crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, []byte, []byte, error) {
+func (c *CA) Renew(ctx context.Context, crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, []byte, []byte, []byte, error) {
 	update := crt.DeepCopy()
 
-	signeeKey, err := kube.SecretTLSKey(c.secretsLister, c.issuer.Namespace, crt.Spec.SecretName)
+	if err := checkAllowedDNSZones(c.issuer, crt); err != nil {
+		s := messageErrorDeniedDomain + err.Error()
+		update.UpdateStatusCondition(v1alpha1.CertificateConditionDenied, v1alpha1.ConditionTrue, errorDeniedDomain, s)
+		return update.Status, nil, nil, nil, err
+	}
+
+	signeeKey, err := kube.SecretTLSKeyRef(c.secretsLister, c.issuer.Namespace, crt.Spec.SecretName, crt.PrivateKeyKey())
 
 	if err != nil {
 		s := messageErrorGetCertKeyPair + err.Error()
 		update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionFalse, errorGetCertKeyPair, s)
-		return update.Status, nil, nil, err
+		return update.Status, nil, nil, nil, err
 	}
 
-	certPem, err := c.obtainCertificate(crt, signeeKey)
+	certPem, err := c.obtainCertificate(ctx, crt, signeeKey)
 
 	if err != nil {
 		s := messageErrorRenewCert + err.Error()
 		update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionFalse, errorRenewCert, s)
-		return update.Status, nil, nil, err
+		return update.Status, nil, nil, nil, err
 	}
 
 	update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionTrue, successCertRenewed, messageCertRenewed)
+	update.UpdateStatusCondition(v1alpha1.CertificateConditionDenied, v1alpha1.ConditionFalse, successCertRenewed, messageCertRenewed)
+
+	var caPem []byte
+	if signerSecret, err := c.secretsLister.Secrets(c.signerNamespace()).Get(c.issuer.Spec.CA.SecretRef.Name); err == nil {
+		caPem = buildCABundle(c.secretsLister, crt.Namespace, crt.Spec.SecretName, crt.CAKey(), c.issuer.Spec.CA, signerSecret.Data[api.TLSCertKey])
+	}
 
-	return update.Status, pki.EncodePKCS1PrivateKey(signeeKey), certPem, nil
+	return update.Status, pki.EncodePKCS1PrivateKey(signeeKey), certPem, caPem, nil
 }
@@ -1,10 +1,14 @@
 package ca
 
 import (
+	"context"
+	"crypto/x509"
 	"fmt"
+	"time"
 
 	"k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/golang/glog"
 	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
@@ -17,16 +21,36 @@ const (
 
 	successKeyPairVerified = "KeyPairVerified"
 
+	reasonSignerExpiringSoon = "SignerExpiringSoon"
+	reasonSignerExpiryOK     = "SignerExpiryOK"
+
 	messageErrorGetKeyPair     = "Error getting keypair for CA issuer: "
 	messageErrorInvalidKeyPair = "Invalid signing key pair: "
 
 	messageKeyPairVerified = "Signing CA verified"
+
+	messageSignerExpiringSoon = "Signing CA certificate expires at %s, which is within the configured signerExpiryThreshold of %s - rotate spec.ca.secretRef soon"
+	messageSignerExpiryOK     = "Signing CA certificate is not within its signerExpiryThreshold of expiry"
+
+	// defaultSignerExpiryThreshold is used when CAIssuer.SignerExpiryThreshold
+	// is not set, matching the 30 day renewBefore window the certificates
+	// controller uses for Certificates signed by this issuer.
+	defaultSignerExpiryThreshold = time.Hour * 24 * 30
 )
 
-func (c *CA) Setup() (v1alpha1.IssuerStatus, error) {
+func (c *CA) Setup(ctx context.Context) (v1alpha1.IssuerStatus, error) {
 	update := c.issuer.DeepCopy()
 
-	cert, err := kube.SecretTLSCert(c.secretsLister, update.Namespace, update.Spec.CA.SecretRef.Name)
+	secretNamespace := c.signerNamespace()
+	if err := checkCrossNamespaceReference(c.secretsLister, update, secretNamespace); err != nil {
+		s := messageErrorGetKeyPair + err.Error()
+		glog.Info(s)
+		c.recorder.Event(update, v1.EventTypeWarning, errorGetKeyPair, s)
+		update.UpdateStatusCondition(v1alpha1.IssuerConditionReady, v1alpha1.ConditionFalse, errorGetKeyPair, s)
+		return update.Status, err
+	}
+
+	cert, err := kube.SecretTLSCert(c.secretsLister, secretNamespace, update.Spec.CA.SecretRef.Name)
 
 	if k8sErrors.IsNotFound(err) {
 		s := messageErrorGetKeyPair + err.Error()
@@ -48,5 +72,54 @@ func (c *CA) Setup() (v1alpha1.IssuerStatus, error) {
 	c.recorder.Event(update, v1.EventTypeNormal, successKeyPairVerified, messageKeyPairVerified)
 	update.UpdateStatusCondition(v1alpha1.IssuerConditionReady, v1alpha1.ConditionTrue, successKeyPairVerified, messageKeyPairVerified)
 
+	c.checkSignerExpiry(update, cert)
+
+	if len(update.Spec.CA.DelegatedNamespaces) > 0 {
+		key, err := kube.SecretTLSKey(c.secretsLister, secretNamespace, update.Spec.CA.SecretRef.Name)
+		if err != nil {
+			s := messageErrorGetKeyPair + err.Error()
+			glog.Info(s)
+			c.recorder.Event(update, v1.EventTypeWarning, errorGetKeyPair, s)
+			update.UpdateStatusCondition(v1alpha1.IssuerConditionReady, v1alpha1.ConditionFalse, errorGetKeyPair, s)
+			return update.Status, err
+		}
+
+		if err := c.provisionDelegatedNamespaces(ctx, cert, key); err != nil {
+			update.UpdateStatusCondition(v1alpha1.IssuerConditionReady, v1alpha1.ConditionFalse, errorDelegatedCA, err.Error())
+			return update.Status, err
+		}
+	}
+
 	return update.Status, nil
 }
+
+// checkSignerExpiry flags update with the SignerExpiringSoon condition, and
+// records cert's NotAfter in update.Status.SignerExpiry, if cert is within
+// the issuer's configured signerExpiryThreshold of its own expiry. It
+// clears both once cert is no longer within that window, e.g. after
+// spec.ca.secretRef has been rotated to a freshly issued signing
+// certificate.
+func (c *CA) checkSignerExpiry(update *v1alpha1.Issuer, cert *x509.Certificate) {
+	threshold := defaultSignerExpiryThreshold
+	if update.Spec.CA.SignerExpiryThreshold != "" {
+		if d, err := time.ParseDuration(update.Spec.CA.SignerExpiryThreshold); err == nil {
+			threshold = d
+		} else {
+			glog.Warningf("invalid signerExpiryThreshold %q, using default of %s: %s", update.Spec.CA.SignerExpiryThreshold, defaultSignerExpiryThreshold, err.Error())
+		}
+	}
+
+	if c.clock.Now().Add(threshold).Before(cert.NotAfter) {
+		if update.HasCondition(v1alpha1.IssuerCondition{Type: v1alpha1.IssuerConditionSignerExpiringSoon, Status: v1alpha1.ConditionTrue}) {
+			update.UpdateStatusCondition(v1alpha1.IssuerConditionSignerExpiringSoon, v1alpha1.ConditionFalse, reasonSignerExpiryOK, messageSignerExpiryOK)
+		}
+		update.Status.SignerExpiry = nil
+		return
+	}
+
+	s := fmt.Sprintf(messageSignerExpiringSoon, cert.NotAfter, threshold)
+	glog.Warning(s)
+	c.recorder.Event(update, v1.EventTypeWarning, reasonSignerExpiringSoon, s)
+	update.UpdateStatusCondition(v1alpha1.IssuerConditionSignerExpiringSoon, v1alpha1.ConditionTrue, reasonSignerExpiringSoon, s)
+	update.Status.SignerExpiry = &metav1.Time{Time: cert.NotAfter}
+}
@@ -11,6 +11,14 @@ const (
 	IssuerACME string = "acme"
 	// IssuerCA is the name of the simple issuer
 	IssuerCA string = "ca"
+	// IssuerStepCA is the name of the step-ca issuer
+	IssuerStepCA string = "stepca"
+	// IssuerEST is the name of the EST issuer
+	IssuerEST string = "est"
+	// IssuerHub is the name of the hub/spoke issuer
+	IssuerHub string = "hub"
+	// IssuerFake is the name of the fake issuer
+	IssuerFake string = "fake"
 )
 
 // nameForIssuer determines the name of the issuer implementation given an
@@ -21,6 +29,14 @@ func nameForIssuer(i *v1alpha1.Issuer) (string, error) {
 		return IssuerACME, nil
 	case i.Spec.CA != nil:
 		return IssuerCA, nil
+	case i.Spec.StepCA != nil:
+		return IssuerStepCA, nil
+	case i.Spec.EST != nil:
+		return IssuerEST, nil
+	case i.Spec.Hub != nil:
+		return IssuerHub, nil
+	case i.Spec.Fake != nil:
+		return IssuerFake, nil
 	}
 	return "", fmt.Errorf("no issuer specified for Issuer '%s/%s'", i.Namespace, i.Name)
 }
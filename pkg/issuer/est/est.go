@@ -0,0 +1,60 @@
+package est
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/client/clientset"
+	"github.com/jetstack-experimental/cert-manager/pkg/issuer"
+)
+
+// EST is an issuer that enrols certificates from an EST (RFC 7030) server,
+// such as EJBCA or Microsoft NDES, allowing enterprise CAs to be used
+// without a bespoke connector.
+type EST struct {
+	issuer *v1alpha1.Issuer
+
+	client   kubernetes.Interface
+	cmClient clientset.Interface
+	recorder record.EventRecorder
+
+	secretsLister corelisters.SecretLister
+}
+
+// New returns a new EST issuer interface for the given issuer.
+func New(issuer *v1alpha1.Issuer,
+	client kubernetes.Interface,
+	cmClient clientset.Interface,
+	recorder record.EventRecorder,
+	secretsInformer cache.SharedIndexInformer) (issuer.Interface, error) {
+	secretsLister := corelisters.NewSecretLister(secretsInformer.GetIndexer())
+	return &EST{
+		issuer:        issuer,
+		client:        client,
+		cmClient:      cmClient,
+		recorder:      recorder,
+		secretsLister: secretsLister,
+	}, nil
+}
+
+func init() {
+	issuer.Register(issuer.IssuerEST, func(i *v1alpha1.Issuer, ctx *issuer.Context) (issuer.Interface, error) {
+		return New(
+			i,
+			ctx.Client,
+			ctx.CMClient,
+			ctx.Recorder,
+			ctx.SharedInformerFactory.InformerFor(
+				ctx.Namespace,
+				metav1.GroupVersionKind{Version: "v1", Kind: "Secret"},
+				coreinformers.NewSecretInformer(ctx.Client, ctx.Namespace, time.Second*30, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})),
+		)
+	})
+}
@@ -0,0 +1,50 @@
+package est
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+)
+
+// httpClient returns the http.Client that should be used for all requests
+// made to this issuer's EST server. If the issuer has a CABundle
+// configured, it is trusted in addition to the system trust store when
+// validating the EST server's TLS certificate.
+func (e *EST) httpClient() (*http.Client, error) {
+	caBundle := e.issuer.Spec.EST.CABundle
+	if len(caBundle) == 0 {
+		return http.DefaultClient, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(caBundle) {
+		return nil, fmt.Errorf("error parsing CA bundle: no valid PEM certificates found")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// basicAuthPassword resolves the password to use for HTTP Basic
+// authentication against the EST server from PasswordSecretRef.
+func (e *EST) basicAuthPassword() (string, error) {
+	estIssuer := e.issuer.Spec.EST
+
+	secret, err := e.secretsLister.Secrets(e.issuer.Namespace).Get(estIssuer.PasswordSecretRef.Name)
+	if err != nil {
+		return "", fmt.Errorf("error getting passwordSecretRef: %s", err.Error())
+	}
+
+	password, ok := secret.Data[estIssuer.PasswordSecretRef.Key]
+	if !ok {
+		return "", fmt.Errorf("error getting passwordSecretRef: key %q not found in secret %q", estIssuer.PasswordSecretRef.Key, estIssuer.PasswordSecretRef.Name)
+	}
+
+	return string(password), nil
+}
@@ -0,0 +1,144 @@
+package est
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/kube"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/pki"
+)
+
+const (
+	errorGetCertKeyPair = "ErrGetCertKeyPair"
+	errorIssueCert      = "ErrIssueCert"
+
+	successCertIssued = "CertIssueSuccess"
+
+	messageErrorGetCertKeyPair = "Error getting keypair for certificate: "
+	messageErrorIssueCert      = "Error issuing TLS certificate: "
+
+	messageCertIssued = "Certificate issued successfully"
+)
+
+func (e *EST) Issue(ctx context.Context, crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, []byte, []byte, []byte, error) {
+	update := crt.DeepCopy()
+
+	keySize := crt.Spec.KeySize
+	if keySize == 0 {
+		keySize = pki.DefaultRSAKeySize
+	}
+
+	signeeKey, err := kube.SecretTLSKeyRef(e.secretsLister, crt.Namespace, crt.Spec.SecretName, crt.PrivateKeyKey())
+	regenerate := k8sErrors.IsNotFound(err)
+
+	if err == nil && !pki.PrivateKeyMatchesSize(signeeKey, keySize) {
+		regenerate = true
+		err = nil
+	}
+
+	if regenerate {
+		signeeKey, err = pki.GenerateRSAPrivateKey(keySize)
+	}
+
+	if err != nil {
+		msg := messageErrorGetCertKeyPair + err.Error()
+		update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionFalse, errorGetCertKeyPair, msg)
+		return update.Status, nil, nil, nil, err
+	}
+
+	certPem, caPem, err := e.obtainCertificate(ctx, crt, signeeKey)
+	if err != nil {
+		msg := messageErrorIssueCert + err.Error()
+		update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionFalse, errorIssueCert, msg)
+		return update.Status, nil, nil, nil, err
+	}
+
+	update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionTrue, successCertIssued, messageCertIssued)
+
+	return update.Status, pki.EncodePKCS1PrivateKey(signeeKey), certPem, caPem, nil
+}
+
+// obtainCertificate builds a PKCS#10 CSR for crt and POSTs it to the EST
+// server's simpleenroll endpoint, returning the issued leaf certificate PEM
+// and, if the response chain contains further certificates, a CA bundle
+// PEM of the remainder.
+func (e *EST) obtainCertificate(ctx context.Context, crt *v1alpha1.Certificate, signeeKey interface{}) ([]byte, []byte, error) {
+	estIssuer := e.issuer.Spec.EST
+
+	if crt.Spec.CommonNameOnly {
+		return nil, nil, fmt.Errorf("commonNameOnly is not supported by the EST issuer: a SAN extension is required to prove domain control")
+	}
+
+	csrTemplate := pki.GenerateCSR(crt.Spec.Domains, crt.Spec.IPAddresses)
+	csrDER, err := x509.CreateCertificateRequest(nil, csrTemplate, signeeKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating certificate request: %s", err.Error())
+	}
+
+	httpClient, err := e.httpClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body := make([]byte, base64.StdEncoding.EncodedLen(len(csrDER)))
+	base64.StdEncoding.Encode(body, csrDER)
+
+	req, err := http.NewRequest(http.MethodPost, estIssuer.URL+"/simpleenroll", bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building simpleenroll request: %s", err.Error())
+	}
+	req.Header.Set("Content-Type", "application/pkcs10")
+	req.Header.Set("Content-Transfer-Encoding", "base64")
+
+	if estIssuer.Username != "" {
+		password, err := e.basicAuthPassword()
+		if err != nil {
+			return nil, nil, err
+		}
+		req.SetBasicAuth(estIssuer.Username, password)
+	}
+
+	resp, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error calling EST simpleenroll endpoint: %s", err.Error())
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading EST simpleenroll response: %s", err.Error())
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("EST simpleenroll endpoint returned unexpected status code %d: %s", resp.StatusCode, respBody)
+	}
+
+	pkcs7DER := make([]byte, base64.StdEncoding.DecodedLen(len(respBody)))
+	n, err := base64.StdEncoding.Decode(pkcs7DER, bytes.TrimSpace(respBody))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error base64 decoding EST simpleenroll response: %s", err.Error())
+	}
+
+	certs, err := parseDegeneratePKCS7Certificates(pkcs7DER[:n])
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing EST simpleenroll response: %s", err.Error())
+	}
+
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certs[0].Raw})
+
+	var caPem []byte
+	for _, ca := range certs[1:] {
+		caPem = append(caPem, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw})...)
+	}
+
+	return certPem, caPem, nil
+}
@@ -0,0 +1,66 @@
+package est
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"fmt"
+)
+
+// contentInfo is the outer PKCS#7 ContentInfo structure (RFC 2315 s7).
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,optional,tag:0"`
+}
+
+// signedData is the PKCS#7 SignedData structure (RFC 2315 s9.1). EST
+// simpleenroll responses are a "degenerate" SignedData containing no
+// signers, used purely as a container to carry the issued certificate
+// chain, so only the certificates field is of interest here.
+type signedData struct {
+	Version      int
+	DigestAlgos  asn1.RawValue `asn1:"set"`
+	ContentInfo  contentInfo
+	Certificates asn1.RawValue `asn1:"optional,tag:0"`
+}
+
+// parseDegeneratePKCS7Certificates extracts the certificates carried by a
+// degenerate, certs-only PKCS#7 SignedData message, such as the body of an
+// EST server's simpleenroll response.
+func parseDegeneratePKCS7Certificates(der []byte) ([]*x509.Certificate, error) {
+	var outer contentInfo
+	if _, err := asn1.Unmarshal(der, &outer); err != nil {
+		return nil, fmt.Errorf("error parsing PKCS7 ContentInfo: %s", err.Error())
+	}
+
+	var inner signedData
+	if _, err := asn1.Unmarshal(outer.Content.Bytes, &inner); err != nil {
+		return nil, fmt.Errorf("error parsing PKCS7 SignedData: %s", err.Error())
+	}
+
+	rest := inner.Certificates.Bytes
+	if len(rest) == 0 {
+		return nil, fmt.Errorf("PKCS7 message did not contain any certificates")
+	}
+
+	// Certificates is encoded as an [0] IMPLICIT SET OF Certificate: asn1
+	// strips the outer context-specific tag into RawValue.Bytes, leaving
+	// the concatenated DER of each (normally tagged) Certificate SEQUENCE
+	// behind, so those can be parsed directly one after another.
+	var certs []*x509.Certificate
+	for len(rest) > 0 {
+		var raw asn1.RawValue
+		var err error
+		rest, err = asn1.Unmarshal(rest, &raw)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing PKCS7 certificates set: %s", err.Error())
+		}
+
+		cert, err := x509.ParseCertificate(raw.FullBytes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing certificate: %s", err.Error())
+		}
+		certs = append(certs, cert)
+	}
+
+	return certs, nil
+}
@@ -0,0 +1,102 @@
+package est
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// buildDegeneratePKCS7 wraps the given DER encoded certificates in a
+// degenerate, certs-only PKCS#7 SignedData message, in the same shape an
+// EST server's simpleenroll response body takes.
+func buildDegeneratePKCS7(t *testing.T, certsDER ...[]byte) []byte {
+	t.Helper()
+
+	var certBytes []byte
+	for _, der := range certsDER {
+		certBytes = append(certBytes, der...)
+	}
+
+	sd := signedData{
+		Version:      1,
+		ContentInfo:  contentInfo{ContentType: asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}},
+		Certificates: asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: certBytes},
+	}
+	sdBytes, err := asn1.Marshal(sd)
+	if err != nil {
+		t.Fatalf("error marshalling SignedData: %s", err)
+	}
+
+	outer := contentInfo{
+		ContentType: asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2},
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: sdBytes},
+	}
+	outerBytes, err := asn1.Marshal(outer)
+	if err != nil {
+		t.Fatalf("error marshalling ContentInfo: %s", err)
+	}
+
+	return outerBytes
+}
+
+func generateTestCertDER(t *testing.T, commonName string) []byte {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("error generating test key: %s", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("error creating test certificate: %s", err)
+	}
+	return der
+}
+
+func TestParseDegeneratePKCS7Certificates(t *testing.T) {
+	leafDER := generateTestCertDER(t, "leaf")
+	caDER := generateTestCertDER(t, "ca")
+
+	t.Run("single certificate", func(t *testing.T) {
+		certs, err := parseDegeneratePKCS7Certificates(buildDegeneratePKCS7(t, leafDER))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(certs) != 1 {
+			t.Fatalf("expected 1 certificate, got %d", len(certs))
+		}
+		if certs[0].Subject.CommonName != "leaf" {
+			t.Errorf("unexpected common name: %s", certs[0].Subject.CommonName)
+		}
+	})
+
+	t.Run("certificate chain", func(t *testing.T) {
+		certs, err := parseDegeneratePKCS7Certificates(buildDegeneratePKCS7(t, leafDER, caDER))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(certs) != 2 {
+			t.Fatalf("expected 2 certificates, got %d", len(certs))
+		}
+		if certs[0].Subject.CommonName != "leaf" || certs[1].Subject.CommonName != "ca" {
+			t.Errorf("unexpected certificate order: %s, %s", certs[0].Subject.CommonName, certs[1].Subject.CommonName)
+		}
+	})
+
+	t.Run("no certificates", func(t *testing.T) {
+		if _, err := parseDegeneratePKCS7Certificates(buildDegeneratePKCS7(t)); err == nil {
+			t.Errorf("expected an error for a PKCS7 message with no certificates")
+		}
+	})
+}
@@ -0,0 +1,20 @@
+package est
+
+import (
+	"context"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
+
+// Prepare does nothing for the EST issuer, since simpleenroll issues a
+// certificate in a single request rather than requiring a separate
+// authorization step.
+func (e *EST) Prepare(ctx context.Context, crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, error) {
+	return crt.Status, nil
+}
+
+// CleanupStalePendingChallenges does nothing for the EST issuer, since it
+// doesn't perform challenge based domain validation.
+func (e *EST) CleanupStalePendingChallenges(ctx context.Context, crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, error) {
+	return crt.Status, nil
+}
@@ -0,0 +1,41 @@
+package est
+
+import (
+	"context"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/kube"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/pki"
+)
+
+const (
+	errorRenewCert = "ErrRenewCert"
+
+	successCertRenewed = "CertRenewSuccess"
+
+	messageErrorRenewCert = "Error renewing TLS certificate: "
+
+	messageCertRenewed = "Certificate renewed successfully"
+)
+
+func (e *EST) Renew(ctx context.Context, crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, []byte, []byte, []byte, error) {
+	update := crt.DeepCopy()
+
+	signeeKey, err := kube.SecretTLSKeyRef(e.secretsLister, crt.Namespace, crt.Spec.SecretName, crt.PrivateKeyKey())
+	if err != nil {
+		msg := messageErrorGetCertKeyPair + err.Error()
+		update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionFalse, errorGetCertKeyPair, msg)
+		return update.Status, nil, nil, nil, err
+	}
+
+	certPem, caPem, err := e.obtainCertificate(ctx, crt, signeeKey)
+	if err != nil {
+		msg := messageErrorRenewCert + err.Error()
+		update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionFalse, errorRenewCert, msg)
+		return update.Status, nil, nil, nil, err
+	}
+
+	update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionTrue, successCertRenewed, messageCertRenewed)
+
+	return update.Status, pki.EncodePKCS1PrivateKey(signeeKey), certPem, caPem, nil
+}
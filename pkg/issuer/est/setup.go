@@ -0,0 +1,53 @@
+package est
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/api/core/v1"
+
+	"github.com/golang/glog"
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
+
+const (
+	errorInvalidConfig  = "ErrInvalidConfig"
+	errorGetCredentials = "ErrGetCredentials"
+
+	successConfigVerified = "ESTConfigVerified"
+
+	messageInvalidConfig       = "Invalid EST issuer configuration: "
+	messageErrorGetCredentials = "Error getting EST server credentials: "
+
+	messageConfigVerified = "The EST issuer configuration was verified"
+)
+
+// Setup checks that this issuer is correctly configured before it is used
+// to issue certificates.
+func (e *EST) Setup(ctx context.Context) (v1alpha1.IssuerStatus, error) {
+	update := e.issuer.DeepCopy()
+
+	estIssuer := update.Spec.EST
+	if estIssuer.URL == "" {
+		s := messageInvalidConfig + "url is required"
+		glog.Info(s)
+		update.UpdateStatusCondition(v1alpha1.IssuerConditionReady, v1alpha1.ConditionFalse, errorInvalidConfig, s)
+		return update.Status, fmt.Errorf(s)
+	}
+
+	if estIssuer.Username != "" {
+		if _, err := e.basicAuthPassword(); err != nil {
+			msg := messageErrorGetCredentials + err.Error()
+			glog.Info(msg)
+			e.recorder.Event(update, v1.EventTypeWarning, errorGetCredentials, msg)
+			update.UpdateStatusCondition(v1alpha1.IssuerConditionReady, v1alpha1.ConditionFalse, errorGetCredentials, msg)
+			return update.Status, fmt.Errorf(msg)
+		}
+	}
+
+	glog.Info(messageConfigVerified)
+	e.recorder.Event(update, v1.EventTypeNormal, successConfigVerified, messageConfigVerified)
+	update.UpdateStatusCondition(v1alpha1.IssuerConditionReady, v1alpha1.ConditionTrue, successConfigVerified, messageConfigVerified)
+
+	return update.Status, nil
+}
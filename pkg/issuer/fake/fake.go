@@ -0,0 +1,42 @@
+// Package fake implements the "fake" issuer type: an Issuer that signs
+// every Certificate as a true self-signed leaf (its own freshly generated
+// key signs its own certificate) instead of chaining to any CA. It makes
+// no network or Kubernetes API calls beyond updating the
+// Certificate/Issuer status, so application teams can apply their real
+// manifests in a CI cluster and get back a working TLS certificate
+// without needing a real CA or an ACME staging server.
+package fake
+
+import (
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/issuer"
+)
+
+// Fake is an issuer that instantly issues a self-signed certificate for
+// every Certificate resource, with no external dependencies.
+type Fake struct {
+	issuer   *v1alpha1.Issuer
+	recorder record.EventRecorder
+
+	// clock is used to determine the current time, and is overridden in
+	// tests so that NotBefore/NotAfter math can be verified deterministically.
+	clock clock.Clock
+}
+
+// New returns a new fake issuer interface for the given issuer.
+func New(issuer *v1alpha1.Issuer, recorder record.EventRecorder) (issuer.Interface, error) {
+	return &Fake{
+		issuer:   issuer,
+		recorder: recorder,
+		clock:    clock.RealClock{},
+	}, nil
+}
+
+func init() {
+	issuer.Register(issuer.IssuerFake, func(i *v1alpha1.Issuer, ctx *issuer.Context) (issuer.Interface, error) {
+		return New(i, ctx.Recorder)
+	})
+}
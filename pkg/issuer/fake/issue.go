@@ -0,0 +1,150 @@
+package fake
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/pki"
+)
+
+const (
+	errorIssueCert = "ErrIssueCert"
+
+	successFakeReady  = "FakeIssuerReady"
+	successCertIssued = "CertIssueSuccess"
+
+	messageFakeReady      = "Fake issuer is always ready"
+	messageErrorIssueCert = "Error issuing fake TLS certificate: "
+	messageCertIssued     = "Fake certificate issued successfully"
+
+	// certificateDuration of 1 year, matching the CA issuer's default.
+	certificateDuration = time.Hour * 24 * 365
+
+	// organization is set on every certificate issued by the fake issuer so
+	// that it is never mistaken for a real one, even if a Secret it wrote
+	// ends up mounted somewhere it shouldn't be.
+	organization = "cert-manager-fake-issuer"
+
+	// serialNumberBits matches the CA issuer's default.
+	serialNumberBits = 128
+)
+
+// Issue instantly returns a self-signed certificate for crt: a freshly
+// generated key signs its own certificate, so there is no CA to manage and
+// no external call to make.
+func (c *Fake) Issue(ctx context.Context, crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, []byte, []byte, []byte, error) {
+	return c.issue(crt)
+}
+
+// Renew is identical to Issue for the fake issuer: every call mints a
+// brand new self-signed certificate, so there is no existing key to renew.
+func (c *Fake) Renew(ctx context.Context, crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, []byte, []byte, []byte, error) {
+	return c.issue(crt)
+}
+
+func (c *Fake) issue(crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, []byte, []byte, []byte, error) {
+	update := crt.DeepCopy()
+
+	keySize := crt.Spec.KeySize
+	if keySize == 0 {
+		keySize = pki.DefaultRSAKeySize
+	}
+
+	key, err := pki.GenerateRSAPrivateKey(keySize)
+	if err != nil {
+		s := messageErrorIssueCert + err.Error()
+		update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionFalse, errorIssueCert, s)
+		return update.Status, nil, nil, nil, err
+	}
+
+	certPem, err := c.signSelf(crt, key)
+	if err != nil {
+		s := messageErrorIssueCert + err.Error()
+		update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionFalse, errorIssueCert, s)
+		return update.Status, nil, nil, nil, err
+	}
+
+	update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionTrue, successCertIssued, messageCertIssued)
+
+	return update.Status, pki.EncodePKCS1PrivateKey(key), certPem, nil, nil
+}
+
+func (c *Fake) signSelf(crt *v1alpha1.Certificate, key *rsa.PrivateKey) ([]byte, error) {
+	template, err := c.certificateTemplate(crt, &key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating x509 certificate: %s", err.Error())
+	}
+
+	pemBytes := bytes.NewBuffer(nil)
+	if err := pem.Encode(pemBytes, &pem.Block{Type: "CERTIFICATE", Bytes: derBytes}); err != nil {
+		return nil, fmt.Errorf("error encoding certificate PEM: %s", err.Error())
+	}
+
+	return pemBytes.Bytes(), nil
+}
+
+func (c *Fake) certificateTemplate(crt *v1alpha1.Certificate, publicKey interface{}) (*x509.Certificate, error) {
+	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), uint(serialNumberBits))
+	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %s", err.Error())
+	}
+
+	notBefore := c.clock.Now()
+	if crt.Spec.NotBeforeSkew != "" {
+		skew, err := time.ParseDuration(crt.Spec.NotBeforeSkew)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing notBeforeSkew %q: %s", crt.Spec.NotBeforeSkew, err.Error())
+		}
+		notBefore = notBefore.Add(-skew)
+	}
+
+	duration := certificateDuration
+	if crt.Spec.Duration != "" {
+		d, err := time.ParseDuration(crt.Spec.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing requested certificate duration %q: %s", crt.Spec.Duration, err.Error())
+		}
+		duration = d
+	}
+
+	var commonName string
+	dnsNames := crt.Spec.Domains
+	if len(dnsNames) > 0 {
+		commonName = dnsNames[0]
+	}
+	if crt.Spec.CommonNameOnly {
+		dnsNames = nil
+	}
+
+	return &x509.Certificate{
+		Version:               3,
+		BasicConstraintsValid: true,
+		SerialNumber:          serialNumber,
+		SignatureAlgorithm:    x509.SHA256WithRSA,
+		PublicKey:             publicKey,
+		Subject: pkix.Name{
+			Organization: []string{organization},
+			CommonName:   commonName,
+		},
+		NotBefore:      notBefore,
+		NotAfter:       notBefore.Add(duration),
+		KeyUsage:       x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		DNSNames:       dnsNames,
+		EmailAddresses: crt.Spec.EmailAddresses,
+	}, nil
+}
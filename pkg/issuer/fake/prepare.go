@@ -0,0 +1,19 @@
+package fake
+
+import (
+	"context"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
+
+// Prepare does nothing for the fake issuer, which performs no challenge
+// based domain validation.
+func (c *Fake) Prepare(ctx context.Context, crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, error) {
+	return crt.Status, nil
+}
+
+// CleanupStalePendingChallenges does nothing for the fake issuer, which
+// performs no challenge based domain validation.
+func (c *Fake) CleanupStalePendingChallenges(ctx context.Context, crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, error) {
+	return crt.Status, nil
+}
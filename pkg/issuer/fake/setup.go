@@ -0,0 +1,15 @@
+package fake
+
+import (
+	"context"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
+
+// Setup marks the fake issuer ready. There is no external account or
+// signing material to verify.
+func (c *Fake) Setup(ctx context.Context) (v1alpha1.IssuerStatus, error) {
+	update := c.issuer.DeepCopy()
+	update.UpdateStatusCondition(v1alpha1.IssuerConditionReady, v1alpha1.ConditionTrue, successFakeReady, messageFakeReady)
+	return update.Status, nil
+}
@@ -0,0 +1,50 @@
+package hub
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+)
+
+// httpClient returns the http.Client that should be used for all requests
+// made to this issuer's hub. If the issuer has a CABundle configured, it is
+// trusted in addition to the system trust store when validating the hub's
+// TLS certificate.
+func (h *Hub) httpClient() (*http.Client, error) {
+	caBundle := h.issuer.Spec.Hub.CABundle
+	if len(caBundle) == 0 {
+		return http.DefaultClient, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(caBundle) {
+		return nil, fmt.Errorf("error parsing CA bundle: no valid PEM certificates found")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// bearerToken resolves the bearer token this spoke cluster authenticates to
+// the hub with from TokenSecretRef.
+func (h *Hub) bearerToken() (string, error) {
+	hubIssuer := h.issuer.Spec.Hub
+
+	secret, err := h.secretsLister.Secrets(h.issuer.Namespace).Get(hubIssuer.TokenSecretRef.Name)
+	if err != nil {
+		return "", fmt.Errorf("error getting tokenSecretRef: %s", err.Error())
+	}
+
+	token, ok := secret.Data[hubIssuer.TokenSecretRef.Key]
+	if !ok {
+		return "", fmt.Errorf("error getting tokenSecretRef: key %q not found in secret %q", hubIssuer.TokenSecretRef.Key, hubIssuer.TokenSecretRef.Name)
+	}
+
+	return string(token), nil
+}
@@ -0,0 +1,63 @@
+package hub
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/client/clientset"
+	"github.com/jetstack-experimental/cert-manager/pkg/issuer"
+)
+
+// Hub is an issuer that forwards CSRs to a remote signing endpoint exposed
+// by a hub cluster's cert-manager, authenticated with a bearer token. It
+// implements the spoke side of a hub/spoke multi-cluster deployment: the
+// private key is always generated locally, so it never has to leave the
+// spoke cluster, and the hub never needs access to it; only the CSR is
+// sent over the wire.
+type Hub struct {
+	issuer *v1alpha1.Issuer
+
+	client   kubernetes.Interface
+	cmClient clientset.Interface
+	recorder record.EventRecorder
+
+	secretsLister corelisters.SecretLister
+}
+
+// New returns a new Hub issuer interface for the given issuer.
+func New(issuer *v1alpha1.Issuer,
+	client kubernetes.Interface,
+	cmClient clientset.Interface,
+	recorder record.EventRecorder,
+	secretsInformer cache.SharedIndexInformer) (issuer.Interface, error) {
+	secretsLister := corelisters.NewSecretLister(secretsInformer.GetIndexer())
+	return &Hub{
+		issuer:        issuer,
+		client:        client,
+		cmClient:      cmClient,
+		recorder:      recorder,
+		secretsLister: secretsLister,
+	}, nil
+}
+
+func init() {
+	issuer.Register(issuer.IssuerHub, func(i *v1alpha1.Issuer, ctx *issuer.Context) (issuer.Interface, error) {
+		return New(
+			i,
+			ctx.Client,
+			ctx.CMClient,
+			ctx.Recorder,
+			ctx.SharedInformerFactory.InformerFor(
+				ctx.Namespace,
+				metav1.GroupVersionKind{Version: "v1", Kind: "Secret"},
+				coreinformers.NewSecretInformer(ctx.Client, ctx.Namespace, time.Second*30, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})),
+		)
+	})
+}
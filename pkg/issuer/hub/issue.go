@@ -0,0 +1,143 @@
+package hub
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/kube"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/pki"
+)
+
+const (
+	errorGetCertKeyPair = "ErrGetCertKeyPair"
+	errorIssueCert      = "ErrIssueCert"
+
+	successCertIssued = "CertIssueSuccess"
+
+	messageErrorGetCertKeyPair = "Error getting keypair for certificate: "
+	messageErrorIssueCert      = "Error issuing TLS certificate: "
+
+	messageCertIssued = "Certificate issued successfully"
+)
+
+func (h *Hub) Issue(ctx context.Context, crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, []byte, []byte, []byte, error) {
+	update := crt.DeepCopy()
+
+	keySize := crt.Spec.KeySize
+	if keySize == 0 {
+		keySize = pki.DefaultRSAKeySize
+	}
+
+	signeeKey, err := kube.SecretTLSKeyRef(h.secretsLister, crt.Namespace, crt.Spec.SecretName, crt.PrivateKeyKey())
+	regenerate := k8sErrors.IsNotFound(err)
+
+	if err == nil && !pki.PrivateKeyMatchesSize(signeeKey, keySize) {
+		regenerate = true
+		err = nil
+	}
+
+	if regenerate {
+		signeeKey, err = pki.GenerateRSAPrivateKey(keySize)
+	}
+
+	if err != nil {
+		msg := messageErrorGetCertKeyPair + err.Error()
+		update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionFalse, errorGetCertKeyPair, msg)
+		return update.Status, nil, nil, nil, err
+	}
+
+	certPem, caPem, err := h.obtainCertificate(ctx, crt, signeeKey)
+	if err != nil {
+		msg := messageErrorIssueCert + err.Error()
+		update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionFalse, errorIssueCert, msg)
+		return update.Status, nil, nil, nil, err
+	}
+
+	update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionTrue, successCertIssued, messageCertIssued)
+
+	return update.Status, pki.EncodePKCS1PrivateKey(signeeKey), certPem, caPem, nil
+}
+
+// obtainCertificate builds a PEM encoded PKCS#10 CSR for crt and POSTs it,
+// bearer-token authenticated, to the hub's /sign endpoint. This defines the
+// contract a hub signing endpoint must implement: it receives a PEM CSR as
+// the request body and returns a 200 response whose body is one or more
+// PEM encoded certificates, leaf first, followed by any intermediates.
+func (h *Hub) obtainCertificate(ctx context.Context, crt *v1alpha1.Certificate, signeeKey interface{}) ([]byte, []byte, error) {
+	hubIssuer := h.issuer.Spec.Hub
+
+	if crt.Spec.CommonNameOnly {
+		return nil, nil, fmt.Errorf("commonNameOnly is not supported by the Hub issuer: a SAN extension is required to prove domain control")
+	}
+
+	csrTemplate := pki.GenerateCSR(crt.Spec.Domains, crt.Spec.IPAddresses)
+	csrDER, err := x509.CreateCertificateRequest(nil, csrTemplate, signeeKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating certificate request: %s", err.Error())
+	}
+	csrPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+
+	httpClient, err := h.httpClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	token, err := h.bearerToken()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, hubIssuer.URL+"/sign", bytes.NewReader(csrPem))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building sign request: %s", err.Error())
+	}
+	req.Header.Set("Content-Type", "application/x-pem-file")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error calling hub sign endpoint: %s", err.Error())
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading hub sign response: %s", err.Error())
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("hub sign endpoint returned unexpected status code %d: %s", resp.StatusCode, respBody)
+	}
+
+	var certs []*pem.Block
+	rest := respBody
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		certs = append(certs, block)
+	}
+
+	if len(certs) == 0 {
+		return nil, nil, fmt.Errorf("hub sign endpoint returned no PEM certificates")
+	}
+
+	certPem := pem.EncodeToMemory(certs[0])
+
+	var caPem []byte
+	for _, ca := range certs[1:] {
+		caPem = append(caPem, pem.EncodeToMemory(ca)...)
+	}
+
+	return certPem, caPem, nil
+}
@@ -0,0 +1,20 @@
+package hub
+
+import (
+	"context"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
+
+// Prepare does nothing for the Hub issuer, since the hub's sign endpoint
+// issues a certificate in a single request rather than requiring a
+// separate authorization step.
+func (h *Hub) Prepare(ctx context.Context, crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, error) {
+	return crt.Status, nil
+}
+
+// CleanupStalePendingChallenges does nothing for the Hub issuer, since it
+// doesn't perform challenge based domain validation.
+func (h *Hub) CleanupStalePendingChallenges(ctx context.Context, crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, error) {
+	return crt.Status, nil
+}
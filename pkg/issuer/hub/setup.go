@@ -0,0 +1,51 @@
+package hub
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/api/core/v1"
+
+	"github.com/golang/glog"
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
+
+const (
+	errorInvalidConfig  = "ErrInvalidConfig"
+	errorGetCredentials = "ErrGetCredentials"
+
+	successConfigVerified = "HubConfigVerified"
+
+	messageInvalidConfig       = "Invalid Hub issuer configuration: "
+	messageErrorGetCredentials = "Error getting hub bearer token: "
+
+	messageConfigVerified = "The Hub issuer configuration was verified"
+)
+
+// Setup checks that this issuer is correctly configured before it is used
+// to issue certificates.
+func (h *Hub) Setup(ctx context.Context) (v1alpha1.IssuerStatus, error) {
+	update := h.issuer.DeepCopy()
+
+	hubIssuer := update.Spec.Hub
+	if hubIssuer.URL == "" {
+		s := messageInvalidConfig + "url is required"
+		glog.Info(s)
+		update.UpdateStatusCondition(v1alpha1.IssuerConditionReady, v1alpha1.ConditionFalse, errorInvalidConfig, s)
+		return update.Status, fmt.Errorf(s)
+	}
+
+	if _, err := h.bearerToken(); err != nil {
+		msg := messageErrorGetCredentials + err.Error()
+		glog.Info(msg)
+		h.recorder.Event(update, v1.EventTypeWarning, errorGetCredentials, msg)
+		update.UpdateStatusCondition(v1alpha1.IssuerConditionReady, v1alpha1.ConditionFalse, errorGetCredentials, msg)
+		return update.Status, fmt.Errorf(msg)
+	}
+
+	glog.Info(messageConfigVerified)
+	h.recorder.Event(update, v1.EventTypeNormal, successConfigVerified, messageConfigVerified)
+	update.UpdateStatusCondition(v1alpha1.IssuerConditionReady, v1alpha1.ConditionTrue, successConfigVerified, messageConfigVerified)
+
+	return update.Status, nil
+}
@@ -1,18 +1,32 @@
 package issuer
 
-import "github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+import (
+	"context"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
 
 type Interface interface {
 	// Setup initialises the issuer. This may include registering accounts with
 	// a service, creating a CA and storing it somewhere, or verifying
 	// credentials and authorization with a remote server.
-	Setup() (v1alpha1.IssuerStatus, error)
+	Setup(ctx context.Context) (v1alpha1.IssuerStatus, error)
 	// Prepare
-	Prepare(*v1alpha1.Certificate) (v1alpha1.CertificateStatus, error)
+	Prepare(ctx context.Context, crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, error)
 	// Issue attempts to issue a certificate as described by the certificate
-	// resource given
-	Issue(*v1alpha1.Certificate) (v1alpha1.CertificateStatus, []byte, []byte, error)
+	// resource given. The returned values are the updated status, the
+	// private key, the certificate and, if the issuer has one, the CA
+	// certificate bundle to be stored alongside it (ca.crt).
+	Issue(ctx context.Context, crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, []byte, []byte, []byte, error)
 	// Renew attempts to renew the certificate describe by the certificate
-	// resource given. If no certificate exists, an error is returned.
-	Renew(*v1alpha1.Certificate) (v1alpha1.CertificateStatus, []byte, []byte, error)
+	// resource given. If no certificate exists, an error is returned. The
+	// returned values are as per Issue.
+	Renew(ctx context.Context, crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, []byte, []byte, []byte, error)
+	// CleanupStalePendingChallenges removes any challenge resources (such as
+	// a DNS-01 TXT record or HTTP-01 Service/Ingress/Job) left behind by an
+	// issuance attempt that was abandoned or interrupted before it could
+	// clean up after itself, and returns the updated status with those
+	// entries cleared. Issuers that don't perform challenge based domain
+	// validation return the given status unchanged.
+	CleanupStalePendingChallenges(ctx context.Context, crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, error)
 }
@@ -0,0 +1,93 @@
+// Package notify implements an optional, best-effort notification sink for
+// certificate issuance, renewal and failure, configured via a Certificate's
+// spec.notify field. Unlike pkg/issuer/policy's policy webhook, a notify
+// webhook never blocks or fails issuance: it exists purely so teams can
+// wire up Slack/PagerDuty style alerting without watching Kubernetes
+// Events.
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/golang/glog"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/pki"
+)
+
+// Event identifies why a Notification is being sent.
+type Event string
+
+const (
+	EventIssued  Event = "issued"
+	EventRenewed Event = "renewed"
+	EventFailed  Event = "failed"
+)
+
+// Notification is the JSON payload POSTed to a Certificate's configured
+// notify webhook.
+type Notification struct {
+	Event     Event      `json:"event"`
+	Namespace string     `json:"namespace"`
+	Name      string     `json:"name"`
+	Serial    string     `json:"serial,omitempty"`
+	NotAfter  *time.Time `json:"notAfter,omitempty"`
+	Message   string     `json:"message,omitempty"`
+}
+
+// NotificationForCertificate builds the Notification that should be sent
+// for event on crt. certPEM is the issued certificate and is only set for
+// EventIssued/EventRenewed; if it cannot be parsed, Serial and NotAfter are
+// simply omitted rather than failing the notification.
+func NotificationForCertificate(event Event, crt *v1alpha1.Certificate, certPEM []byte, message string) *Notification {
+	n := &Notification{
+		Event:     event,
+		Namespace: crt.Namespace,
+		Name:      crt.Name,
+		Message:   message,
+	}
+
+	if cert, err := pki.DecodeX509CertificateBytes(certPEM); err == nil {
+		n.Serial = cert.SerialNumber.String()
+		notAfter := cert.NotAfter
+		n.NotAfter = &notAfter
+	}
+
+	return n
+}
+
+// Send POSTs n to cfg's webhook URL. A nil cfg, or one with an empty URL,
+// is a no-op. Any error reaching or returned by the webhook is logged and
+// otherwise ignored.
+func Send(cfg *v1alpha1.CertificateNotifyWebhook, n *Notification) {
+	if cfg == nil || cfg.URL == "" {
+		return
+	}
+
+	if err := send(cfg, n); err != nil {
+		glog.Warningf("error sending %s notification for certificate %s/%s: %s", n.Event, n.Namespace, n.Name, err.Error())
+	}
+}
+
+func send(cfg *v1alpha1.CertificateNotifyWebhook, n *Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("error encoding notification: %s", err.Error())
+	}
+
+	resp, err := http.Post(cfg.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error calling notify webhook: %s", err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("notify webhook returned unexpected status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
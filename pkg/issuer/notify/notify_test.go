@@ -0,0 +1,106 @@
+package notify
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
+
+func testCertPEM(t *testing.T, serial int64, notAfter time.Time) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error generating key: %s", err.Error())
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     notAfter,
+		IsCA:         true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("error creating certificate: %s", err.Error())
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestNotificationForCertificate(t *testing.T) {
+	notAfter := time.Now().Add(time.Hour).Truncate(time.Second)
+	certPEM := testCertPEM(t, 42, notAfter)
+
+	crt := &v1alpha1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "example"},
+	}
+
+	n := NotificationForCertificate(EventIssued, crt, certPEM, "")
+	if n.Namespace != "default" || n.Name != "example" {
+		t.Errorf("unexpected namespace/name: %+v", n)
+	}
+	if n.Serial == "" {
+		t.Errorf("expected a serial to be set")
+	}
+	if n.NotAfter == nil || !n.NotAfter.Equal(notAfter) {
+		t.Errorf("expected NotAfter %s, got %v", notAfter, n.NotAfter)
+	}
+}
+
+func TestNotificationForCertificateFailure(t *testing.T) {
+	crt := &v1alpha1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "example"},
+	}
+
+	n := NotificationForCertificate(EventFailed, crt, nil, "boom")
+	if n.Serial != "" || n.NotAfter != nil {
+		t.Errorf("expected no serial/notAfter without a certificate, got %+v", n)
+	}
+	if n.Message != "boom" {
+		t.Errorf("expected message to be preserved, got %q", n.Message)
+	}
+}
+
+func TestSendPostsNotification(t *testing.T) {
+	received := make(chan Notification, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var n Notification
+		if err := json.NewDecoder(r.Body).Decode(&n); err != nil {
+			t.Errorf("error decoding request body: %s", err.Error())
+		}
+		received <- n
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	crt := &v1alpha1.Certificate{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "example"}}
+	Send(&v1alpha1.CertificateNotifyWebhook{URL: srv.URL}, NotificationForCertificate(EventIssued, crt, nil, ""))
+
+	select {
+	case n := <-received:
+		if n.Event != EventIssued {
+			t.Errorf("unexpected event: %s", n.Event)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for webhook to be called")
+	}
+}
+
+func TestSendNilConfigIsNoOp(t *testing.T) {
+	// Must not panic or block; there is no server listening to receive it.
+	Send(nil, &Notification{Event: EventIssued})
+	Send(&v1alpha1.CertificateNotifyWebhook{}, &Notification{Event: EventIssued})
+}
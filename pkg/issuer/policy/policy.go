@@ -0,0 +1,109 @@
+// Package policy implements an optional external policy decision point for
+// certificate issuance, configured via an Issuer's spec.policyWebhook field.
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
+
+// requestTimeout bounds how long a single policy webhook call is allowed to
+// take, so that a slow or hung endpoint cannot wedge a certificate worker,
+// as promised by PolicyWebhook.FailurePolicy's doc comment.
+const requestTimeout = 30 * time.Second
+
+// Request describes a pending certificate issuance or renewal, as sent to a
+// policy webhook.
+type Request struct {
+	Namespace   string   `json:"namespace"`
+	Name        string   `json:"name"`
+	Issuer      string   `json:"issuer"`
+	Domains     []string `json:"domains,omitempty"`
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+}
+
+// Response is the decision returned by a policy webhook.
+type Response struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// RequestForCertificate builds the Request that should be sent to a policy
+// webhook ahead of issuing or renewing crt with the named issuer.
+func RequestForCertificate(issuerName string, crt *v1alpha1.Certificate) *Request {
+	return &Request{
+		Namespace:   crt.Namespace,
+		Name:        crt.Name,
+		Issuer:      issuerName,
+		Domains:     crt.Spec.Domains,
+		IPAddresses: crt.Spec.IPAddresses,
+	}
+}
+
+// Check POSTs req to cfg's webhook URL and returns an error if the request
+// is denied. A nil cfg, or one with an empty URL, always allows. If the
+// webhook cannot be reached, does not respond within requestTimeout, or
+// returns an invalid response, the request is denied unless
+// cfg.FailurePolicy is "Ignore". ctx bounds the whole call, in addition to
+// requestTimeout, so the caller's own deadline is also honoured.
+func Check(ctx context.Context, cfg *v1alpha1.PolicyWebhook, req *Request) error {
+	if cfg == nil || cfg.URL == "" {
+		return nil
+	}
+
+	if err := check(ctx, cfg, req); err != nil {
+		if cfg.FailurePolicy == "Ignore" {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+func check(ctx context.Context, cfg *v1alpha1.PolicyWebhook, req *Request) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("error encoding policy webhook request: %s", err.Error())
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error building policy webhook request: %s", err.Error())
+	}
+	httpReq = httpReq.WithContext(ctx)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("error calling policy webhook: %s", err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("policy webhook returned unexpected status code: %d", resp.StatusCode)
+	}
+
+	var out Response
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return fmt.Errorf("error decoding policy webhook response: %s", err.Error())
+	}
+
+	if !out.Allowed {
+		if out.Reason != "" {
+			return fmt.Errorf("denied by policy webhook: %s", out.Reason)
+		}
+		return fmt.Errorf("denied by policy webhook")
+	}
+
+	return nil
+}
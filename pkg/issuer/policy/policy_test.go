@@ -0,0 +1,114 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
+
+func testCertificate() *v1alpha1.Certificate {
+	return &v1alpha1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "example"},
+		Spec:       v1alpha1.CertificateSpec{Domains: []string{"example.com"}},
+	}
+}
+
+func TestRequestForCertificate(t *testing.T) {
+	crt := testCertificate()
+
+	req := RequestForCertificate("my-issuer", crt)
+	if req.Namespace != "default" || req.Name != "example" || req.Issuer != "my-issuer" {
+		t.Errorf("unexpected request: %+v", req)
+	}
+	if len(req.Domains) != 1 || req.Domains[0] != "example.com" {
+		t.Errorf("unexpected domains: %+v", req.Domains)
+	}
+}
+
+func TestCheckNilConfigAllows(t *testing.T) {
+	if err := Check(context.Background(), nil, RequestForCertificate("my-issuer", testCertificate())); err != nil {
+		t.Errorf("expected nil cfg to allow, got error: %s", err.Error())
+	}
+
+	cfg := &v1alpha1.PolicyWebhook{}
+	if err := Check(context.Background(), cfg, RequestForCertificate("my-issuer", testCertificate())); err != nil {
+		t.Errorf("expected empty URL to allow, got error: %s", err.Error())
+	}
+}
+
+func TestCheckAllowed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("error decoding request body: %s", err.Error())
+		}
+		if req.Name != "example" {
+			t.Errorf("unexpected request: %+v", req)
+		}
+
+		json.NewEncoder(w).Encode(Response{Allowed: true})
+	}))
+	defer srv.Close()
+
+	cfg := &v1alpha1.PolicyWebhook{URL: srv.URL}
+	if err := Check(context.Background(), cfg, RequestForCertificate("my-issuer", testCertificate())); err != nil {
+		t.Errorf("expected webhook to allow, got error: %s", err.Error())
+	}
+}
+
+func TestCheckDenied(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Response{Allowed: false, Reason: "no thanks"})
+	}))
+	defer srv.Close()
+
+	cfg := &v1alpha1.PolicyWebhook{URL: srv.URL}
+	err := Check(context.Background(), cfg, RequestForCertificate("my-issuer", testCertificate()))
+	if err == nil {
+		t.Fatalf("expected denial to return an error")
+	}
+	if err.Error() != "denied by policy webhook: no thanks" {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+}
+
+func TestCheckUnreachableFailurePolicy(t *testing.T) {
+	// No server listening on this address, so the request fails to connect.
+	cfg := &v1alpha1.PolicyWebhook{URL: "http://127.0.0.1:0"}
+
+	if err := Check(context.Background(), cfg, RequestForCertificate("my-issuer", testCertificate())); err == nil {
+		t.Errorf("expected default FailurePolicy to deny when webhook is unreachable")
+	}
+
+	cfg.FailurePolicy = "Ignore"
+	if err := Check(context.Background(), cfg, RequestForCertificate("my-issuer", testCertificate())); err != nil {
+		t.Errorf("expected FailurePolicy Ignore to allow when webhook is unreachable, got error: %s", err.Error())
+	}
+}
+
+func TestCheckTimesOutSlowWebhook(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	cfg := &v1alpha1.PolicyWebhook{URL: srv.URL}
+	start := time.Now()
+	err := Check(ctx, cfg, RequestForCertificate("my-issuer", testCertificate()))
+	if err == nil {
+		t.Fatalf("expected a timed-out webhook call to return an error")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Check took too long to return after its context expired: %s", elapsed)
+	}
+}
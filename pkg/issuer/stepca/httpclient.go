@@ -0,0 +1,34 @@
+package stepca
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+)
+
+// httpClient returns the http.Client that should be used for all requests
+// made to this issuer's step-ca server. If the issuer has a CABundle
+// configured, it is trusted in addition to the system trust store when
+// validating the step-ca server's TLS certificate - this is typically
+// required, since step-ca commonly serves its API using a certificate it
+// issued itself.
+func (s *StepCA) httpClient() (*http.Client, error) {
+	caBundle := s.issuer.Spec.StepCA.CABundle
+	if len(caBundle) == 0 {
+		return http.DefaultClient, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(caBundle) {
+		return nil, fmt.Errorf("error parsing CA bundle: no valid PEM certificates found")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+
+	return &http.Client{Transport: transport}, nil
+}
@@ -0,0 +1,164 @@
+package stepca
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/kube"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/pki"
+)
+
+const (
+	errorGetCertKeyPair = "ErrGetCertKeyPair"
+	errorIssueCert      = "ErrIssueCert"
+
+	successCertIssued = "CertIssueSuccess"
+
+	messageErrorGetCertKeyPair = "Error getting keypair for certificate: "
+	messageErrorIssueCert      = "Error issuing TLS certificate: "
+
+	messageCertIssued = "Certificate issued successfully"
+)
+
+// signResponse is the subset of step-ca's POST /1.0/sign response body that
+// this issuer needs: the issued leaf certificate and the CA certificate
+// that signed it, both PEM encoded.
+type signResponse struct {
+	Crt string `json:"crt"`
+	CA  string `json:"ca"`
+}
+
+func (s *StepCA) Issue(ctx context.Context, crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, []byte, []byte, []byte, error) {
+	update := crt.DeepCopy()
+
+	keySize := crt.Spec.KeySize
+	if keySize == 0 {
+		keySize = pki.DefaultRSAKeySize
+	}
+
+	signeeKey, err := kube.SecretTLSKeyRef(s.secretsLister, crt.Namespace, crt.Spec.SecretName, crt.PrivateKeyKey())
+	regenerate := k8sErrors.IsNotFound(err)
+
+	if err == nil && !pki.PrivateKeyMatchesSize(signeeKey, keySize) {
+		regenerate = true
+		err = nil
+	}
+
+	if regenerate {
+		signeeKey, err = pki.GenerateRSAPrivateKey(keySize)
+	}
+
+	if err != nil {
+		msg := messageErrorGetCertKeyPair + err.Error()
+		update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionFalse, errorGetCertKeyPair, msg)
+		return update.Status, nil, nil, nil, err
+	}
+
+	certPem, caPem, err := s.obtainCertificate(ctx, crt, signeeKey)
+	if err != nil {
+		msg := messageErrorIssueCert + err.Error()
+		update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionFalse, errorIssueCert, msg)
+		return update.Status, nil, nil, nil, err
+	}
+
+	update.UpdateStatusCondition(v1alpha1.CertificateConditionReady, v1alpha1.ConditionTrue, successCertIssued, messageCertIssued)
+
+	return update.Status, pki.EncodePKCS1PrivateKey(signeeKey), certPem, caPem, nil
+}
+
+// obtainCertificate builds a CSR for crt, mints a one-time token from the
+// configured provisioner and POSTs both to step-ca's sign endpoint,
+// returning the issued certificate and CA bundle PEMs.
+func (s *StepCA) obtainCertificate(ctx context.Context, crt *v1alpha1.Certificate, signeeKey interface{}) ([]byte, []byte, error) {
+	stepCA := s.issuer.Spec.StepCA
+
+	csrPEM, err := buildCSR(crt, signeeKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ott, err := s.mintProvisionerToken(crt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpClient, err := s.httpClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"csr": string(csrPEM),
+		"ott": ott,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building sign request: %s", err.Error())
+	}
+
+	req, err := http.NewRequest(http.MethodPost, stepCA.URL+"/1.0/sign", bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building sign request: %s", err.Error())
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error calling step-ca sign endpoint: %s", err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, nil, fmt.Errorf("step-ca sign endpoint returned unexpected status code %d", resp.StatusCode)
+	}
+
+	return parseSignResponse(resp.Body)
+}
+
+// buildCSR returns the PEM encoded certificate request step-ca's sign
+// endpoint expects for crt, signed by signeeKey. step-ca requires a SAN
+// extension to prove domain control, so crt.Spec.CommonNameOnly is
+// rejected.
+func buildCSR(crt *v1alpha1.Certificate, signeeKey interface{}) ([]byte, error) {
+	if crt.Spec.CommonNameOnly {
+		return nil, fmt.Errorf("commonNameOnly is not supported by the StepCA issuer: a SAN extension is required to prove domain control")
+	}
+
+	csrTemplate := pki.GenerateCSR(crt.Spec.Domains, crt.Spec.IPAddresses)
+	csrDER, err := x509.CreateCertificateRequest(nil, csrTemplate, signeeKey)
+	if err != nil {
+		return nil, fmt.Errorf("error creating certificate request: %s", err.Error())
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER}), nil
+}
+
+// parseSignResponse decodes a step-ca POST /1.0/sign response body into
+// its issued certificate and CA bundle PEMs.
+func parseSignResponse(body io.Reader) ([]byte, []byte, error) {
+	var signResp signResponse
+	if err := json.NewDecoder(body).Decode(&signResp); err != nil {
+		return nil, nil, fmt.Errorf("error decoding step-ca sign response: %s", err.Error())
+	}
+
+	return []byte(signResp.Crt), []byte(signResp.CA), nil
+}
+
+// mintProvisionerToken constructs the one-time token step-ca requires on
+// every sign request. step-ca's JWK provisioners require this to be a JWS
+// signed with the provisioner's private key (decrypted using
+// ProvisionerPasswordSecretRef); this repository does not currently vendor
+// a JOSE/JWT library, so token minting is not yet implemented. The
+// remainder of this issuer (CSR construction, the sign request and
+// response handling) is wired up and ready to use one once available.
+func (s *StepCA) mintProvisionerToken(crt *v1alpha1.Certificate) (string, error) {
+	return "", fmt.Errorf("stepca issuer: minting a provisioner token requires a JOSE/JWT library, which is not currently vendored in cert-manager")
+}
@@ -0,0 +1,83 @@
+package stepca
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/util/pki"
+)
+
+// TestBuildCSR verifies that buildCSR produces a PEM encoded certificate
+// request for crt's domains, signed by signeeKey.
+func TestBuildCSR(t *testing.T) {
+	key, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatalf("error generating test key: %s", err.Error())
+	}
+
+	crt := &v1alpha1.Certificate{
+		Spec: v1alpha1.CertificateSpec{Domains: []string{"example.com", "www.example.com"}},
+	}
+
+	csrPEM, err := buildCSR(crt, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	block, _ := pem.Decode(csrPEM)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		t.Fatalf("expected a PEM encoded certificate request, got: %s", csrPEM)
+	}
+
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		t.Fatalf("error parsing certificate request: %s", err.Error())
+	}
+	if len(csr.DNSNames) != 2 || csr.DNSNames[0] != "example.com" || csr.DNSNames[1] != "www.example.com" {
+		t.Errorf("unexpected DNS names in CSR: %v", csr.DNSNames)
+	}
+}
+
+// TestBuildCSRRejectsCommonNameOnly verifies that buildCSR refuses
+// Spec.CommonNameOnly, since step-ca requires a SAN extension to prove
+// domain control.
+func TestBuildCSRRejectsCommonNameOnly(t *testing.T) {
+	key, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatalf("error generating test key: %s", err.Error())
+	}
+
+	crt := &v1alpha1.Certificate{
+		Spec: v1alpha1.CertificateSpec{Domains: []string{"example.com"}, CommonNameOnly: true},
+	}
+
+	if _, err := buildCSR(crt, key); err == nil {
+		t.Fatalf("expected an error for commonNameOnly")
+	}
+}
+
+// TestParseSignResponse verifies that parseSignResponse extracts the issued
+// certificate and CA bundle PEMs from a step-ca sign response body.
+func TestParseSignResponse(t *testing.T) {
+	body := `{"crt": "leaf-pem", "ca": "ca-pem"}`
+
+	crt, ca, err := parseSignResponse(strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(crt) != "leaf-pem" || string(ca) != "ca-pem" {
+		t.Errorf("unexpected result: crt=%q ca=%q", crt, ca)
+	}
+}
+
+// TestParseSignResponseInvalidJSON verifies that parseSignResponse returns
+// an error for a malformed response body, rather than silently returning
+// empty PEMs.
+func TestParseSignResponseInvalidJSON(t *testing.T) {
+	if _, _, err := parseSignResponse(strings.NewReader("not json")); err == nil {
+		t.Fatalf("expected an error for a malformed sign response")
+	}
+}
@@ -0,0 +1,20 @@
+package stepca
+
+import (
+	"context"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
+
+// Prepare does nothing for the step-ca issuer, since step-ca signs a
+// certificate in a single request rather than requiring a separate
+// authorization step.
+func (s *StepCA) Prepare(ctx context.Context, crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, error) {
+	return crt.Status, nil
+}
+
+// CleanupStalePendingChallenges does nothing for the step-ca issuer, since
+// it doesn't perform challenge based domain validation.
+func (s *StepCA) CleanupStalePendingChallenges(ctx context.Context, crt *v1alpha1.Certificate) (v1alpha1.CertificateStatus, error) {
+	return crt.Status, nil
+}
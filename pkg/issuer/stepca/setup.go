@@ -0,0 +1,115 @@
+package stepca
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"k8s.io/api/core/v1"
+
+	"github.com/golang/glog"
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
+
+const (
+	errorInvalidConfig           = "ErrInvalidConfig"
+	errorGetProvisionerCreds     = "ErrGetProvisionerCreds"
+	errorServerUnreachable       = "ErrServerUnreachable"
+	errorTokenMintingUnsupported = "ErrTokenMintingUnsupported"
+
+	messageInvalidConfig            = "Invalid step-ca issuer configuration: "
+	messageErrorGetProvisionerCreds = "Error getting provisioner password: "
+	messageErrorServerUnreachable   = "Error reaching step-ca server: "
+
+	messageServerReachable = "The step-ca server was reachable and the provisioner credentials were found"
+
+	// messageTokenMintingUnsupported explains why this issuer can never
+	// become Ready, even once the checks above pass: mintProvisionerToken
+	// (issue.go) is a stub, because this repository does not vendor a
+	// JOSE/JWT library capable of signing a JWK provisioner one-time
+	// token. Every Issue/Renew call would otherwise fail despite a
+	// "verified" Ready condition, so Setup fails closed here rather than
+	// reporting a working issuer.
+	messageTokenMintingUnsupported = "step-ca provisioner token minting is not yet implemented (requires a vendored JOSE/JWT library); this issuer cannot issue certificates"
+)
+
+// Setup checks that this issuer is correctly configured and that its
+// step-ca server is reachable, before it is used to issue certificates.
+func (s *StepCA) Setup(ctx context.Context) (v1alpha1.IssuerStatus, error) {
+	update := s.issuer.DeepCopy()
+
+	stepCA := update.Spec.StepCA
+	if stepCA.URL == "" || stepCA.Provisioner == "" {
+		s := messageInvalidConfig + "url and provisioner are required"
+		glog.Info(s)
+		update.UpdateStatusCondition(v1alpha1.IssuerConditionReady, v1alpha1.ConditionFalse, errorInvalidConfig, s)
+		return update.Status, fmt.Errorf(s)
+	}
+
+	secret, err := s.secretsLister.Secrets(update.Namespace).Get(stepCA.ProvisionerPasswordSecretRef.Name)
+	if err != nil {
+		msg := messageErrorGetProvisionerCreds + err.Error()
+		glog.Info(msg)
+		s.recorder.Event(update, v1.EventTypeWarning, errorGetProvisionerCreds, msg)
+		update.UpdateStatusCondition(v1alpha1.IssuerConditionReady, v1alpha1.ConditionFalse, errorGetProvisionerCreds, msg)
+		return update.Status, fmt.Errorf(msg)
+	}
+
+	if _, ok := secret.Data[stepCA.ProvisionerPasswordSecretRef.Key]; !ok {
+		msg := messageErrorGetProvisionerCreds + fmt.Sprintf("key %q not found in secret %q", stepCA.ProvisionerPasswordSecretRef.Key, stepCA.ProvisionerPasswordSecretRef.Name)
+		glog.Info(msg)
+		s.recorder.Event(update, v1.EventTypeWarning, errorGetProvisionerCreds, msg)
+		update.UpdateStatusCondition(v1alpha1.IssuerConditionReady, v1alpha1.ConditionFalse, errorGetProvisionerCreds, msg)
+		return update.Status, fmt.Errorf(msg)
+	}
+
+	httpClient, err := s.httpClient()
+	if err != nil {
+		msg := messageErrorServerUnreachable + err.Error()
+		glog.Info(msg)
+		s.recorder.Event(update, v1.EventTypeWarning, errorServerUnreachable, msg)
+		update.UpdateStatusCondition(v1alpha1.IssuerConditionReady, v1alpha1.ConditionFalse, errorServerUnreachable, msg)
+		return update.Status, fmt.Errorf(msg)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, stepCA.URL+"/health", nil)
+	if err != nil {
+		msg := messageErrorServerUnreachable + err.Error()
+		glog.Info(msg)
+		s.recorder.Event(update, v1.EventTypeWarning, errorServerUnreachable, msg)
+		update.UpdateStatusCondition(v1alpha1.IssuerConditionReady, v1alpha1.ConditionFalse, errorServerUnreachable, msg)
+		return update.Status, fmt.Errorf(msg)
+	}
+
+	resp, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		msg := messageErrorServerUnreachable + err.Error()
+		glog.Info(msg)
+		s.recorder.Event(update, v1.EventTypeWarning, errorServerUnreachable, msg)
+		update.UpdateStatusCondition(v1alpha1.IssuerConditionReady, v1alpha1.ConditionFalse, errorServerUnreachable, msg)
+		return update.Status, fmt.Errorf(msg)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg := messageErrorServerUnreachable + fmt.Sprintf("unexpected status code %d", resp.StatusCode)
+		glog.Info(msg)
+		s.recorder.Event(update, v1.EventTypeWarning, errorServerUnreachable, msg)
+		update.UpdateStatusCondition(v1alpha1.IssuerConditionReady, v1alpha1.ConditionFalse, errorServerUnreachable, msg)
+		return update.Status, fmt.Errorf(msg)
+	}
+
+	glog.Info(messageServerReachable)
+	s.recorder.Event(update, v1.EventTypeNormal, "StepCAServerVerified", messageServerReachable)
+
+	// The server is reachable and the provisioner credentials exist, but
+	// this issuer cannot mint the provisioner token every sign request
+	// needs (see mintProvisionerToken in issue.go), so it is never
+	// actually able to issue certificates. Fail closed rather than
+	// report Ready=True for a path that is unconditionally broken.
+	glog.Info(messageTokenMintingUnsupported)
+	s.recorder.Event(update, v1.EventTypeWarning, errorTokenMintingUnsupported, messageTokenMintingUnsupported)
+	update.UpdateStatusCondition(v1alpha1.IssuerConditionReady, v1alpha1.ConditionFalse, errorTokenMintingUnsupported, messageTokenMintingUnsupported)
+
+	return update.Status, fmt.Errorf(messageTokenMintingUnsupported)
+}
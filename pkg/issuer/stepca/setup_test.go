@@ -0,0 +1,156 @@
+package stepca
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
+
+func newTestStepCA(t *testing.T, issuer *v1alpha1.Issuer, secrets ...*api.Secret) *StepCA {
+	t.Helper()
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, secret := range secrets {
+		if err := indexer.Add(secret); err != nil {
+			t.Fatalf("error adding secret to indexer: %s", err.Error())
+		}
+	}
+
+	return &StepCA{
+		issuer:        issuer,
+		recorder:      record.NewFakeRecorder(10),
+		secretsLister: corelisters.NewSecretLister(indexer),
+	}
+}
+
+func testStepCAIssuer(url string) *v1alpha1.Issuer {
+	return &v1alpha1.Issuer{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "step-ca"},
+		Spec: v1alpha1.IssuerSpec{
+			StepCA: &v1alpha1.StepCAIssuer{
+				URL:         url,
+				Provisioner: "admin",
+				ProvisionerPasswordSecretRef: v1alpha1.SecretKeySelector{
+					LocalObjectReference: v1alpha1.LocalObjectReference{Name: "step-ca-provisioner"},
+					Key:                  "password",
+				},
+			},
+		},
+	}
+}
+
+// readyCondition returns status's IssuerConditionReady condition, or nil if
+// it hasn't been set.
+func readyCondition(status v1alpha1.IssuerStatus) *v1alpha1.IssuerCondition {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == v1alpha1.IssuerConditionReady {
+			return &status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func testProvisionerSecret() *api.Secret {
+	return &api.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "step-ca-provisioner"},
+		Data:       map[string][]byte{"password": []byte("hunter2")},
+	}
+}
+
+// TestSetupInvalidConfig verifies that Setup rejects an issuer missing
+// spec.stepCA.url or spec.stepCA.provisioner before looking at anything
+// else.
+func TestSetupInvalidConfig(t *testing.T) {
+	issuer := testStepCAIssuer("")
+	s := newTestStepCA(t, issuer)
+
+	status, err := s.Setup(context.Background())
+	if err == nil {
+		t.Fatalf("expected an error for a missing url")
+	}
+	if cond := readyCondition(status); cond == nil || cond.Status != v1alpha1.ConditionFalse || cond.Reason != errorInvalidConfig {
+		t.Errorf("expected a False Ready condition with reason %q, got: %+v", errorInvalidConfig, cond)
+	}
+}
+
+// TestSetupMissingProvisionerSecret verifies that Setup fails when the
+// provisioner password Secret referenced by spec.stepCA doesn't exist.
+func TestSetupMissingProvisionerSecret(t *testing.T) {
+	issuer := testStepCAIssuer("https://ca.example.com")
+	s := newTestStepCA(t, issuer)
+
+	status, err := s.Setup(context.Background())
+	if err == nil {
+		t.Fatalf("expected an error for a missing provisioner secret")
+	}
+	if cond := readyCondition(status); cond == nil || cond.Status != v1alpha1.ConditionFalse || cond.Reason != errorGetProvisionerCreds {
+		t.Errorf("expected a False Ready condition with reason %q, got: %+v", errorGetProvisionerCreds, cond)
+	}
+}
+
+// TestSetupMissingProvisionerSecretKey verifies that Setup fails when the
+// provisioner password Secret exists but doesn't have the configured key.
+func TestSetupMissingProvisionerSecretKey(t *testing.T) {
+	issuer := testStepCAIssuer("https://ca.example.com")
+	secret := &api.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "step-ca-provisioner"},
+		Data:       map[string][]byte{"other-key": []byte("hunter2")},
+	}
+	s := newTestStepCA(t, issuer, secret)
+
+	status, err := s.Setup(context.Background())
+	if err == nil {
+		t.Fatalf("expected an error for a missing secret key")
+	}
+	if cond := readyCondition(status); cond == nil || cond.Status != v1alpha1.ConditionFalse || cond.Reason != errorGetProvisionerCreds {
+		t.Errorf("expected a False Ready condition with reason %q, got: %+v", errorGetProvisionerCreds, cond)
+	}
+}
+
+// TestSetupServerUnreachable verifies that Setup fails when the step-ca
+// server cannot be reached.
+func TestSetupServerUnreachable(t *testing.T) {
+	issuer := testStepCAIssuer("http://127.0.0.1:0")
+	s := newTestStepCA(t, issuer, testProvisionerSecret())
+
+	status, err := s.Setup(context.Background())
+	if err == nil {
+		t.Fatalf("expected an error for an unreachable server")
+	}
+	if cond := readyCondition(status); cond == nil || cond.Status != v1alpha1.ConditionFalse || cond.Reason != errorServerUnreachable {
+		t.Errorf("expected a False Ready condition with reason %q, got: %+v", errorServerUnreachable, cond)
+	}
+}
+
+// TestSetupFailsClosedWhenServerIsReachable verifies that Setup still
+// reports Ready=False, with errorTokenMintingUnsupported, even once the
+// server is reachable and the provisioner credentials are present - since
+// mintProvisionerToken (issue.go) cannot actually mint a token, this issuer
+// can never successfully Issue or Renew.
+func TestSetupFailsClosedWhenServerIsReachable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	issuer := testStepCAIssuer(srv.URL)
+	s := newTestStepCA(t, issuer, testProvisionerSecret())
+
+	status, err := s.Setup(context.Background())
+	if err == nil {
+		t.Fatalf("expected Setup to fail closed, got no error")
+	}
+	cond := readyCondition(status)
+	if cond == nil || cond.Status != v1alpha1.ConditionFalse || cond.Reason != errorTokenMintingUnsupported {
+		t.Errorf("expected a False Ready condition with reason %q, got: %+v", errorTokenMintingUnsupported, cond)
+	}
+}
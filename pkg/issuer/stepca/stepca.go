@@ -0,0 +1,64 @@
+package stepca
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack-experimental/cert-manager/pkg/client/clientset"
+	"github.com/jetstack-experimental/cert-manager/pkg/issuer"
+)
+
+// StepCA is an issuer that requests certificates from a Smallstep step-ca
+// server's provisioner API, for users who already operate step-ca as their
+// online CA. Only JWK provisioners are modeled (see StepCAIssuer); OIDC
+// provisioners are not supported. Issuance is additionally blocked on
+// minting a provisioner token (see mintProvisionerToken in issue.go), which
+// requires a JOSE/JWT library this repository does not yet vendor, so
+// Setup always reports this issuer as not Ready.
+type StepCA struct {
+	issuer *v1alpha1.Issuer
+
+	client   kubernetes.Interface
+	cmClient clientset.Interface
+	recorder record.EventRecorder
+
+	secretsLister corelisters.SecretLister
+}
+
+// New returns a new step-ca issuer interface for the given issuer.
+func New(issuer *v1alpha1.Issuer,
+	client kubernetes.Interface,
+	cmClient clientset.Interface,
+	recorder record.EventRecorder,
+	secretsInformer cache.SharedIndexInformer) (issuer.Interface, error) {
+	secretsLister := corelisters.NewSecretLister(secretsInformer.GetIndexer())
+	return &StepCA{
+		issuer:        issuer,
+		client:        client,
+		cmClient:      cmClient,
+		recorder:      recorder,
+		secretsLister: secretsLister,
+	}, nil
+}
+
+func init() {
+	issuer.Register(issuer.IssuerStepCA, func(i *v1alpha1.Issuer, ctx *issuer.Context) (issuer.Interface, error) {
+		return New(
+			i,
+			ctx.Client,
+			ctx.CMClient,
+			ctx.Recorder,
+			ctx.SharedInformerFactory.InformerFor(
+				ctx.Namespace,
+				metav1.GroupVersionKind{Version: "v1", Kind: "Secret"},
+				coreinformers.NewSecretInformer(ctx.Client, ctx.Namespace, time.Second*30, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})),
+		)
+	})
+}
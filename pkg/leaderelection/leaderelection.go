@@ -0,0 +1,59 @@
+// Package leaderelection wraps k8s.io/client-go/tools/leaderelection,
+// adding the ability to release a held lease on graceful shutdown - a
+// capability the vendored package doesn't expose - without patching
+// vendor/.
+package leaderelection
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sleaderelection "k8s.io/client-go/tools/leaderelection"
+	rl "k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// Elector wraps a running *k8sleaderelection.LeaderElector together with
+// the LeaderElectionConfig it was started with, so that its lease can be
+// released afterwards via Release.
+type Elector struct {
+	le     *k8sleaderelection.LeaderElector
+	config k8sleaderelection.LeaderElectionConfig
+}
+
+// RunOrDie starts a client with the provided config or panics if the
+// config fails to validate, exactly like
+// k8sleaderelection.LeaderElector.RunOrDie, but returns an Elector once the
+// election loop has returned so the caller can Release its lease.
+func RunOrDie(lec k8sleaderelection.LeaderElectionConfig) *Elector {
+	le, err := k8sleaderelection.NewLeaderElector(lec)
+	if err != nil {
+		panic(err)
+	}
+
+	e := &Elector{le: le, config: lec}
+	le.Run()
+	return e
+}
+
+// Release gives up the leader lease, if e currently holds it, by updating
+// the lock record to an empty HolderIdentity. This allows a new leader to
+// be acquired immediately, rather than waiting for the lease to expire. It
+// is safe to call even if the lease is not currently held.
+func (e *Elector) Release() bool {
+	if !e.le.IsLeader() {
+		return true
+	}
+
+	record := rl.LeaderElectionRecord{
+		HolderIdentity:       "",
+		LeaseDurationSeconds: int(e.config.LeaseDuration / time.Second),
+		AcquireTime:          metav1.Now(),
+		RenewTime:            metav1.Now(),
+	}
+	if err := e.config.Lock.Update(record); err != nil {
+		glog.Errorf("Failed to release lock: %v", err)
+		return false
+	}
+	return true
+}
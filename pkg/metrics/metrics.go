@@ -0,0 +1,183 @@
+// Package metrics exposes a minimal Prometheus text-format /metrics
+// endpoint for cert-manager's own certificate state, without depending on
+// the full prometheus client library.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/golang/glog"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/client/clientset"
+)
+
+// renewBefore mirrors the renewal window used by the certificates
+// controller (pkg/controller/certificates) to decide when a Certificate is
+// due for renewal.
+const renewBefore = time.Hour * 24 * 30
+
+// issuanceLatencyQuantiles are the percentiles reported for
+// certmanager_certificate_issuance_duration_seconds, mirroring the
+// quantiles a Prometheus client_golang Summary would expose.
+var issuanceLatencyQuantiles = []float64{0.5, 0.9, 0.99}
+
+// Collector serves Prometheus metrics describing the Certificate resources
+// known to the controller.
+type Collector struct {
+	cmClient clientset.Interface
+}
+
+// New returns a Collector that reads Certificate state from the given
+// cert-manager clientset when scraped.
+func New(cmClient clientset.Interface) *Collector {
+	return &Collector{cmClient: cmClient}
+}
+
+// Handler returns an http.Handler that serves the collected metrics in the
+// Prometheus text exposition format.
+func (c *Collector) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		if err := c.write(w); err != nil {
+			glog.Errorf("error writing metrics: %s", err.Error())
+		}
+	})
+}
+
+func (c *Collector) write(w io.Writer) error {
+	list, err := c.cmClient.CertmanagerV1alpha1().Certificates(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing certificates: %s", err.Error())
+	}
+	crts := list.Items
+
+	fmt.Fprintln(w, "# HELP certmanager_certificate_expiration_timestamp_seconds The date after which the certificate expires, in unix epoch time")
+	fmt.Fprintln(w, "# TYPE certmanager_certificate_expiration_timestamp_seconds gauge")
+	for _, crt := range crts {
+		if crt.Status.NotAfter == nil {
+			continue
+		}
+		fmt.Fprintf(w, "certmanager_certificate_expiration_timestamp_seconds{name=%q,namespace=%q,issuer=%q} %d\n",
+			crt.Name, crt.Namespace, crt.Spec.Issuer, crt.Status.NotAfter.Unix())
+	}
+
+	fmt.Fprintln(w, "# HELP certmanager_certificate_renewal_timestamp_seconds The date at which the certificate will next be attempted to be renewed, in unix epoch time")
+	fmt.Fprintln(w, "# TYPE certmanager_certificate_renewal_timestamp_seconds gauge")
+	for _, crt := range crts {
+		if crt.Status.NotAfter == nil {
+			continue
+		}
+		renewalTime := crt.Status.NotAfter.Add(-renewBefore)
+		fmt.Fprintf(w, "certmanager_certificate_renewal_timestamp_seconds{name=%q,namespace=%q,issuer=%q} %d\n",
+			crt.Name, crt.Namespace, crt.Spec.Issuer, renewalTime.Unix())
+	}
+
+	fmt.Fprintln(w, "# HELP certmanager_certificate_last_issuance_duration_seconds How long the most recent issuance or renewal attempt for a Certificate took, from being triggered to reaching a terminal outcome")
+	fmt.Fprintln(w, "# TYPE certmanager_certificate_last_issuance_duration_seconds gauge")
+	var succeededDurations []float64
+	for _, crt := range crts {
+		if crt.Status.LastIssuance == nil {
+			continue
+		}
+		fmt.Fprintf(w, "certmanager_certificate_last_issuance_duration_seconds{name=%q,namespace=%q,issuer=%q,succeeded=\"%t\"} %f\n",
+			crt.Name, crt.Namespace, crt.Spec.Issuer, crt.Status.LastIssuance.Succeeded, crt.Status.LastIssuance.DurationSeconds)
+		if crt.Status.LastIssuance.Succeeded {
+			succeededDurations = append(succeededDurations, crt.Status.LastIssuance.DurationSeconds)
+		}
+	}
+
+	fmt.Fprintln(w, "# HELP certmanager_certificate_issuance_duration_seconds Percentiles of how long successful issuance/renewal attempts take to reach Ready, across all Certificates' most recent attempts, for SLO monitoring")
+	fmt.Fprintln(w, "# TYPE certmanager_certificate_issuance_duration_seconds summary")
+	for _, q := range issuanceLatencyQuantiles {
+		fmt.Fprintf(w, "certmanager_certificate_issuance_duration_seconds{quantile=\"%g\"} %f\n", q, quantile(succeededDurations, q))
+	}
+	fmt.Fprintf(w, "certmanager_certificate_issuance_duration_seconds_sum %f\n", sum(succeededDurations))
+	fmt.Fprintf(w, "certmanager_certificate_issuance_duration_seconds_count %d\n", len(succeededDurations))
+
+	issuerList, err := c.cmClient.CertmanagerV1alpha1().Issuers(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing issuers: %s", err.Error())
+	}
+	issuers := issuerList.Items
+
+	fmt.Fprintln(w, "# HELP certmanager_issuer_synthetic_probe_success Whether the most recent synthetic probe issuance for an issuer succeeded, for issuers with a synthetic probe configured")
+	fmt.Fprintln(w, "# TYPE certmanager_issuer_synthetic_probe_success gauge")
+	for _, iss := range issuers {
+		if iss.Status.SyntheticProbe == nil {
+			continue
+		}
+		success := 0
+		if iss.Status.SyntheticProbe.Succeeded {
+			success = 1
+		}
+		fmt.Fprintf(w, "certmanager_issuer_synthetic_probe_success{name=%q,namespace=%q} %d\n",
+			iss.Name, iss.Namespace, success)
+	}
+
+	fmt.Fprintln(w, "# HELP certmanager_issuer_synthetic_probe_duration_seconds How long the most recent synthetic probe issuance for an issuer took to complete")
+	fmt.Fprintln(w, "# TYPE certmanager_issuer_synthetic_probe_duration_seconds gauge")
+	for _, iss := range issuers {
+		if iss.Status.SyntheticProbe == nil {
+			continue
+		}
+		fmt.Fprintf(w, "certmanager_issuer_synthetic_probe_duration_seconds{name=%q,namespace=%q} %f\n",
+			iss.Name, iss.Namespace, iss.Status.SyntheticProbe.DurationSeconds)
+	}
+
+	fmt.Fprintln(w, "# HELP certmanager_issuer_synthetic_probe_timestamp_seconds When the most recent synthetic probe issuance for an issuer was attempted, in unix epoch time")
+	fmt.Fprintln(w, "# TYPE certmanager_issuer_synthetic_probe_timestamp_seconds gauge")
+	for _, iss := range issuers {
+		if iss.Status.SyntheticProbe == nil {
+			continue
+		}
+		fmt.Fprintf(w, "certmanager_issuer_synthetic_probe_timestamp_seconds{name=%q,namespace=%q} %d\n",
+			iss.Name, iss.Namespace, iss.Status.SyntheticProbe.LastProbeTime.Unix())
+	}
+
+	fmt.Fprintln(w, "# HELP certmanager_issuer_signer_expiration_timestamp_seconds The date after which a CA issuer's signing certificate expires, in unix epoch time, reported while the issuer's SignerExpiringSoon condition is true")
+	fmt.Fprintln(w, "# TYPE certmanager_issuer_signer_expiration_timestamp_seconds gauge")
+	for _, iss := range issuers {
+		if iss.Status.SignerExpiry == nil {
+			continue
+		}
+		fmt.Fprintf(w, "certmanager_issuer_signer_expiration_timestamp_seconds{name=%q,namespace=%q} %d\n",
+			iss.Name, iss.Namespace, iss.Status.SignerExpiry.Unix())
+	}
+
+	return nil
+}
+
+// quantile returns the q-quantile (0 <= q <= 1) of values using the
+// nearest-rank method. It returns 0 if values is empty.
+func quantile(values []float64, q float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	rank := int(q*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+// sum returns the sum of values.
+func sum(values []float64) float64 {
+	var total float64
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
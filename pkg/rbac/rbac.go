@@ -0,0 +1,104 @@
+// Package rbac holds the aggregated ClusterRoles cert-manager ships for its
+// CRDs, as Go values that can be installed onto a cluster in code (see
+// pkg/util/kube.EnsureClusterRole and VerifyClusterRole). They use the same
+// aggregation convention as the built-in Kubernetes view/edit/admin
+// ClusterRoles (rbac.authorization.k8s.io/aggregate-to-view, etc.), so a
+// cluster already using those aggregated roles automatically grants
+// sensible permissions on Certificates and Issuers, without the cluster
+// admin having to hand-write RBAC for cert-manager's CRDs.
+package rbac
+
+import (
+	rbacv1beta1 "k8s.io/api/rbac/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// resources are the cert-manager API resources that the aggregated roles in
+// this package grant access to.
+var resources = []string{"certificates", "issuers"}
+
+// All returns the aggregated ClusterRoles cert-manager requires.
+func All() []*rbacv1beta1.ClusterRole {
+	return []*rbacv1beta1.ClusterRole{
+		View(),
+		Edit(),
+		Admin(),
+	}
+}
+
+// View returns the ClusterRole aggregated into the cluster's "view" role,
+// granting read-only access to cert-manager's CRDs.
+func View() *rbacv1beta1.ClusterRole {
+	return &rbacv1beta1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cert-manager-view",
+			Labels: map[string]string{
+				"rbac.authorization.k8s.io/aggregate-to-view": "true",
+			},
+		},
+		Rules: []rbacv1beta1.PolicyRule{
+			{
+				APIGroups: []string{"certmanager.k8s.io"},
+				Resources: resources,
+				Verbs:     []string{"get", "list", "watch"},
+			},
+		},
+	}
+}
+
+// Edit returns the ClusterRole aggregated into the cluster's "edit" role,
+// granting read-write access to cert-manager's CRDs, but not to their
+// status subresource (which the controller, not a human editor, owns).
+func Edit() *rbacv1beta1.ClusterRole {
+	return &rbacv1beta1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cert-manager-edit",
+			Labels: map[string]string{
+				"rbac.authorization.k8s.io/aggregate-to-edit": "true",
+			},
+		},
+		Rules: []rbacv1beta1.PolicyRule{
+			{
+				APIGroups: []string{"certmanager.k8s.io"},
+				Resources: resources,
+				Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
+			},
+		},
+	}
+}
+
+// Admin returns the ClusterRole aggregated into the cluster's "admin" role.
+// It grants the same access as Edit, plus write access to the status
+// subresource, for namespace admins who need to clear a stuck condition by
+// hand.
+func Admin() *rbacv1beta1.ClusterRole {
+	return &rbacv1beta1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cert-manager-admin",
+			Labels: map[string]string{
+				"rbac.authorization.k8s.io/aggregate-to-admin": "true",
+			},
+		},
+		Rules: []rbacv1beta1.PolicyRule{
+			{
+				APIGroups: []string{"certmanager.k8s.io"},
+				Resources: resources,
+				Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
+			},
+			{
+				APIGroups: []string{"certmanager.k8s.io"},
+				Resources: subresources(resources, "status"),
+				Verbs:     []string{"get", "update", "patch"},
+			},
+		},
+	}
+}
+
+// subresources returns "<resource>/<subresource>" for every resource.
+func subresources(resourceList []string, subresource string) []string {
+	out := make([]string, len(resourceList))
+	for i, r := range resourceList {
+		out[i] = r + "/" + subresource
+	}
+	return out
+}
@@ -0,0 +1,119 @@
+// Package rollout implements an opt-in mechanism for triggering a rolling
+// restart of Deployments and StatefulSets that mount a given Secret, for
+// applications that don't watch their certificate files on disk and
+// reload them automatically. It is modelled on the same trick `kubectl
+// rollout restart` uses: stamping a timestamp onto the workload's pod
+// template causes Kubernetes to roll every Pod it owns, without changing
+// anything the application itself observes.
+package rollout
+
+import (
+	"fmt"
+	"time"
+
+	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/kubernetes"
+)
+
+// RestartedAtAnnotation is the pod template annotation `kubectl rollout
+// restart` itself uses. Reusing it means a restart triggered by this
+// package looks the same to any other tooling as one triggered manually.
+const RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// Trigger restarts Deployments and StatefulSets that reference a given
+// Secret, so their Pods pick up a certificate that's just been renewed.
+type Trigger struct {
+	client kubernetes.Interface
+}
+
+// New returns a new Trigger.
+func New(client kubernetes.Interface) *Trigger {
+	return &Trigger{client: client}
+}
+
+// Restart triggers a rolling restart of every Deployment and StatefulSet in
+// namespace whose Pod template references secretName, either via a Secret
+// volume or an envFrom/env secretKeyRef source. Errors restarting
+// individual workloads are collected and returned together, so a failure
+// restarting one workload doesn't prevent others from being restarted.
+func (t *Trigger) Restart(namespace, secretName string) error {
+	var errs []error
+
+	deployments, err := t.client.ExtensionsV1beta1().Deployments(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing Deployments in %s: %s", namespace, err.Error())
+	}
+	for _, d := range deployments.Items {
+		if !podSpecReferencesSecret(d.Spec.Template.Spec, secretName) {
+			continue
+		}
+		if err := t.restartDeployment(&d); err != nil {
+			errs = append(errs, fmt.Errorf("error restarting Deployment %s/%s: %s", d.Namespace, d.Name, err.Error()))
+		}
+	}
+
+	statefulSets, err := t.client.AppsV1beta1().StatefulSets(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing StatefulSets in %s: %s", namespace, err.Error())
+	}
+	for _, s := range statefulSets.Items {
+		if !podSpecReferencesSecret(s.Spec.Template.Spec, secretName) {
+			continue
+		}
+		if err := t.restartStatefulSet(&s); err != nil {
+			errs = append(errs, fmt.Errorf("error restarting StatefulSet %s/%s: %s", s.Namespace, s.Name, err.Error()))
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+func (t *Trigger) restartDeployment(d *extensionsv1beta1.Deployment) error {
+	stampRestart(&d.Spec.Template)
+	_, err := t.client.ExtensionsV1beta1().Deployments(d.Namespace).Update(d)
+	return err
+}
+
+func (t *Trigger) restartStatefulSet(s *appsv1beta1.StatefulSet) error {
+	stampRestart(&s.Spec.Template)
+	_, err := t.client.AppsV1beta1().StatefulSets(s.Namespace).Update(s)
+	return err
+}
+
+func stampRestart(tmpl *corev1.PodTemplateSpec) {
+	if tmpl.Annotations == nil {
+		tmpl.Annotations = make(map[string]string)
+	}
+	tmpl.Annotations[RestartedAtAnnotation] = time.Now().Format(time.RFC3339)
+}
+
+// podSpecReferencesSecret returns true if spec has a Secret volume, or a
+// container (or init container) with an envFrom or env secretKeyRef
+// source, naming secretName.
+func podSpecReferencesSecret(spec corev1.PodSpec, secretName string) bool {
+	for _, v := range spec.Volumes {
+		if v.Secret != nil && v.Secret.SecretName == secretName {
+			return true
+		}
+	}
+
+	containers := append(append([]corev1.Container{}, spec.Containers...), spec.InitContainers...)
+	for _, c := range containers {
+		for _, ef := range c.EnvFrom {
+			if ef.SecretRef != nil && ef.SecretRef.Name == secretName {
+				return true
+			}
+		}
+		for _, e := range c.Env {
+			if e.ValueFrom != nil && e.ValueFrom.SecretKeyRef != nil && e.ValueFrom.SecretKeyRef.Name == secretName {
+				return true
+			}
+		}
+	}
+
+	return false
+}
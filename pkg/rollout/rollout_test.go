@@ -0,0 +1,124 @@
+package rollout
+
+import (
+	"testing"
+
+	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPodSpecReferencesSecret(t *testing.T) {
+	tests := map[string]struct {
+		spec     corev1.PodSpec
+		expected bool
+	}{
+		"volume mount": {
+			spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{
+					{VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "target-tls"}}},
+				},
+			},
+			expected: true,
+		},
+		"envFrom": {
+			spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "target-tls"}}}}},
+				},
+			},
+			expected: true,
+		},
+		"env secretKeyRef on init container": {
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{Env: []corev1.EnvVar{{ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "target-tls"}, Key: "tls.crt"}}}}},
+				},
+			},
+			expected: true,
+		},
+		"unrelated secret": {
+			spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{
+					{VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "other-tls"}}},
+				},
+			},
+			expected: false,
+		},
+		"no references": {
+			spec:     corev1.PodSpec{},
+			expected: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := podSpecReferencesSecret(test.spec, "target-tls"); got != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestRestartRestartsOnlyReferencingWorkloads(t *testing.T) {
+	referencing := &extensionsv1beta1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "referencing"},
+		Spec: extensionsv1beta1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "target-tls"}}},
+					},
+				},
+			},
+		},
+	}
+	unrelated := &extensionsv1beta1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "unrelated"},
+	}
+	statefulSet := &appsv1beta1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "referencing-sts"},
+		Spec: appsv1beta1.StatefulSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "target-tls"}}},
+					},
+				},
+			},
+		},
+	}
+
+	client := fake.NewSimpleClientset(referencing, unrelated, statefulSet)
+	trigger := New(client)
+
+	if err := trigger.Restart("testns", "target-tls"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	updatedReferencing, err := client.ExtensionsV1beta1().Deployments("testns").Get("referencing", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error fetching Deployment: %s", err.Error())
+	}
+	if _, ok := updatedReferencing.Spec.Template.Annotations[RestartedAtAnnotation]; !ok {
+		t.Errorf("expected referencing Deployment to have %s annotation", RestartedAtAnnotation)
+	}
+
+	updatedUnrelated, err := client.ExtensionsV1beta1().Deployments("testns").Get("unrelated", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error fetching Deployment: %s", err.Error())
+	}
+	if _, ok := updatedUnrelated.Spec.Template.Annotations[RestartedAtAnnotation]; ok {
+		t.Errorf("did not expect unrelated Deployment to have %s annotation", RestartedAtAnnotation)
+	}
+
+	updatedStatefulSet, err := client.AppsV1beta1().StatefulSets("testns").Get("referencing-sts", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error fetching StatefulSet: %s", err.Error())
+	}
+	if _, ok := updatedStatefulSet.Spec.Template.Annotations[RestartedAtAnnotation]; !ok {
+		t.Errorf("expected referencing StatefulSet to have %s annotation", RestartedAtAnnotation)
+	}
+}
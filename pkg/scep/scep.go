@@ -0,0 +1,104 @@
+// Package scep exposes a minimal SCEP (RFC 8894) responder, so legacy
+// network devices that only speak SCEP can enrol for certificates that are
+// actually signed by a cert-manager CA Issuer.
+package scep
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net/http"
+
+	"github.com/golang/glog"
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/jetstack-experimental/cert-manager/pkg/client/clientset"
+)
+
+// Server serves the SCEP operations required by devices enrolling against
+// a single cert-manager CA Issuer.
+type Server struct {
+	client   kubernetes.Interface
+	cmClient clientset.Interface
+
+	namespace  string
+	issuerName string
+}
+
+// New returns a Server that answers SCEP requests using the CA Issuer
+// named issuerName in namespace.
+func New(client kubernetes.Interface, cmClient clientset.Interface, namespace, issuerName string) *Server {
+	return &Server{
+		client:     client,
+		cmClient:   cmClient,
+		namespace:  namespace,
+		issuerName: issuerName,
+	}
+}
+
+// Handler returns an http.Handler that serves SCEP requests at /scep, as
+// described by RFC 8894 section 3 (operations are selected using the
+// 'operation' query parameter, per the HTTP GET/POST transport binding).
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/scep", s.serveSCEP)
+	return mux
+}
+
+func (s *Server) serveSCEP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Query().Get("operation") {
+	case "GetCACert":
+		s.getCACert(w, r)
+	case "PKIOperation":
+		// A full PKIOperation implementation requires unwrapping the
+		// device's request from a signed and RSA-enveloped CMS PKIMessage,
+		// and wrapping the response the same way (RFC 8894 section 3.3).
+		// This repository does not currently vendor a CMS/PKCS7 encryption
+		// library, so PKIOperation is not yet implemented; GetCACert (used
+		// by devices to bootstrap trust in the CA) is.
+		msg := "PKIOperation is not implemented: it requires a CMS/PKCS7 encryption library not currently vendored in cert-manager"
+		glog.Info(msg)
+		http.Error(w, msg, http.StatusNotImplemented)
+	default:
+		http.Error(w, fmt.Sprintf("unsupported SCEP operation %q", r.URL.Query().Get("operation")), http.StatusBadRequest)
+	}
+}
+
+// getCACert implements the GetCACert operation (RFC 8894 section 4.2.1),
+// returning the DER encoded CA certificate of the configured Issuer.
+func (s *Server) getCACert(w http.ResponseWriter, r *http.Request) {
+	iss, err := s.cmClient.CertmanagerV1alpha1().Issuers(s.namespace).Get(s.issuerName, metav1.GetOptions{})
+	if err != nil {
+		msg := fmt.Sprintf("error getting issuer %q: %s", s.issuerName, err.Error())
+		glog.Error(msg)
+		http.Error(w, msg, http.StatusInternalServerError)
+		return
+	}
+
+	if iss.Spec.CA == nil {
+		msg := fmt.Sprintf("issuer %q is not a CA issuer: SCEP is only supported for CA issuers", s.issuerName)
+		glog.Error(msg)
+		http.Error(w, msg, http.StatusInternalServerError)
+		return
+	}
+
+	secret, err := s.client.CoreV1().Secrets(s.namespace).Get(iss.Spec.CA.SecretRef.Name, metav1.GetOptions{})
+	if err != nil {
+		msg := fmt.Sprintf("error getting issuer signing keypair: %s", err.Error())
+		glog.Error(msg)
+		http.Error(w, msg, http.StatusInternalServerError)
+		return
+	}
+
+	block, _ := pem.Decode(secret.Data[api.TLSCertKey])
+	if block == nil {
+		msg := "error decoding issuer signing certificate: not PEM encoded"
+		glog.Error(msg)
+		http.Error(w, msg, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-x509-ca-cert")
+	w.Write(block.Bytes)
+}
@@ -0,0 +1,53 @@
+package errors
+
+import "golang.org/x/crypto/acme"
+
+// FailureClass is a coarse-grained, machine-readable classification of why
+// an issuance or renewal attempt failed, independent of which step in the
+// issuance workflow produced the error. Automation watching Certificate
+// Events can branch on FailureClass instead of pattern matching on
+// human-readable error messages, which are not guaranteed to be stable.
+type FailureClass string
+
+const (
+	// FailureClassQuota indicates the issuer's rate limit or quota was
+	// exceeded.
+	FailureClassQuota FailureClass = "Quota"
+	// FailureClassAuth indicates the issuer rejected the request as
+	// unauthorized, or requires some action to be taken by the account
+	// holder before it will proceed.
+	FailureClassAuth FailureClass = "Auth"
+	// FailureClassDNS indicates a DNS or CAA related failure.
+	FailureClassDNS FailureClass = "DNS"
+	// FailureClassValidation indicates the request itself was rejected as
+	// malformed or otherwise invalid.
+	FailureClassValidation FailureClass = "Validation"
+	// FailureClassUnknown is returned when err could not be classified into
+	// one of the above classes.
+	FailureClassUnknown FailureClass = "Unknown"
+)
+
+// ClassifyFailure inspects err and returns the FailureClass it belongs to.
+// It currently only recognises ACME problem documents (RFC 8555 section
+// 6.7); errors from other sources are reported as FailureClassUnknown.
+func ClassifyFailure(err error) FailureClass {
+	acmeErr, ok := err.(*acme.Error)
+	if !ok {
+		return FailureClassUnknown
+	}
+
+	switch acmeErr.ProblemType {
+	case "urn:ietf:params:acme:error:rateLimited":
+		return FailureClassQuota
+	case "urn:ietf:params:acme:error:unauthorized", "urn:ietf:params:acme:error:userActionRequired":
+		return FailureClassAuth
+	case "urn:ietf:params:acme:error:dns", "urn:ietf:params:acme:error:caa":
+		return FailureClassDNS
+	case "urn:ietf:params:acme:error:malformed", "urn:ietf:params:acme:error:rejectedIdentifier",
+		"urn:ietf:params:acme:error:unsupportedIdentifier", "urn:ietf:params:acme:error:badCSR",
+		"urn:ietf:params:acme:error:invalidContact", "urn:ietf:params:acme:error:unsupportedContact":
+		return FailureClassValidation
+	default:
+		return FailureClassUnknown
+	}
+}
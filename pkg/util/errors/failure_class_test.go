@@ -0,0 +1,52 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	"golang.org/x/crypto/acme"
+)
+
+func TestClassifyFailure(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expClass FailureClass
+	}{
+		"rate limited": {
+			err:      &acme.Error{ProblemType: "urn:ietf:params:acme:error:rateLimited"},
+			expClass: FailureClassQuota,
+		},
+		"unauthorized": {
+			err:      &acme.Error{ProblemType: "urn:ietf:params:acme:error:unauthorized"},
+			expClass: FailureClassAuth,
+		},
+		"user action required": {
+			err:      &acme.Error{ProblemType: "urn:ietf:params:acme:error:userActionRequired"},
+			expClass: FailureClassAuth,
+		},
+		"dns": {
+			err:      &acme.Error{ProblemType: "urn:ietf:params:acme:error:dns"},
+			expClass: FailureClassDNS,
+		},
+		"malformed": {
+			err:      &acme.Error{ProblemType: "urn:ietf:params:acme:error:malformed"},
+			expClass: FailureClassValidation,
+		},
+		"unrecognised problem type": {
+			err:      &acme.Error{ProblemType: "urn:ietf:params:acme:error:serverInternal"},
+			expClass: FailureClassUnknown,
+		},
+		"non-acme error": {
+			err:      errors.New("some other error"),
+			expClass: FailureClassUnknown,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if class := ClassifyFailure(test.err); class != test.expClass {
+				t.Errorf("expected %s, got %s", test.expClass, class)
+			}
+		})
+	}
+}
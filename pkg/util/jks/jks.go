@@ -0,0 +1,138 @@
+// Package jks encodes Java KeyStore (JKS) trust stores, for Certificates
+// that need to distribute a CA bundle to Java clients in the format
+// expected by javax.net.ssl.TrustManagerFactory. Only trusted certificate
+// entries are supported; there is no support for writing private key
+// entries, since that is not required for a truststore.
+package jks
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/binary"
+	"fmt"
+)
+
+// magic and version identify the JKS file format, per Sun's (undocumented
+// but widely reverse engineered) on-disk layout.
+const (
+	magic   uint32 = 0xfeedfeed
+	version uint32 = 2
+
+	trustedCertEntryTag uint32 = 2
+
+	certTypeX509 = "X.509"
+
+	// sha1Size is the length in bytes of the SHA-1 integrity digest
+	// appended to the end of every JKS store.
+	sha1Size = sha1.Size
+
+	// signatureWhitening is the fixed string Sun's JKS implementation mixes
+	// into the password before hashing it into the store's integrity
+	// digest. It is not a secret; every JKS implementation hard-codes it.
+	signatureWhitening = "Mighty Aphrodite"
+)
+
+// EncodeTrustStore returns the bytes of a JKS trust store containing certs,
+// each stored as a trusted certificate entry under the alias
+// "ca-<index>". password is required by the JKS format's integrity check;
+// it does not need to be kept secret to protect the certificates, which
+// are public, but most tooling that opens a JKS file expects one to be
+// set.
+func EncodeTrustStore(password string, certs []*x509.Certificate) ([]byte, error) {
+	var body bytes.Buffer
+
+	if err := writeUint32(&body, magic); err != nil {
+		return nil, err
+	}
+	if err := writeUint32(&body, version); err != nil {
+		return nil, err
+	}
+	if err := writeUint32(&body, uint32(len(certs))); err != nil {
+		return nil, err
+	}
+
+	for i, cert := range certs {
+		if err := writeTrustedCertEntry(&body, fmt.Sprintf("ca-%d", i), cert); err != nil {
+			return nil, fmt.Errorf("error encoding certificate %d: %s", i, err.Error())
+		}
+	}
+
+	digest := signature(password, body.Bytes())
+
+	out := append([]byte{}, body.Bytes()...)
+	return append(out, digest...), nil
+}
+
+// writeTrustedCertEntry appends a single trusted certificate entry to w, in
+// the format: tag, alias, creation timestamp, certificate type, DER length
+// and DER bytes. The creation timestamp is written as 0, since it is
+// informational only and cert-manager has no meaningful "imported at" time
+// to record.
+func writeTrustedCertEntry(w *bytes.Buffer, alias string, cert *x509.Certificate) error {
+	if err := writeUint32(w, trustedCertEntryTag); err != nil {
+		return err
+	}
+	if err := writeUTF(w, alias); err != nil {
+		return err
+	}
+	if err := writeUint64(w, 0); err != nil {
+		return err
+	}
+	if err := writeUTF(w, certTypeX509); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(len(cert.Raw))); err != nil {
+		return err
+	}
+	_, err := w.Write(cert.Raw)
+	return err
+}
+
+// signature computes the JKS integrity digest appended to the end of the
+// store: SHA-1 of the password (as UTF-16BE code units), the fixed
+// signatureWhitening string (also UTF-16BE), and the preceding store
+// bytes.
+func signature(password string, body []byte) []byte {
+	h := sha1.New()
+	h.Write(utf16beString(password))
+	h.Write(utf16beString(signatureWhitening))
+	h.Write(body)
+	return h.Sum(nil)
+}
+
+// utf16beString encodes s as UTF-16BE code units, matching how the JVM's
+// Modified UTF-8 aware JKS implementation hashes the store password.
+// cert-manager-supplied passwords are expected to be ASCII, so this does
+// not need to handle surrogate pairs for characters outside the Basic
+// Multilingual Plane.
+func utf16beString(s string) []byte {
+	out := make([]byte, 0, len(s)*2)
+	for _, r := range s {
+		out = append(out, byte(r>>8), byte(r))
+	}
+	return out
+}
+
+// writeUTF writes s as a Java "modified UTF-8" string: a two-byte length
+// prefix followed by its UTF-8 bytes. cert-manager only ever writes ASCII
+// aliases and type strings here, so plain UTF-8 encoding is equivalent to
+// Java's modified form.
+func writeUTF(w *bytes.Buffer, s string) error {
+	if len(s) > 0xffff {
+		return fmt.Errorf("string %q is too long to encode as a JKS UTF string", s)
+	}
+	if err := binary.Write(w, binary.BigEndian, uint16(len(s))); err != nil {
+		return err
+	}
+	_, err := w.WriteString(s)
+	return err
+}
+
+func writeUint32(w *bytes.Buffer, v uint32) error {
+	return binary.Write(w, binary.BigEndian, v)
+}
+
+func writeUint64(w *bytes.Buffer, v uint64) error {
+	return binary.Write(w, binary.BigEndian, v)
+}
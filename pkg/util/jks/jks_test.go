@@ -0,0 +1,84 @@
+package jks
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func testCert(t *testing.T, commonName string) *x509.Certificate {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error generating key: %s", err.Error())
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("error creating certificate: %s", err.Error())
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("error parsing certificate: %s", err.Error())
+	}
+	return cert
+}
+
+func TestEncodeTrustStore(t *testing.T) {
+	certs := []*x509.Certificate{
+		testCert(t, "root-ca"),
+		testCert(t, "intermediate-ca"),
+	}
+
+	out, err := EncodeTrustStore("changeit", certs)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if got := binary.BigEndian.Uint32(out[0:4]); got != magic {
+		t.Errorf("unexpected magic: got %x, want %x", got, magic)
+	}
+	if got := binary.BigEndian.Uint32(out[4:8]); got != version {
+		t.Errorf("unexpected version: got %d, want %d", got, version)
+	}
+	if got := binary.BigEndian.Uint32(out[8:12]); got != uint32(len(certs)) {
+		t.Errorf("unexpected entry count: got %d, want %d", got, len(certs))
+	}
+
+	body := out[:len(out)-sha1Size]
+	wantDigest := signature("changeit", body)
+	gotDigest := out[len(out)-sha1Size:]
+	if !bytes.Equal(gotDigest, wantDigest) {
+		t.Errorf("integrity digest does not match recomputed signature")
+	}
+
+	// A wrong password must not reproduce the same digest, otherwise the
+	// integrity check is not actually checking anything.
+	if bytes.Equal(gotDigest, signature("wrong", body)) {
+		t.Errorf("digest should depend on the password used to encode the store")
+	}
+}
+
+func TestEncodeTrustStoreEmpty(t *testing.T) {
+	out, err := EncodeTrustStore("changeit", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if got := binary.BigEndian.Uint32(out[8:12]); got != 0 {
+		t.Errorf("unexpected entry count: got %d, want 0", got)
+	}
+}
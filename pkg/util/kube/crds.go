@@ -0,0 +1,63 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EnsureCRD creates crd, or updates its spec in place if a
+// CustomResourceDefinition of that name already exists. See EnsureSecret for
+// the meaning of ctx.
+func EnsureCRD(ctx context.Context, cl apiextensionsclientset.Interface, crd *apiextensionsv1beta1.CustomResourceDefinition) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	_, err := cl.ApiextensionsV1beta1().CustomResourceDefinitions().Create(crd)
+	if err == nil {
+		return nil
+	}
+	if !k8sErrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	existing, err := cl.ApiextensionsV1beta1().CustomResourceDefinitions().Get(crd.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	updated := existing.DeepCopy()
+	updated.Spec = crd.Spec
+	_, err = cl.ApiextensionsV1beta1().CustomResourceDefinitions().Update(updated)
+	return err
+}
+
+// VerifyCRD reports whether a CustomResourceDefinition matching crd's name
+// and spec is already installed, without creating or modifying anything. It
+// is intended for a startup check that fails fast if CRDs have not been
+// installed, as an alternative to EnsureCRD actually installing them.
+func VerifyCRD(ctx context.Context, cl apiextensionsclientset.Interface, crd *apiextensionsv1beta1.CustomResourceDefinition) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	existing, err := cl.ApiextensionsV1beta1().CustomResourceDefinitions().Get(crd.Name, metav1.GetOptions{})
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return fmt.Errorf("CustomResourceDefinition %s is not installed", crd.Name)
+		}
+		return err
+	}
+
+	if !reflect.DeepEqual(existing.Spec, crd.Spec) {
+		return fmt.Errorf("installed CustomResourceDefinition %s does not match the expected spec", crd.Name)
+	}
+
+	return nil
+}
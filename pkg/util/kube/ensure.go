@@ -1,26 +1,78 @@
 package kube
 
 import (
+	"context"
+
 	batch "k8s.io/api/batch/v1"
 	api "k8s.io/api/core/v1"
 	core "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
+	policy "k8s.io/api/policy/v1beta1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
-func EnsureSecret(cl kubernetes.Interface, secret *api.Secret) (*api.Secret, error) {
+// EnsureSecret creates secret, or updates it if it already exists. An
+// update is applied as a three-way merge patch against the Secret's live
+// state (see mergeSecret), rather than a blind overwrite, so that fields
+// added by another controller sharing the same Secret are preserved. ctx is
+// checked before any API call is made, so a reconcile that has already been
+// cancelled or timed out doesn't start new work; the underlying clientset
+// predates context-aware request methods, so ctx is not propagated into the
+// HTTP request itself.
+func EnsureSecret(ctx context.Context, cl kubernetes.Interface, secret *api.Secret) (*api.Secret, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	stamped, err := stampLastAppliedConfig(secret)
+	if err != nil {
+		return nil, err
+	}
+	s, err := cl.CoreV1().Secrets(secret.Namespace).Create(stamped)
+	if err != nil {
+		if k8sErrors.IsAlreadyExists(err) {
+			current, err := cl.CoreV1().Secrets(secret.Namespace).Get(secret.Name, metav1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return mergeSecret(cl, current, secret)
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// EnsureImmutableSecret creates secret, or leaves the existing Secret
+// untouched if one already exists under the same name, rather than
+// overwriting it. It is intended for callers (such as
+// Certificate.Spec.ImmutableSecretRotation) that derive the Secret's name
+// from a checksum of its contents, so an "already exists" response means
+// the Secret already holds the data being written and nothing more needs
+// to be done; a vendored client-go old enough to predate the Secret
+// .Immutable API field is not able to enforce this at the API server, so
+// it is enforced here by simply never issuing an Update. See EnsureSecret
+// for the meaning of ctx.
+func EnsureImmutableSecret(ctx context.Context, cl kubernetes.Interface, secret *api.Secret) (*api.Secret, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	s, err := cl.CoreV1().Secrets(secret.Namespace).Create(secret)
 	if err != nil {
 		if k8sErrors.IsAlreadyExists(err) {
-			return cl.CoreV1().Secrets(secret.Namespace).Update(secret)
+			return cl.CoreV1().Secrets(secret.Namespace).Get(secret.Name, metav1.GetOptions{})
 		}
 		return nil, err
 	}
 	return s, nil
 }
 
-func EnsureIngress(cl kubernetes.Interface, ingress *extensions.Ingress) (*extensions.Ingress, error) {
+// EnsureIngress creates ingress, or updates it if it already exists. See
+// EnsureSecret for the meaning of ctx.
+func EnsureIngress(ctx context.Context, cl kubernetes.Interface, ingress *extensions.Ingress) (*extensions.Ingress, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	s, err := cl.ExtensionsV1beta1().Ingresses(ingress.Namespace).Update(ingress)
 	if err != nil {
 		if k8sErrors.IsNotFound(err) {
@@ -31,7 +83,12 @@ func EnsureIngress(cl kubernetes.Interface, ingress *extensions.Ingress) (*exten
 	return s, nil
 }
 
-func EnsureService(cl kubernetes.Interface, service *core.Service) (*core.Service, error) {
+// EnsureService creates service, or updates it if it already exists. See
+// EnsureSecret for the meaning of ctx.
+func EnsureService(ctx context.Context, cl kubernetes.Interface, service *core.Service) (*core.Service, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	s, err := cl.CoreV1().Services(service.Namespace).Update(service)
 	if err != nil {
 		if k8sErrors.IsNotFound(err) {
@@ -42,7 +99,12 @@ func EnsureService(cl kubernetes.Interface, service *core.Service) (*core.Servic
 	return s, nil
 }
 
-func EnsureJob(cl kubernetes.Interface, job *batch.Job) (*batch.Job, error) {
+// EnsureJob creates job, or updates it if it already exists. See
+// EnsureSecret for the meaning of ctx.
+func EnsureJob(ctx context.Context, cl kubernetes.Interface, job *batch.Job) (*batch.Job, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	s, err := cl.BatchV1().Jobs(job.Namespace).Update(job)
 	if err != nil {
 		if k8sErrors.IsNotFound(err) {
@@ -52,3 +114,19 @@ func EnsureJob(cl kubernetes.Interface, job *batch.Job) (*batch.Job, error) {
 	}
 	return s, nil
 }
+
+// EnsurePodDisruptionBudget creates pdb, or updates its spec in place if one
+// of that name already exists. See EnsureSecret for the meaning of ctx.
+func EnsurePodDisruptionBudget(ctx context.Context, cl kubernetes.Interface, pdb *policy.PodDisruptionBudget) (*policy.PodDisruptionBudget, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s, err := cl.PolicyV1beta1().PodDisruptionBudgets(pdb.Namespace).Update(pdb)
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return cl.PolicyV1beta1().PodDisruptionBudgets(pdb.Namespace).Create(pdb)
+		}
+		return nil, err
+	}
+	return s, nil
+}
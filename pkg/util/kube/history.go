@@ -0,0 +1,82 @@
+package kube
+
+import (
+	"encoding/json"
+
+	api "k8s.io/api/core/v1"
+)
+
+const (
+	// SecretHistoryAnnotationKey stores the most recently superseded cert/key
+	// data for a Certificate's target Secret, so a previous revision can be
+	// restored (e.g. via `cmctl rollback`) if a freshly issued certificate
+	// breaks a consumer.
+	SecretHistoryAnnotationKey = "certmanager.k8s.io/previous-revisions"
+
+	// MaxSecretHistory is the number of previous cert/key revisions retained
+	// in the SecretHistoryAnnotationKey annotation. Older revisions are
+	// discarded.
+	MaxSecretHistory = 5
+)
+
+// SecretRevision is a single previously issued cert/key pair, as stored in
+// the SecretHistoryAnnotationKey annotation, most recent first.
+type SecretRevision struct {
+	Data map[string][]byte `json:"data"`
+}
+
+// RecordSecretHistory returns a copy of newSecret with its history
+// annotation updated to additionally record the data currently held by
+// existingSecret, if any, capped at MaxSecretHistory entries. existingSecret
+// may be nil if the Secret did not previously exist.
+func RecordSecretHistory(existingSecret, newSecret *api.Secret) (*api.Secret, error) {
+	if existingSecret == nil || len(existingSecret.Data) == 0 {
+		return newSecret, nil
+	}
+
+	history, err := SecretHistoryFromSecret(existingSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	history = append([]SecretRevision{{Data: existingSecret.Data}}, history...)
+	if len(history) > MaxSecretHistory {
+		history = history[:MaxSecretHistory]
+	}
+
+	encoded, err := EncodeSecretHistory(history)
+	if err != nil {
+		return nil, err
+	}
+
+	out := newSecret.DeepCopy()
+	if out.Annotations == nil {
+		out.Annotations = make(map[string]string)
+	}
+	out.Annotations[SecretHistoryAnnotationKey] = encoded
+	return out, nil
+}
+
+// EncodeSecretHistory encodes history into the string form stored in the
+// SecretHistoryAnnotationKey annotation.
+func EncodeSecretHistory(history []SecretRevision) (string, error) {
+	encoded, err := json.Marshal(history)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// SecretHistoryFromSecret decodes the revision history stored on secret, if
+// any, most recent first. A secret with no history returns a nil slice.
+func SecretHistoryFromSecret(secret *api.Secret) ([]SecretRevision, error) {
+	raw, ok := secret.Annotations[SecretHistoryAnnotationKey]
+	if !ok || raw == "" {
+		return nil, nil
+	}
+	var history []SecretRevision
+	if err := json.Unmarshal([]byte(raw), &history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
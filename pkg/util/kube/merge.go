@@ -0,0 +1,72 @@
+package kube
+
+import (
+	"encoding/json"
+
+	api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/kubernetes"
+)
+
+// LastAppliedConfigAnnotationKey is set on Secrets written through
+// mergeSecret, recording the full object most recently written as
+// cert-manager's own desired state. It is used as the "original" document
+// in a three-way merge patch (the same strategy "kubectl apply" uses), so
+// that a Secret mutated by another controller sharing it (for example, one
+// that adds its own annotations or labels) is patched rather than
+// overwritten: fields cert-manager never touched are left alone, and
+// fields it previously set and has now removed are still cleared.
+const LastAppliedConfigAnnotationKey = "certmanager.k8s.io/last-applied-configuration"
+
+// mergeSecret computes a three-way strategic merge patch between the
+// Secret cert-manager most recently applied (recorded in current's
+// LastAppliedConfigAnnotationKey annotation, or treated as empty if unset),
+// the Secret cert-manager now wants applied (desired), and current itself,
+// and applies it. desired is also stamped with its own
+// LastAppliedConfigAnnotationKey so the next call can repeat the process.
+func mergeSecret(cl kubernetes.Interface, current, desired *api.Secret) (*api.Secret, error) {
+	stamped, err := stampLastAppliedConfig(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	currentJSON, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+
+	modifiedJSON, err := json.Marshal(stamped)
+	if err != nil {
+		return nil, err
+	}
+
+	original := []byte(current.Annotations[LastAppliedConfigAnnotationKey])
+
+	patch, err := strategicpatch.CreateThreeWayMergePatch(original, modifiedJSON, currentJSON, &api.Secret{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.CoreV1().Secrets(desired.Namespace).Patch(desired.Name, types.StrategicMergePatchType, patch)
+}
+
+// stampLastAppliedConfig returns a copy of secret with its
+// LastAppliedConfigAnnotationKey annotation set to secret's own JSON
+// encoding (computed before the annotation is added, so the stored value
+// does not recursively embed itself).
+func stampLastAppliedConfig(secret *api.Secret) (*api.Secret, error) {
+	out := secret.DeepCopy()
+	delete(out.Annotations, LastAppliedConfigAnnotationKey)
+
+	encoded, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+
+	if out.Annotations == nil {
+		out.Annotations = make(map[string]string)
+	}
+	out.Annotations[LastAppliedConfigAnnotationKey] = string(encoded)
+	return out, nil
+}
@@ -24,13 +24,20 @@ func GetKeyPair(secretLister corelisters.SecretLister, namespace, name string) (
 }
 
 func SecretTLSKey(secretLister corelisters.SecretLister, namespace, name string) (*rsa.PrivateKey, error) {
+	return SecretTLSKeyRef(secretLister, namespace, name, api.TLSPrivateKeyKey)
+}
+
+// SecretTLSKeyRef behaves like SecretTLSKey, but reads the private key from
+// the given Secret data key rather than the default "tls.key", for Secrets
+// whose keys have been customized (e.g. via CertificateSpec.SecretKeys).
+func SecretTLSKeyRef(secretLister corelisters.SecretLister, namespace, name, keyRef string) (*rsa.PrivateKey, error) {
 	secret, err := secretLister.Secrets(namespace).Get(name)
 
 	if err != nil {
 		return nil, err
 	}
 
-	keyBytes := secret.Data[api.TLSPrivateKeyKey]
+	keyBytes := secret.Data[keyRef]
 	key, err := pki.DecodePKCS1PrivateKeyBytes(keyBytes)
 
 	if err != nil {
@@ -40,13 +47,21 @@ func SecretTLSKey(secretLister corelisters.SecretLister, namespace, name string)
 }
 
 func SecretTLSCert(secretLister corelisters.SecretLister, namespace, name string) (*x509.Certificate, error) {
+	return SecretTLSCertRef(secretLister, namespace, name, api.TLSCertKey)
+}
+
+// SecretTLSCertRef behaves like SecretTLSCert, but reads the certificate
+// from the given Secret data key rather than the default "tls.crt", for
+// Secrets whose keys have been customized (e.g. via
+// CertificateSpec.SecretKeys).
+func SecretTLSCertRef(secretLister corelisters.SecretLister, namespace, name, keyRef string) (*x509.Certificate, error) {
 	secret, err := secretLister.Secrets(namespace).Get(name)
 
 	if err != nil {
 		return nil, err
 	}
 
-	certBytes := secret.Data[api.TLSCertKey]
+	certBytes := secret.Data[keyRef]
 	cert, err := pki.DecodeX509CertificateBytes(certBytes)
 
 	if err != nil {
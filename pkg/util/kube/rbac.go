@@ -0,0 +1,65 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	rbacv1beta1 "k8s.io/api/rbac/v1beta1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// EnsureClusterRole creates role, or updates its rules and labels in place
+// if a ClusterRole of that name already exists. See EnsureSecret for the
+// meaning of ctx.
+func EnsureClusterRole(ctx context.Context, cl kubernetes.Interface, role *rbacv1beta1.ClusterRole) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	_, err := cl.RbacV1beta1().ClusterRoles().Create(role)
+	if err == nil {
+		return nil
+	}
+	if !k8sErrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	existing, err := cl.RbacV1beta1().ClusterRoles().Get(role.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	updated := existing.DeepCopy()
+	updated.Labels = role.Labels
+	updated.Rules = role.Rules
+	_, err = cl.RbacV1beta1().ClusterRoles().Update(updated)
+	return err
+}
+
+// VerifyClusterRole reports whether a ClusterRole matching role's name,
+// labels and rules is already installed, without creating or modifying
+// anything. It is intended for a startup check that fails fast if RBAC
+// aggregation roles have not been installed, as an alternative to
+// EnsureClusterRole actually installing them.
+func VerifyClusterRole(ctx context.Context, cl kubernetes.Interface, role *rbacv1beta1.ClusterRole) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	existing, err := cl.RbacV1beta1().ClusterRoles().Get(role.Name, metav1.GetOptions{})
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return fmt.Errorf("ClusterRole %s is not installed", role.Name)
+		}
+		return err
+	}
+
+	if !reflect.DeepEqual(existing.Rules, role.Rules) || !reflect.DeepEqual(existing.Labels, role.Labels) {
+		return fmt.Errorf("installed ClusterRole %s does not match the expected rules or labels", role.Name)
+	}
+
+	return nil
+}
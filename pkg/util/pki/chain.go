@@ -0,0 +1,100 @@
+package pki
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+)
+
+// ChainFromCertificates takes a list of raw DER certificate bytes, in
+// whatever order the server returned them in, and returns a PEM encoded
+// chain in leaf-to-root order, optionally omitting the root CA certificate.
+// Most ACME servers don't send the root at all (clients are expected to
+// already trust it out of band), but some do - omitting it avoids the extra
+// handshake bytes and works around strict clients that reject a served
+// root.
+func ChainFromCertificates(certs [][]byte, excludeRootCA bool) ([]byte, error) {
+	parsed := make([]*x509.Certificate, len(certs))
+	for i, der := range certs {
+		cert, err := DecodeDERCertificateBytes(der)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing certificate %d in chain: %s", i, err.Error())
+		}
+		parsed[i] = cert
+	}
+
+	ordered := orderChain(parsed)
+
+	out := bytes.NewBuffer(nil)
+	for _, cert := range ordered {
+		if excludeRootCA && isSelfSigned(cert) {
+			continue
+		}
+		if err := pem.Encode(out, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}); err != nil {
+			return nil, fmt.Errorf("error encoding certificate PEM: %s", err.Error())
+		}
+	}
+	return out.Bytes(), nil
+}
+
+// orderChain reorders certs so that the first is the leaf (the one that is
+// not the issuer of any other certificate in the set) and each following
+// certificate is the issuer of the one before it. This protects against a
+// server response with intermediates out of order, which strict TLS clients
+// reject.
+func orderChain(certs []*x509.Certificate) []*x509.Certificate {
+	remaining := append([]*x509.Certificate{}, certs...)
+	if len(remaining) == 0 {
+		return remaining
+	}
+
+	leafIdx := 0
+	for i, c := range remaining {
+		isIssuer := false
+		for j, other := range remaining {
+			if i == j {
+				continue
+			}
+			if CertificateSignedBy(other, c) {
+				isIssuer = true
+				break
+			}
+		}
+		if !isIssuer {
+			leafIdx = i
+			break
+		}
+	}
+
+	ordered := []*x509.Certificate{remaining[leafIdx]}
+	remaining = append(remaining[:leafIdx], remaining[leafIdx+1:]...)
+
+	for len(remaining) > 0 {
+		current := ordered[len(ordered)-1]
+		found := -1
+		for i, c := range remaining {
+			if CertificateSignedBy(current, c) {
+				found = i
+				break
+			}
+		}
+		if found == -1 {
+			// no issuer for the current tail found in the remaining certs -
+			// append what's left in the order the server sent it, rather
+			// than silently dropping certificates we couldn't place
+			ordered = append(ordered, remaining...)
+			break
+		}
+		ordered = append(ordered, remaining[found])
+		remaining = append(remaining[:found], remaining[found+1:]...)
+	}
+
+	return ordered
+}
+
+// isSelfSigned returns true if cert was signed by its own key, i.e. it is a
+// root CA certificate.
+func isSelfSigned(cert *x509.Certificate) bool {
+	return CertificateSignedBy(cert, cert)
+}
@@ -0,0 +1,124 @@
+package pki
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// testCA generates a self-signed or intermediate-signed certificate for use
+// in chain tests. If signer/signerKey are nil, the certificate is
+// self-signed (i.e. a root CA).
+func testCA(t *testing.T, commonName string, signer *x509.Certificate, signerKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error generating key: %s", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	parent := template
+	parentKey := key
+	if signer != nil {
+		parent = signer
+		parentKey = signerKey
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("error creating certificate: %s", err)
+	}
+
+	cert, err := DecodeDERCertificateBytes(der)
+	if err != nil {
+		t.Fatalf("error parsing generated certificate: %s", err)
+	}
+
+	return cert, key
+}
+
+func TestChainFromCertificates(t *testing.T) {
+	root, rootKey := testCA(t, "root", nil, nil)
+	intermediate, intermediateKey := testCA(t, "intermediate", root, rootKey)
+	leaf, _ := testCA(t, "leaf", intermediate, intermediateKey)
+
+	tests := map[string]struct {
+		order         []*x509.Certificate
+		excludeRootCA bool
+		expOrder      []*x509.Certificate
+	}{
+		"already in leaf-to-root order": {
+			order:    []*x509.Certificate{leaf, intermediate, root},
+			expOrder: []*x509.Certificate{leaf, intermediate, root},
+		},
+		"out of order is corrected": {
+			order:    []*x509.Certificate{root, leaf, intermediate},
+			expOrder: []*x509.Certificate{leaf, intermediate, root},
+		},
+		"root is excluded when requested": {
+			order:         []*x509.Certificate{leaf, intermediate, root},
+			excludeRootCA: true,
+			expOrder:      []*x509.Certificate{leaf, intermediate},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			certs := make([][]byte, len(test.order))
+			for i, c := range test.order {
+				certs[i] = c.Raw
+			}
+
+			out, err := ChainFromCertificates(certs, test.excludeRootCA)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			gotCerts, err := parsePEMChain(out)
+			if err != nil {
+				t.Fatalf("error parsing returned chain: %s", err)
+			}
+
+			if len(gotCerts) != len(test.expOrder) {
+				t.Fatalf("expected %d certificates, got %d", len(test.expOrder), len(gotCerts))
+			}
+			for i, c := range gotCerts {
+				if c.Subject.CommonName != test.expOrder[i].Subject.CommonName {
+					t.Errorf("expected certificate %d to be %q, got %q", i, test.expOrder[i].Subject.CommonName, c.Subject.CommonName)
+				}
+			}
+		})
+	}
+}
+
+func parsePEMChain(chainPEM []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := chainPEM
+	for len(rest) > 0 {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
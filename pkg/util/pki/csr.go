@@ -3,14 +3,33 @@ package pki
 import (
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"net"
 )
 
-func GenerateCSR(domains []string) *x509.CertificateRequest {
+// GenerateCSR creates a CertificateRequest template requesting the given
+// domains and IP addresses as subjectAltNames. ipAddresses that fail to
+// parse as an IP are silently skipped.
+func GenerateCSR(domains []string, ipAddresses []string) *x509.CertificateRequest {
+	commonName := ""
+	if len(domains) > 0 {
+		commonName = domains[0]
+	} else if len(ipAddresses) > 0 {
+		commonName = ipAddresses[0]
+	}
+
+	var ips []net.IP
+	for _, ip := range ipAddresses {
+		if parsed := net.ParseIP(ip); parsed != nil {
+			ips = append(ips, parsed)
+		}
+	}
+
 	template := x509.CertificateRequest{
 		Subject: pkix.Name{
-			CommonName: domains[0],
+			CommonName: commonName,
 		},
-		DNSNames: domains,
+		DNSNames:    domains,
+		IPAddresses: ips,
 	}
 	return &template
 }
@@ -0,0 +1,42 @@
+package pki
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/idna"
+	"golang.org/x/net/publicsuffix"
+)
+
+// NormalizeDomain converts domain to its canonical ASCII (punycode) form, so
+// that internationalized domain names are issued and compared consistently
+// regardless of how they were originally entered. It also lower-cases the
+// domain and strips a trailing root "." (both of which are cosmetic and
+// refer to the same name), so that these differences don't cause
+// Certificates to be considered out of date and trigger a spurious
+// re-issuance. Domains that are already in this canonical form, including
+// wildcard domains, are returned unchanged.
+func NormalizeDomain(domain string) (string, error) {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+
+	ascii, err := idna.ToASCII(domain)
+	if err != nil {
+		return "", fmt.Errorf("invalid domain name %q: %s", domain, err.Error())
+	}
+	return ascii, nil
+}
+
+// ValidateDomain returns an error if domain is a bare public suffix (e.g.
+// "com", "co.uk") or a wildcard directly over one (e.g. "*.com"). No CA will
+// ever issue a certificate for an entire public suffix, so rejecting these
+// early gives a clear error instead of an opaque issuer failure later.
+func ValidateDomain(domain string) error {
+	base := strings.ToLower(strings.TrimPrefix(domain, "*."))
+
+	suffix, _ := publicsuffix.PublicSuffix(base)
+	if suffix == base {
+		return fmt.Errorf("domain %q is a public suffix; certificates cannot be issued for an entire public suffix", domain)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package pki
+
+import "testing"
+
+func TestNormalizeDomain(t *testing.T) {
+	tests := map[string]struct {
+		domain string
+		expOut string
+		expErr bool
+	}{
+		"ascii domain is returned unchanged": {
+			domain: "example.com",
+			expOut: "example.com",
+		},
+		"wildcard ascii domain is returned unchanged": {
+			domain: "*.example.com",
+			expOut: "*.example.com",
+		},
+		"unicode domain is converted to punycode": {
+			domain: "müller.de",
+			expOut: "xn--mller-kva.de",
+		},
+		"mixed case domain is lower-cased": {
+			domain: "Example.COM",
+			expOut: "example.com",
+		},
+		"trailing root dot is stripped": {
+			domain: "example.com.",
+			expOut: "example.com",
+		},
+		"invalid domain returns an error": {
+			domain: "xn--invalid-punycode-üü",
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := NormalizeDomain(test.domain)
+			if test.expErr != (err != nil) {
+				t.Errorf("expected error=%v, got: %v", test.expErr, err)
+			}
+			if out != test.expOut {
+				t.Errorf("expected %q, got %q", test.expOut, out)
+			}
+		})
+	}
+}
+
+func TestValidateDomain(t *testing.T) {
+	tests := map[string]struct {
+		domain string
+		expErr bool
+	}{
+		"regular domain is valid": {
+			domain: "example.com",
+		},
+		"subdomain is valid": {
+			domain: "www.example.com",
+		},
+		"wildcard over a registrable domain is valid": {
+			domain: "*.example.com",
+		},
+		"bare public suffix is invalid": {
+			domain: "com",
+			expErr: true,
+		},
+		"multi-part public suffix is invalid": {
+			domain: "co.uk",
+			expErr: true,
+		},
+		"wildcard directly over a public suffix is invalid": {
+			domain: "*.com",
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateDomain(test.domain)
+			if test.expErr != (err != nil) {
+				t.Errorf("expected error=%v, got: %v", test.expErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package pki
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"strings"
+)
+
+// Fingerprint returns the colon-separated hex SHA-256 fingerprint of cert's
+// raw DER encoding, in the conventional "AB:CD:...:EF" form used to compare
+// certificates by eye (e.g. when confirming a CA issuer's signing
+// certificate is the one expected, without comparing the full PEM).
+func Fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+
+	hexBytes := make([]string, len(sum))
+	for i, b := range sum {
+		hexBytes[i] = hex.EncodeToString([]byte{b})
+	}
+
+	return strings.ToUpper(strings.Join(hexBytes, ":"))
+}
@@ -0,0 +1,59 @@
+package pki
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+)
+
+func testCertificate(t *testing.T, commonName string) *x509.Certificate {
+	key, err := GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatalf("error generating private key: %s", err.Error())
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("error creating test certificate: %s", err.Error())
+	}
+
+	cert, err := DecodeDERCertificateBytes(derBytes)
+	if err != nil {
+		t.Fatalf("error decoding test certificate: %s", err.Error())
+	}
+
+	return cert
+}
+
+// TestFingerprint verifies that Fingerprint is deterministic for a given
+// certificate, formatted as colon-separated hex pairs, and distinguishes
+// different certificates.
+func TestFingerprint(t *testing.T) {
+	certA := testCertificate(t, "a.example.com")
+	certB := testCertificate(t, "b.example.com")
+
+	gotA1 := Fingerprint(certA)
+	gotA2 := Fingerprint(certA)
+	if gotA1 != gotA2 {
+		t.Errorf("expected Fingerprint to be deterministic for the same certificate, got %q and %q", gotA1, gotA2)
+	}
+
+	if len(gotA1) != sha256HexFingerprintLength {
+		t.Errorf("expected fingerprint of length %d, got %q (length %d)", sha256HexFingerprintLength, gotA1, len(gotA1))
+	}
+
+	if gotB := Fingerprint(certB); gotA1 == gotB {
+		t.Errorf("expected different certificates to have different fingerprints, both got %q", gotA1)
+	}
+}
+
+// sha256HexFingerprintLength is 32 hex-pair groups (64 hex characters) plus
+// 31 colon separators.
+const sha256HexFingerprintLength = 32*2 + 31
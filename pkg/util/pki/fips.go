@@ -0,0 +1,21 @@
+package pki
+
+import "fmt"
+
+// FIPSApprovedRSAKeySizes are the RSA key sizes, in bits, approved for
+// certificate generation under FIPS 140-2. Sizes below 2048 are
+// considered cryptographically weak and are never approved; 2048, 3072 and
+// 4096 bit keys are the sizes commonly validated by FIPS-certified
+// cryptographic modules.
+var FIPSApprovedRSAKeySizes = []int{2048, 3072, 4096}
+
+// ValidateFIPSKeySize returns an error if keySize is not one of
+// FIPSApprovedRSAKeySizes.
+func ValidateFIPSKeySize(keySize int) error {
+	for _, size := range FIPSApprovedRSAKeySizes {
+		if keySize == size {
+			return nil
+		}
+	}
+	return fmt.Errorf("key size %d bits is not FIPS 140-2 approved (must be one of %v)", keySize, FIPSApprovedRSAKeySizes)
+}
@@ -5,12 +5,71 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
+	"sync"
 )
 
+// DefaultRSAKeySize is the RSA key size used when a Certificate does not
+// specify its own KeySize.
+const DefaultRSAKeySize = 2048
+
+var (
+	keyGenMu             sync.Mutex
+	keyGenMaxConcurrency int // 0 means unlimited
+	keyGenSemaphores     = map[int]chan struct{}{}
+)
+
+// SetMaxConcurrentKeyGen bounds, independently for each RSA key size, how
+// many calls to GenerateRSAPrivateKey for that key size may be in flight at
+// once across the whole process. Additional callers block until a slot
+// frees up, acting as a bounded worker pool per key size, so a burst of
+// concurrent key generation (e.g. many Certificates reconciling at once)
+// cannot saturate CPU on a resource-constrained node. A non-positive limit
+// (the default) removes any bound. It is intended to be called once at
+// startup, before any keys are generated; changing it later only affects
+// calls to GenerateRSAPrivateKey made afterwards.
+func SetMaxConcurrentKeyGen(limit int) {
+	keyGenMu.Lock()
+	defer keyGenMu.Unlock()
+	keyGenMaxConcurrency = limit
+	keyGenSemaphores = map[int]chan struct{}{}
+}
+
+// keyGenSemaphore returns the semaphore channel bounding concurrent key
+// generation for keySize, lazily creating it on first use, or nil if no
+// limit is configured.
+func keyGenSemaphore(keySize int) chan struct{} {
+	keyGenMu.Lock()
+	defer keyGenMu.Unlock()
+
+	if keyGenMaxConcurrency <= 0 {
+		return nil
+	}
+
+	sem, ok := keyGenSemaphores[keySize]
+	if !ok {
+		sem = make(chan struct{}, keyGenMaxConcurrency)
+		keyGenSemaphores[keySize] = sem
+	}
+	return sem
+}
+
 func GenerateRSAPrivateKey(keySize int) (*rsa.PrivateKey, error) {
+	if sem := keyGenSemaphore(keySize); sem != nil {
+		sem <- struct{}{}
+		defer func() { <-sem }()
+	}
+
 	return rsa.GenerateKey(rand.Reader, keySize)
 }
 
+// PrivateKeyMatchesSize returns true if key's modulus length matches
+// keySize. It is used to detect a stored private key that no longer
+// matches the Certificate's requested key size, so that it can be
+// regenerated rather than silently reused.
+func PrivateKeyMatchesSize(key *rsa.PrivateKey, keySize int) bool {
+	return key.N.BitLen() == keySize
+}
+
 func EncodePKCS1PrivateKey(pk *rsa.PrivateKey) []byte {
 	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(pk)}
 
@@ -0,0 +1,63 @@
+package pki
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestGenerateRSAPrivateKeyConcurrencyLimit verifies that SetMaxConcurrentKeyGen
+// bounds the number of concurrent GenerateRSAPrivateKey calls for a given key
+// size, and that clearing the limit (setting it to zero) removes the bound.
+func TestGenerateRSAPrivateKeyConcurrencyLimit(t *testing.T) {
+	defer SetMaxConcurrentKeyGen(0)
+
+	const limit = 2
+	const callers = 6
+	SetMaxConcurrentKeyGen(limit)
+
+	var inFlight int32
+	var maxObserved int32
+	var wg sync.WaitGroup
+
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			sem := keyGenSemaphore(512)
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				max := atomic.LoadInt32(&maxObserved)
+				if n <= max || atomic.CompareAndSwapInt32(&maxObserved, max, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+		}()
+	}
+
+	wg.Wait()
+
+	if maxObserved > limit {
+		t.Errorf("expected at most %d concurrent callers, observed %d", limit, maxObserved)
+	}
+	if maxObserved < limit {
+		t.Errorf("expected concurrency to reach the configured limit of %d, observed only %d", limit, maxObserved)
+	}
+}
+
+// TestGenerateRSAPrivateKeyUnlimitedByDefault verifies that
+// GenerateRSAPrivateKey succeeds with no configured limit.
+func TestGenerateRSAPrivateKeyUnlimitedByDefault(t *testing.T) {
+	SetMaxConcurrentKeyGen(0)
+
+	if _, err := GenerateRSAPrivateKey(512); err != nil {
+		t.Fatalf("error generating key with no concurrency limit configured: %s", err.Error())
+	}
+}
@@ -44,3 +44,42 @@ func DecodeX509CertificateBytes(certBytes []byte) (*x509.Certificate, error) {
 func DecodeDERCertificateBytes(derBytes []byte) (*x509.Certificate, error) {
 	return x509.ParseCertificate(derBytes)
 }
+
+// DecodeX509CertificateChainBytes decodes zero or more concatenated PEM
+// certificate blocks, such as a CA bundle, returning one *x509.Certificate
+// per block in the order they appear.
+func DecodeX509CertificateChainBytes(chainBytes []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+
+	rest := chainBytes
+	for len(rest) > 0 {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.NewInvalidData("error parsing certificate in chain: %s", err.Error())
+		}
+		certs = append(certs, cert)
+	}
+
+	return certs, nil
+}
+
+// CertificateMatchesKey returns true if cert's public key corresponds to key.
+func CertificateMatchesKey(cert *x509.Certificate, key *rsa.PrivateKey) bool {
+	certPubKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return false
+	}
+	return certPubKey.N.Cmp(key.N) == 0 && certPubKey.E == key.E
+}
+
+// CertificateSignedBy returns true if cert's signature was produced by ca's
+// private key, i.e. ca directly signed cert.
+func CertificateSignedBy(cert, ca *x509.Certificate) bool {
+	return cert.CheckSignatureFrom(ca) == nil
+}
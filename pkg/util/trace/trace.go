@@ -0,0 +1,70 @@
+// Package trace provides a minimal span-timing primitive for instrumenting
+// the certificate issuance pipeline (reconcile -> key generation -> sign/
+// order -> secret write). It is not an OpenTelemetry integration: this repo
+// vendors its dependencies with dep and go.opentelemetry.io is not among
+// them, so spans recorded here are logged via glog rather than exported over
+// OTLP. If OpenTelemetry (or another exporter) is vendored in future, this
+// package is the natural place to make StartSpan emit real spans instead of
+// log lines, without having to touch any of its callers.
+package trace
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// Attr is a single key/value attribute attached to a span, such as the
+// certificate name being issued.
+type Attr struct {
+	Key   string
+	Value string
+}
+
+// Span represents a single named unit of work started by StartSpan. It is
+// not safe for concurrent use from multiple goroutines.
+type Span struct {
+	name  string
+	start time.Time
+	attrs []Attr
+}
+
+// StartSpan begins a span called name, logging it as started and returning a
+// Span whose End method must be called to record its completion. attrs are
+// logged alongside the span's duration when it ends.
+//
+// ctx is accepted so that call sites read the same way they would against a
+// context-aware tracing API, and so a future real exporter can thread
+// trace/span IDs through it, but no value is currently read from it.
+func StartSpan(ctx context.Context, name string, attrs ...Attr) *Span {
+	glog.V(4).Infof("trace: %s: started%s", name, formatAttrs(attrs))
+	return &Span{
+		name:  name,
+		start: time.Now(),
+		attrs: attrs,
+	}
+}
+
+// End records the completion of s, logging its total duration alongside the
+// attributes it was started with.
+func (s *Span) End() {
+	glog.V(4).Infof("trace: %s: finished duration=%s%s", s.name, time.Since(s.start), formatAttrs(s.attrs))
+}
+
+// CertificateAttr is a convenience constructor for the "certificate"
+// attribute, which every span in the issuance pipeline is expected to carry.
+func CertificateAttr(namespace, name string) Attr {
+	return Attr{Key: "certificate", Value: namespace + "/" + name}
+}
+
+func formatAttrs(attrs []Attr) string {
+	if len(attrs) == 0 {
+		return ""
+	}
+	s := ""
+	for _, a := range attrs {
+		s += " " + a.Key + "=" + a.Value
+	}
+	return s
+}
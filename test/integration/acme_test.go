@@ -0,0 +1,31 @@
+// Package integration contains tests that exercise cert-manager's
+// controllers against real dependencies (a pebble ACME server) rather than
+// fakes, using an injectable clock so renewal and backoff timing remain
+// deterministic even though the ACME flow itself is real.
+package integration
+
+import (
+	"testing"
+
+	"github.com/jetstack-experimental/cert-manager/test/integration/pebble"
+)
+
+// TestACMEDirectoryReachable starts a local pebble instance and checks that
+// its ACME directory is reachable, as a smoke test for the harness other
+// ACME issuer integration tests (order creation, challenge solving,
+// finalization) are expected to build on. It is skipped, not failed, when
+// no pebble binary is available in the test environment.
+func TestACMEDirectoryReachable(t *testing.T) {
+	p, err := pebble.Start()
+	if err == pebble.ErrNotFound {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatalf("error starting pebble: %s", err.Error())
+	}
+	defer p.Stop()
+
+	if p.DirectoryURL == "" {
+		t.Fatalf("expected a non-empty pebble directory URL")
+	}
+}
@@ -32,6 +32,7 @@ import (
 	"io"
 	"io/ioutil"
 	"math/big"
+	"net"
 	"net/http"
 	"strconv"
 	"strings"
@@ -354,12 +355,19 @@ func (c *Client) Authorize(ctx context.Context, domain string) (*Authorization,
 		Type  string `json:"type"`
 		Value string `json:"value"`
 	}
+	// identifierType is "ip" for IP addresses (RFC 8738) and "dns" for
+	// everything else, so that callers can pass IP literals through the
+	// same Authorize call used for domain names.
+	identifierType := "dns"
+	if net.ParseIP(domain) != nil {
+		identifierType = "ip"
+	}
 	req := struct {
 		Resource   string  `json:"resource"`
 		Identifier authzID `json:"identifier"`
 	}{
 		Resource:   "new-authz",
-		Identifier: authzID{Type: "dns", Value: domain},
+		Identifier: authzID{Type: identifierType, Value: domain},
 	}
 	res, err := c.retryPostJWS(ctx, c.Key, c.dir.AuthzURL, req)
 	if err != nil {